@@ -0,0 +1,23 @@
+package gosybase
+
+import sybase "github.com/CatHood0/Go-Sybase/internal"
+
+// ActiveQuery is a query sent to the bridge that has not answered yet.
+type ActiveQuery = sybase.ActiveQuery
+
+// ActiveQueries returns the queries the application has outstanding against
+// the server, oldest first. Their MsgID can be passed to Cancel. Backends
+// that do not track their queries return none.
+//
+//	for _, query := range db.ActiveQueries() {
+//		if time.Since(query.StartedAt) > time.Minute {
+//			db.Cancel(query.MsgID)
+//		}
+//	}
+func (ds *Database) ActiveQueries() []ActiveQuery {
+	tracker, ok := ds.db.(interface{ ActiveQueries() []ActiveQuery })
+	if !ok {
+		return nil
+	}
+	return tracker.ActiveQueries()
+}
@@ -0,0 +1,286 @@
+// Package arrowexport converts query results into Apache Arrow record
+// batches, to hand Sybase data to analytics tooling and Parquet writers.
+//
+// It is a separate module so the Arrow dependency is only pulled in by
+// the applications that use it.
+package arrowexport
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	sybase "github.com/CatHood0/Go-Sybase/internal"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// defaultBatchSize is the number of rows per record batch when
+// Options.BatchSize is not set.
+const defaultBatchSize = 64 * 1024
+
+// dateLayout is the format used by the bridge for date and time columns.
+const dateLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// Options configures the conversion.
+type Options struct {
+	Columns   []string         // Columns and their order (default: those of the first result set)
+	BatchSize int              // Rows per record batch (default: 65536)
+	Allocator memory.Allocator // Allocator of the arrays (default: memory.DefaultAllocator)
+}
+
+// Schema infers the Arrow schema of rows. The bridge sends every number as
+// a JSON number, so numeric columns whose values are all integral map to
+// int64 and the rest to float64. Strings holding bridge dates map to UTC
+// millisecond timestamps and 0x hex strings (binary columns) to binary.
+// Columns with only NULL values map to string. Every field is nullable.
+func Schema(rows []map[string]any, columns []string) *arrow.Schema {
+	fields := make([]arrow.Field, len(columns))
+	for i, column := range columns {
+		fields[i] = arrow.Field{Name: column, Type: inferType(rows, column), Nullable: true}
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+// Records converts the rows of response into record batches. The caller
+// must Release every returned record.
+func Records(response *sybase.RawResponse, opts Options) ([]arrow.Record, error) {
+	columns := opts.Columns
+	if len(columns) == 0 && len(response.Columns) > 0 {
+		columns = response.Columns[0]
+	}
+	if len(columns) == 0 {
+		// bridges without the columnar format do not keep the order
+		columns = sortedColumns(response.Results)
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	allocator := opts.Allocator
+	if allocator == nil {
+		allocator = memory.DefaultAllocator
+	}
+
+	schema := Schema(response.Results, columns)
+	if len(response.ColumnTypes) > 0 && response.ColumnTypes[0] != nil {
+		schema = typedSchema(response.Results, columns, response.ColumnTypes[0])
+	}
+	builder := array.NewRecordBuilder(allocator, schema)
+	defer builder.Release()
+
+	var records []arrow.Record
+	release := func() {
+		for _, record := range records {
+			record.Release()
+		}
+	}
+
+	for start := 0; start < len(response.Results); start += batchSize {
+		end := min(start+batchSize, len(response.Results))
+		for _, row := range response.Results[start:end] {
+			for i, column := range columns {
+				if err := appendValue(builder.Field(i), row[column]); err != nil {
+					release()
+					return nil, fmt.Errorf("column %q: %w", column, err)
+				}
+			}
+		}
+		records = append(records, builder.NewRecord())
+	}
+	return records, nil
+}
+
+// jdbcTypes maps the java.sql.Types codes of the integer, floating point
+// and boolean columns to Arrow types.
+var jdbcTypes = map[int]arrow.DataType{
+	-7: arrow.FixedWidthTypes.Boolean, // BIT
+	16: arrow.FixedWidthTypes.Boolean, // BOOLEAN
+	-6: arrow.PrimitiveTypes.Int64,    // TINYINT
+	5:  arrow.PrimitiveTypes.Int64,    // SMALLINT
+	4:  arrow.PrimitiveTypes.Int64,    // INTEGER
+	-5: arrow.PrimitiveTypes.Int64,    // BIGINT
+	6:  arrow.PrimitiveTypes.Float64,  // FLOAT
+	7:  arrow.PrimitiveTypes.Float64,  // REAL
+	8:  arrow.PrimitiveTypes.Float64,  // DOUBLE
+}
+
+// typedSchema builds the Arrow schema from the column types reported by
+// the bridge, inferring only the types it cannot map (see Schema).
+func typedSchema(rows []map[string]any, columns []string, types []sybase.ColumnType) *arrow.Schema {
+	fields := make([]arrow.Field, len(columns))
+	for i, column := range columns {
+		fields[i] = arrow.Field{Name: column, Type: inferType(rows, column), Nullable: true}
+
+		index := slices.IndexFunc(types, func(t sybase.ColumnType) bool { return t.Name == column })
+		if index < 0 {
+			continue
+		}
+		switch columnType := types[index]; {
+		case columnType.IsDecimal():
+			fields[i].Type = arrow.PrimitiveTypes.Float64
+		case columnType.IsBinary():
+			fields[i].Type = arrow.BinaryTypes.Binary
+		case columnType.IsTemporal() && columnType.JDBCType != 92:
+			// TIME columns carry no date and stay strings
+			fields[i].Type = &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}
+		default:
+			if dataType, ok := jdbcTypes[columnType.JDBCType]; ok {
+				fields[i].Type = dataType
+			}
+		}
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+// inferType returns the Arrow type of the values of column.
+func inferType(rows []map[string]any, column string) arrow.DataType {
+	seen := false
+	numbers, integers, bools, dates, binary := true, true, true, true, true
+	for _, row := range rows {
+		value := row[column]
+		if value == nil {
+			continue
+		}
+		seen = true
+
+		number, isNumber := value.(float64)
+		numbers = numbers && isNumber
+		integers = integers && isNumber && number == math.Trunc(number) && math.Abs(number) < 1<<53
+
+		_, isBool := value.(bool)
+		bools = bools && isBool
+
+		str, isString := value.(string)
+		dates = dates && isString && isDate(str)
+		binary = binary && isString && isHex(str)
+	}
+
+	switch {
+	case !seen:
+		return arrow.BinaryTypes.String
+	case integers:
+		return arrow.PrimitiveTypes.Int64
+	case numbers:
+		return arrow.PrimitiveTypes.Float64
+	case bools:
+		return arrow.FixedWidthTypes.Boolean
+	case dates:
+		return &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}
+	case binary:
+		return arrow.BinaryTypes.Binary
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+// appendValue appends value to a builder of the type inferred for it.
+func appendValue(builder array.Builder, value any) error {
+	if value == nil {
+		builder.AppendNull()
+		return nil
+	}
+
+	switch b := builder.(type) {
+	case *array.Int64Builder:
+		number, err := toInt64(value)
+		if err != nil {
+			return err
+		}
+		b.Append(number)
+	case *array.Float64Builder:
+		number, err := toFloat64(value)
+		if err != nil {
+			return err
+		}
+		b.Append(number)
+	case *array.BooleanBuilder:
+		b.Append(value.(bool))
+	case *array.TimestampBuilder:
+		date, ok := value.(time.Time)
+		if !ok {
+			var err error
+			if date, err = time.Parse(dateLayout, fmt.Sprint(value)); err != nil {
+				return err
+			}
+		}
+		b.Append(arrow.Timestamp(date.UnixMilli()))
+	case *array.BinaryBuilder:
+		data, ok := value.([]byte)
+		if !ok {
+			var err error
+			if data, err = hex.DecodeString(strings.TrimPrefix(fmt.Sprint(value), "0x")); err != nil {
+				return err
+			}
+		}
+		b.Append(data)
+	case *array.StringBuilder:
+		switch v := value.(type) {
+		case string:
+			b.Append(v)
+		default:
+			b.Append(fmt.Sprint(v))
+		}
+	default:
+		return fmt.Errorf("unsupported builder %T", builder)
+	}
+	return nil
+}
+
+// toFloat64 converts a number of the bridge, a float64 or, with
+// Config.TypedValues, a json.Number.
+func toFloat64(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	}
+	return 0, fmt.Errorf("cannot convert %T to a number", value)
+}
+
+// toInt64 converts an integer of the bridge: a float64 or, with
+// Config.TypedValues, an int64.
+func toInt64(value any) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	}
+	return 0, fmt.Errorf("cannot convert %T to an integer", value)
+}
+
+func isDate(value string) bool {
+	_, err := time.Parse(dateLayout, value)
+	return err == nil
+}
+
+func isHex(value string) bool {
+	digits, ok := strings.CutPrefix(value, "0x")
+	if !ok || len(digits)%2 != 0 {
+		return false
+	}
+	_, err := hex.DecodeString(digits)
+	return err == nil
+}
+
+// sortedColumns returns the columns of the first row in alphabetical order.
+func sortedColumns(rows []map[string]any) []string {
+	if len(rows) == 0 {
+		return nil
+	}
+	columns := make([]string, 0, len(rows[0]))
+	for column := range rows[0] {
+		columns = append(columns, column)
+	}
+	slices.Sort(columns)
+	return columns
+}
@@ -0,0 +1,20 @@
+module github.com/CatHood0/Go-Sybase/arrowexport
+
+go 1.24.3
+
+require (
+	github.com/CatHood0/Go-Sybase v0.0.0
+	github.com/apache/arrow-go/v18 v18.4.0
+)
+
+require (
+	github.com/goccy/go-json v0.10.5 // indirect
+	github.com/google/flatbuffers v25.2.10+incompatible // indirect
+	github.com/klauspost/cpuid/v2 v2.2.11 // indirect
+	github.com/zeebo/xxh3 v1.0.2 // indirect
+	golang.org/x/exp v0.0.0-20250408133849-7e4ce0ab07d0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/xerrors v0.0.0-20240903120638-7835f813f4da // indirect
+)
+
+replace github.com/CatHood0/Go-Sybase => ../
@@ -0,0 +1,54 @@
+package gosybase
+
+import (
+	builder "github.com/CatHood0/Go-Sybase/builders"
+	sybase "github.com/CatHood0/Go-Sybase/internal"
+)
+
+// Backend runs the queries of a Database. Connect and ConnectWithConfigs
+// use the TDSLink bridge; tests can use a fake one instead (see the
+// gosybasetest package).
+type Backend interface {
+	Raw(sql string) (*RawResponse, error)
+	Begin() (BackendTx, error)
+	Disconnect() error
+}
+
+// BackendTx runs the queries of a transaction started by a Backend.
+type BackendTx interface {
+	Raw(sql string) (*RawResponse, error)
+	Commit() error
+	Rollback() error
+}
+
+// NewDatabase creates a connected Database running its queries on backend.
+// The server is not queried on creation, so ServerInfo only reports dialect.
+func NewDatabase(backend Backend, dialect builder.Dialect) *Database {
+	return &Database{
+		db:         backend,
+		dialect:    dialect,
+		serverInfo: ServerInfo{Product: dialect},
+		Connected:  true,
+	}
+}
+
+// bridge adapts the TDSLink bridge to Backend.
+type bridge struct {
+	*sybase.Sybase
+}
+
+func (b bridge) Begin() (BackendTx, error) {
+	tx, err := b.Sybase.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (b bridge) NewSession() (BackendSession, error) {
+	session, err := b.Sybase.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
@@ -0,0 +1,125 @@
+package gosybase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+// selectPrefix matches the leading SELECT (and DISTINCT) of a query, after
+// which TOP and START AT go.
+var selectPrefix = regexp.MustCompile(`(?is)^\s*SELECT(\s+DISTINCT)?\s`)
+
+// FindInBatches runs query a page of batchSize rows at a time with TOP and
+// START AT, calling fn with each page, so ETL jobs bound their memory
+// without paginating by hand. The query must start with SELECT and should
+// ORDER BY a unique key, or the pages may overlap. An error from fn stops
+// the iteration and is returned.
+//
+// ASE cannot skip rows (see Dialect.SupportsOffset); use FindInBatchesBy,
+// which pages by key and works with every dialect.
+func (ds *Database) FindInBatches(query string, batchSize int, fn func(rows []map[string]any) error) error {
+	if batchSize <= 0 {
+		return fmt.Errorf("invalid batch size %d", batchSize)
+	}
+	if !ds.dialect.SupportsOffset() {
+		return fmt.Errorf("%s cannot skip rows: use FindInBatchesBy", ds.dialect)
+	}
+	prefix := selectPrefix.FindStringIndex(query)
+	if prefix == nil {
+		return errors.New("FindInBatches requires a SELECT query")
+	}
+
+	// the pages are built statements; only the query itself is checked
+	if err := ds.lint(context.Background(), query); err != nil {
+		return err
+	}
+
+	for offset := 0; ; offset += batchSize {
+		// START AT is 1-based
+		page := query[:prefix[1]] + "TOP " + strconv.Itoa(batchSize) +
+			" START AT " + strconv.Itoa(offset+1) + " " + query[prefix[1]:]
+		response, err := ds.RawQueryContext(AllowUnsafe(context.Background()), page)
+		if err != nil {
+			return err
+		}
+		if len(response.Results) > 0 {
+			if err := fn(response.Results); err != nil {
+				return err
+			}
+		}
+		if len(response.Results) < batchSize {
+			return nil
+		}
+	}
+}
+
+// FindInBatchesBy runs query a page of batchSize rows at a time, calling fn
+// with each page. Pages are read in keyColumn order, each one starting
+// after the last key of the previous page (key-set pagination), so the key
+// must be unique and not null, and named keyColumn in the result. The query
+// runs as a derived table, so it cannot have ORDER BY and its columns must
+// have names. An error from fn stops the iteration and is returned.
+//
+//	err := db.FindInBatchesBy("SELECT id, total FROM orders WHERE year = 2024", "id", 1000,
+//		func(rows []map[string]any) error {
+//			return export(rows)
+//		})
+func (ds *Database) FindInBatchesBy(query, keyColumn string, batchSize int, fn func(rows []map[string]any) error) error {
+	if batchSize <= 0 {
+		return fmt.Errorf("invalid batch size %d", batchSize)
+	}
+
+	// the pages are built statements; only the query itself is checked
+	if err := ds.lint(context.Background(), query); err != nil {
+		return err
+	}
+
+	var lastKey any
+	for first := true; ; first = false {
+		// the query is a derived table of its own, so the key filter is a
+		// WHERE of the page whatever the query contains
+		source := builder.NewSelect().WithDialect(ds.dialect).
+			SelectColumns("*").
+			From("(" + query + ") gosybase_query")
+		page := ds.NewSelect().
+			SelectColumns("*").
+			FromSubquery(source, "gosybase_batch")
+		if !first {
+			page = page.Where(keyColumn + " > " + ds.dialect.Literal(lastKey))
+		}
+		page = page.OrderByAsc(keyColumn).Limit(batchSize)
+
+		// built without the schema resolver, which would qualify the
+		// derived table
+		statement, err := buildQuery(page, ds.dialect)
+		if err != nil {
+			return err
+		}
+		response, err := ds.RawQueryContext(AllowUnsafe(context.Background()), statement)
+		if err != nil {
+			return err
+		}
+
+		rows := response.Results
+		if len(rows) == 0 {
+			return nil
+		}
+		key, ok := rows[len(rows)-1][keyColumn]
+		if !ok || key == nil {
+			return fmt.Errorf("key column %q missing or null in the result set", keyColumn)
+		}
+		lastKey = key
+
+		if err := fn(rows); err != nil {
+			return err
+		}
+		if len(rows) < batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package gosybase_test
+
+import (
+	"testing"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+	"github.com/CatHood0/Go-Sybase/gosybasetest"
+)
+
+func TestFindInBatchesByFiltersPagesWithWhere(t *testing.T) {
+	bridge := gosybasetest.NewBridge().
+		On(`WHERE id > 2`, gosybasetest.Response{}).
+		Default(gosybasetest.Response{Rows: []map[string]any{{"id": 1.0}, {"id": 2.0}}})
+	db := bridge.Open(builder.DialectSQLAnywhere)
+
+	pages := 0
+	err := db.FindInBatchesBy("SELECT id FROM orders WHERE year = 2024 AND status = 1 OR id = 0", "id", 2,
+		func(rows []map[string]any) error {
+			pages++
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pages != 1 {
+		t.Fatalf("pages = %d, want 1", pages)
+	}
+
+	queries := bridge.Queries()
+	if len(queries) != 2 {
+		t.Fatalf("queries = %q", queries)
+	}
+	want := "SELECT TOP 2 * FROM (SELECT * FROM (SELECT id FROM orders WHERE year = 2024 AND status = 1 OR id = 0) gosybase_query) gosybase_batch WHERE id > 2 ORDER BY id ASC;"
+	if queries[1] != want {
+		t.Fatalf("second page = %q\nwant %q", queries[1], want)
+	}
+}
@@ -0,0 +1,57 @@
+package gosybasebuilder
+
+import "testing"
+
+func BenchmarkSelectBuildSQL(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_, err := NewSelect().
+			SelectColumns("u.id", "u.name", "o.total").
+			From("users u").
+			InnerJoin("orders o", "o.user_id = u.id").
+			WhereEq("u.active", 1).
+			And().
+			WhereValue("o.total", ">", 100).
+			OrderByDesc("o.total").
+			Limit(50).
+			BuildSQL()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSelectBuildSQLWithArgs(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _, err := NewSelect().
+			UseArgs().
+			SelectColumns("id", "name").
+			From("users").
+			WhereEq("active", 1).
+			And().
+			WhereEq("name", "Ana").
+			BuildSQLWithArgs()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkInsertBuildSQL(b *testing.B) {
+	rows := make([][]any, 100)
+	for i := range rows {
+		rows[i] = []any{i, "user", 10.5}
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		_, err := NewInsert().
+			InsertTo("users").
+			ToColumns("id", "name", "balance").
+			ValuesRows(rows).
+			BuildSQL()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,12 +1,113 @@
-package gosybasebuilder 
+package gosybasebuilder
+
+import (
+	"strconv"
+)
+
+// ConditionType indica el tipo de cláusula que representa una Condition y
+// determina cómo se formatea en BuildQueryStr.
+type ConditionType int
+
+const (
+	ConditionColumns               ConditionType = iota + 1 // Columnas de SELECT/INSERT o asignaciones de UPDATE
+	ConditionJoin                                           // JOIN: query=tipo y tabla, where=condición ON
+	ConditionLimit                                          // TOP n
+	ConditionOffset                                         // START AT n
+	ConditionGroupBy                                        // GROUP BY
+	ConditionOrder                                          // Primera columna de ORDER BY
+	ConditionContinueOrder                                  // Columnas siguientes de ORDER BY
+	ConditionWhere                                          // Primera condición de WHERE
+	ConditionContinueWhere                                  // Condiciones siguientes a un AND/OR
+	ConditionArgs                                           // Fragmento SQL literal (AND, OR, DISTINCT, tabla de INSERT...)
+	ConditionPrimaryTableSelection                          // Tabla principal como fragmento literal
+	ConditionFrom                                           // FROM: query=tabla, where y args se añaden a continuación
+	ConditionToValue                                        // Primera fila de VALUES
+	ConditionContinueInsertions                             // Filas siguientes de VALUES y sus separadores
+	ConditionFromUpdate                                     // Tabla de UPDATE: query=tabla, args=SET, where=WHERE
+	ConditionDelete                                         // Tabla de DELETE: query=tabla, where=WHERE
+	ConditionCompute                                        // COMPUTE: query=agregados, args=columnas del BY
+)
+
+// String devuelve el nombre del tipo de condición.
+func (t ConditionType) String() string {
+	switch t {
+	case ConditionColumns:
+		return "columns"
+	case ConditionJoin:
+		return "join"
+	case ConditionLimit:
+		return "limit"
+	case ConditionOffset:
+		return "offset"
+	case ConditionGroupBy:
+		return "groupBy"
+	case ConditionOrder:
+		return "order"
+	case ConditionContinueOrder:
+		return "continue_order"
+	case ConditionWhere:
+		return "where"
+	case ConditionContinueWhere:
+		return "continue_where"
+	case ConditionArgs:
+		return "args"
+	case ConditionPrimaryTableSelection:
+		return "primary_table_selection"
+	case ConditionFrom:
+		return "from"
+	case ConditionToValue:
+		return "to_value"
+	case ConditionContinueInsertions:
+		return "continue_insertions"
+	case ConditionFromUpdate:
+		return "from_update"
+	case ConditionDelete:
+		return "delete"
+	case ConditionCompute:
+		return "compute"
+	default:
+		return "ConditionType(" + strconv.Itoa(int(t)) + ")"
+	}
+}
 
 // Condition representa una parte de una consulta SQL con todos sus componentes.
 // Se utiliza para construir consultas SQL de manera programática.
 type Condition struct {
-	TypeQuery string
+	TypeQuery ConditionType
 	Query     string
 	Where     string
 	Args      string
+
+	// table y qualified guardan la tabla tal como se indicó y el nombre con
+	// esquema que aparece en Query, para poder resolver los esquemas al
+	// ejecutar la consulta (ver SchemaResolvable).
+	table     string
+	qualified string
+
+	// sub y subquery guardan la consulta de una tabla derivada o de un
+	// INSERT ... SELECT y su texto en Query, para resolver también sus esquemas.
+	sub      *SelectQuery
+	subquery string
+}
+
+// NewCondition crea una condición del tipo indicado con su fragmento SQL principal.
+// Ejemplo: NewCondition(ConditionWhere, "edad > 18")
+func NewCondition(typeQuery ConditionType, query string) Condition {
+	return Condition{TypeQuery: typeQuery, Query: query}
+}
+
+// WithWhere devuelve una copia de la condición con el fragmento where indicado
+// (la condición ON de un JOIN o el WHERE de UPDATE/DELETE).
+func (c Condition) WithWhere(where string) Condition {
+	c.Where = where
+	return c
+}
+
+// WithArgs devuelve una copia de la condición con los argumentos indicados
+// (dirección de ORDER BY, columnas de COMPUTE BY, asignaciones SET...).
+func (c Condition) WithArgs(args string) Condition {
+	c.Args = args
+	return c
 }
 
 // BuildSelect construye y devuelve la parte SQL correspondiente a la condición,
@@ -36,39 +137,45 @@ func (c *Condition) BuildQueryStr(isLast bool, isLastColumn bool) string {
 	}
 
 	switch typeQuery {
-	case "columns":
+	case ConditionColumns:
 		if isLastColumn {
 			return query + end
 		}
 		return query + ", "
-	case "join":
+	case ConditionJoin:
 		return query + " ON " + where + end
-	case "limit":
+	case ConditionLimit:
 		return "TOP " + query + args + end
-	case "offset":
+	case ConditionOffset:
 		return "START AT " + query + args + end
-	case "groupBy":
+	case ConditionGroupBy:
 		return "GROUP BY " + query + args + end
-	case "order":
+	case ConditionOrder:
 		return "ORDER BY " + query + " " + args + end
-	case "continue_order":
+	case ConditionContinueOrder:
 		return query + " " + args + end
-	case "where":
+	case ConditionCompute:
+		// Para COMPUTE: query=agregados, args=columnas del BY (opcional)
+		if args == "" {
+			return "COMPUTE " + query + end
+		}
+		return "COMPUTE " + query + " BY " + args + end
+	case ConditionWhere:
 		return "WHERE " + query + end
-	case "continue_where":
+	case ConditionContinueWhere:
 		return query + end
-	case "args", "primary_table_selection":
+	case ConditionArgs, ConditionPrimaryTableSelection:
 		return query + end
-	case "from":
+	case ConditionFrom:
 		return "FROM " + query + where + args + end
-	case "to_value":
+	case ConditionToValue:
 		return " VALUES " + query
-	case "continue_insertions":
+	case ConditionContinueInsertions:
 		return query
-	case "from_update":
+	case ConditionFromUpdate:
 		// Para UPDATE: query=tabla, args=valores SET, where=condiciones WHERE
 		return query + " SET " + args + " " + where + end
-	case "delete":
+	case ConditionDelete:
 		// Para DELETE: query=tabla, where=condiciones WHERE
 		return query + " " + where + end
 	default:
@@ -0,0 +1,240 @@
+package gosybasebuilder
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ColumnDefinition describe una columna de una sentencia CREATE TABLE.
+type ColumnDefinition struct {
+	Name       string // Nombre de la columna
+	Type       string // Tipo de Sybase (ej: "varchar(50)", "numeric(10,0)", "datetime")
+	Nullable   bool   // Permite NULL (por defecto las columnas son NOT NULL)
+	Default    any    // Valor por defecto, convertido a literal (nil => sin DEFAULT)
+	Identity   bool   // Columna IDENTITY (implica NOT NULL)
+	hasDefault bool
+}
+
+// CreateTableQuery representa una sentencia CREATE TABLE con sus columnas
+// y restricciones. Permite generar DDL para migraciones y fixtures de pruebas.
+type CreateTableQuery struct {
+	Table       string
+	Columns     []ColumnDefinition
+	PrimaryKeys []string
+	Uniques     [][]string
+	Schemas     map[string]string
+	dialect     Dialect
+	immutable   bool
+}
+
+// NewCreateTable crea una nueva instancia de CreateTableQuery para la tabla indicada.
+func NewCreateTable(table string) *CreateTableQuery {
+	return &CreateTableQuery{Table: table, Columns: []ColumnDefinition{}, Schemas: map[string]string{}}
+}
+
+// DefineSchemas configura los esquemas de base de datos para las tablas.
+// La clave "general" aplica a todas las tablas.
+func (q *CreateTableQuery) DefineSchemas(schemas map[string]string) *CreateTableQuery {
+	q = q.mutable()
+	q.Schemas = schemas
+	return q
+}
+
+// Clone devuelve una copia independiente de la sentencia, incluyendo sus
+// columnas, restricciones y esquemas.
+func (q *CreateTableQuery) Clone() *CreateTableQuery {
+	clone := *q
+	clone.Columns = slices.Clone(q.Columns)
+	clone.PrimaryKeys = slices.Clone(q.PrimaryKeys)
+	clone.Uniques = make([][]string, len(q.Uniques))
+	for i, unique := range q.Uniques {
+		clone.Uniques[i] = slices.Clone(unique)
+	}
+	clone.Schemas = maps.Clone(q.Schemas)
+	return &clone
+}
+
+// Immutable devuelve una copia de la consulta en modo inmutable: cada método
+// encadenado devuelve una nueva copia en lugar de modificar la consulta, por
+// lo que una consulta base puede compartirse entre goroutines sin riesgo.
+// Ejemplo: base := NewCreateTable("t").Column("id", "int").Immutable(); a := base.Column("nombre", "varchar(50)")
+func (q *CreateTableQuery) Immutable() *CreateTableQuery {
+	clone := q.Clone()
+	clone.immutable = true
+	return clone
+}
+
+// mutable devuelve la consulta a modificar: una copia en modo inmutable o
+// la misma consulta en caso contrario.
+func (q *CreateTableQuery) mutable() *CreateTableQuery {
+	if !q.immutable {
+		return q
+	}
+	return q.Clone()
+}
+
+// WithDialect establece la variante de Sybase para la que se genera el SQL.
+// Por defecto se usa DialectASE.
+func (q *CreateTableQuery) WithDialect(dialect Dialect) *CreateTableQuery {
+	q = q.mutable()
+	q.dialect = dialect
+	return q
+}
+
+// Column añade una columna NOT NULL con su tipo de Sybase.
+// Los métodos Nullable, Default e Identity modifican la última columna añadida.
+// Ejemplo: Column("nombre", "varchar(50)").Nullable()
+func (q *CreateTableQuery) Column(name string, sqlType string) *CreateTableQuery {
+	q = q.mutable()
+	q.Columns = append(q.Columns, ColumnDefinition{Name: name, Type: sqlType})
+	return q
+}
+
+// AddColumn añade una columna a partir de su definición completa.
+func (q *CreateTableQuery) AddColumn(column ColumnDefinition) *CreateTableQuery {
+	q = q.mutable()
+	column.hasDefault = column.hasDefault || column.Default != nil
+	q.Columns = append(q.Columns, column)
+	return q
+}
+
+// Nullable permite valores NULL en la última columna añadida.
+func (q *CreateTableQuery) Nullable() *CreateTableQuery {
+	q = q.mutable()
+	if len(q.Columns) == 0 {
+		return q
+	}
+	q.Columns[len(q.Columns)-1].Nullable = true
+	return q
+}
+
+// Default establece el valor por defecto de la última columna añadida.
+// El valor se convierte en literal SQL (ver Dialect.Literal); nil genera DEFAULT NULL.
+// Ejemplo: Column("activo", "bit").Default(true)
+func (q *CreateTableQuery) Default(value any) *CreateTableQuery {
+	q = q.mutable()
+	if len(q.Columns) == 0 {
+		return q
+	}
+	q.Columns[len(q.Columns)-1].Default = value
+	q.Columns[len(q.Columns)-1].hasDefault = true
+	return q
+}
+
+// Identity marca la última columna añadida como IDENTITY.
+// Ejemplo: Column("id", "numeric(10,0)").Identity()
+func (q *CreateTableQuery) Identity() *CreateTableQuery {
+	q = q.mutable()
+	if len(q.Columns) == 0 {
+		return q
+	}
+	q.Columns[len(q.Columns)-1].Identity = true
+	return q
+}
+
+// PrimaryKey define la clave primaria de la tabla.
+// Ejemplo: PrimaryKey("id") o PrimaryKey("pedido_id", "linea")
+func (q *CreateTableQuery) PrimaryKey(columns ...string) *CreateTableQuery {
+	q = q.mutable()
+	q.PrimaryKeys = columns
+	return q
+}
+
+// Unique añade una restricción UNIQUE sobre una o varias columnas.
+func (q *CreateTableQuery) Unique(columns ...string) *CreateTableQuery {
+	q = q.mutable()
+	if len(columns) == 0 {
+		return q
+	}
+	q.Uniques = append(q.Uniques, columns)
+	return q
+}
+
+// Validate comprueba que la tabla tenga nombre y que las columnas de las
+// restricciones PRIMARY KEY y UNIQUE estén definidas.
+func (q *CreateTableQuery) Validate() error {
+	if q.Table == "" {
+		return queryError("CREATE TABLE without table name")
+	}
+
+	defined := make(map[string]bool, len(q.Columns))
+	for _, column := range q.Columns {
+		if column.Name == "" || column.Type == "" {
+			return queryError("column definition without name or type")
+		}
+		defined[column.Name] = true
+	}
+	for _, constraint := range append([][]string{q.PrimaryKeys}, q.Uniques...) {
+		for _, column := range constraint {
+			if !defined[column] {
+				return queryError("constraint on undefined column %q", column)
+			}
+		}
+	}
+	return nil
+}
+
+// BuildSQL construye y devuelve la sentencia CREATE TABLE completa.
+// Retorna cadena vacía si no hay columnas definidas, o el error de validación
+// (ver Validate).
+func (q *CreateTableQuery) BuildSQL() (string, error) {
+	if len(q.Columns) == 0 {
+		return "", nil
+	}
+	if err := q.Validate(); err != nil {
+		return "", err
+	}
+
+	definitions := make([]string, 0, len(q.Columns)+len(q.Uniques)+1)
+	for _, column := range q.Columns {
+		definitions = append(definitions, q.buildColumn(column))
+	}
+	if len(q.PrimaryKeys) > 0 {
+		definitions = append(definitions, "PRIMARY KEY ("+strings.Join(q.PrimaryKeys, ", ")+")")
+	}
+	for _, unique := range q.Uniques {
+		definitions = append(definitions, "UNIQUE ("+strings.Join(unique, ", ")+")")
+	}
+
+	return "CREATE TABLE " + getCreateTableSchema(q.Table, q) + " (" + strings.Join(definitions, ", ") + ");", nil
+}
+
+// BuildSQLWithArgs implementa QueryBuilder. Los valores van incrustados en el
+// SQL como literales, por lo que args siempre es nil.
+func (q *CreateTableQuery) BuildSQLWithArgs() (string, []any, error) {
+	query, err := q.BuildSQL()
+	return query, nil, err
+}
+
+// buildColumn construye la definición de una columna siguiendo el orden de
+// Sybase: nombre, tipo, DEFAULT y luego IDENTITY / NULL / NOT NULL.
+func (q *CreateTableQuery) buildColumn(column ColumnDefinition) string {
+	definition := column.Name + " " + column.Type
+	if column.hasDefault && !column.Identity {
+		definition += " DEFAULT " + q.dialect.Literal(column.Default)
+	}
+
+	switch {
+	case column.Identity:
+		definition += " IDENTITY"
+	case column.Nullable:
+		definition += " NULL"
+	default:
+		definition += " NOT NULL"
+	}
+	return definition
+}
+
+// getCreateTableSchema aplica los esquemas definidos al nombre de la tabla.
+func getCreateTableSchema(table string, q *CreateTableQuery) string {
+	schema := q.Schemas[table]
+	if schema == "" {
+		schema = q.Schemas["general"]
+	}
+
+	if schema == "" {
+		return table
+	}
+	return schema + "." + table
+}
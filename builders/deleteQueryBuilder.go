@@ -1,8 +1,9 @@
 package gosybasebuilder
 
 import (
+	"maps"
+	"slices"
 	"strings"
-
 )
 
 // DeleteQuery representa una consulta DELETE de SQL y sus componentes.
@@ -10,8 +11,15 @@ import (
 // - Conditions: Un slice de condiciones que forman partes de la consulta DELETE
 // - Schemas: Un mapa que define esquemas de base de datos para diferentes tablas
 type DeleteQuery struct {
-	Conditions []Condition
-	Schemas    map[string]string
+	Conditions       []Condition
+	Schemas          map[string]string
+	dialect          Dialect
+	err              error
+	useArgs          bool
+	args             []any
+	immutable        bool
+	softDeleteColumn string
+	unscoped         bool
 }
 
 // New crea y devuelve una nueva instancia de DeleteQuery inicializada.
@@ -23,33 +31,94 @@ func NewDelete() *DeleteQuery {
 //
 // - schemas: Mapa donde las claves son nombres de tabla y los valores son nombres de esquema
 func (q *DeleteQuery) DefineSchemas(schemas map[string]string) *DeleteQuery {
+	q = q.mutable()
 	q.Schemas = schemas
 	return q
 }
 
+// Clone devuelve una copia independiente de la consulta, incluyendo sus
+// condiciones y esquemas, para reutilizar una consulta base sin modificarla.
+func (q *DeleteQuery) Clone() *DeleteQuery {
+	clone := *q
+	clone.Conditions = slices.Clone(q.Conditions)
+	clone.Schemas = maps.Clone(q.Schemas)
+	clone.args = slices.Clone(q.args)
+	return &clone
+}
+
+// Immutable devuelve una copia de la consulta en modo inmutable: cada método
+// encadenado devuelve una nueva copia en lugar de modificar la consulta, por
+// lo que una consulta base puede compartirse entre goroutines sin riesgo.
+// Ejemplo: base := NewDelete().From("t").Immutable(); a := base.WhereEq("id", 1)
+func (q *DeleteQuery) Immutable() *DeleteQuery {
+	clone := q.Clone()
+	clone.immutable = true
+	return clone
+}
+
+// mutable devuelve la consulta a modificar: una copia en modo inmutable o
+// la misma consulta en caso contrario.
+func (q *DeleteQuery) mutable() *DeleteQuery {
+	if !q.immutable {
+		return q
+	}
+	return q.Clone()
+}
+
+// UseArgs activa el modo argumentos: los valores de Go (WhereValue, WhereEq,
+// WhereNotEq) se generan como marcadores ? y se devuelven en
+// BuildSQLWithArgs, para ejecutar la misma consulta con distintos valores
+// (ver Database.Prepare).
+func (q *DeleteQuery) UseArgs() *DeleteQuery {
+	q = q.mutable()
+	q.useArgs = true
+	return q
+}
+
+// bind convierte un valor en literal o marcador según el modo argumentos.
+func (q *DeleteQuery) bind(value any) string {
+	return bindValue(q.dialect, q.useArgs, &q.args, value)
+}
+
+// WithDialect establece la variante de Sybase para la que se genera el SQL.
+// Por defecto se usa DialectASE.
+func (q *DeleteQuery) WithDialect(dialect Dialect) *DeleteQuery {
+	q = q.mutable()
+	q.dialect = dialect
+	return q
+}
+
 // From establece la tabla principal para la consulta DELETE.
 //
 // - from: Nombre de la tabla de la que se eliminarán registros
 func (q *DeleteQuery) From(from string) *DeleteQuery {
-	q.Conditions = append(q.Conditions, Condition{TypeQuery: "delete", Query: getDeleteSchema(from, q)})
+	q = q.mutable()
+	qualified := getDeleteSchema(from, q)
+	q.Conditions = append(q.Conditions, Condition{TypeQuery: ConditionDelete, Query: qualified, table: from, qualified: qualified})
 	return q
 }
 
 // Where añade una condición WHERE simple a la consulta.
+// Si se llama antes de From, se registra un error (ver Err).
 //
 // - where: Condición WHERE como cadena SQL
 func (q *DeleteQuery) Where(where string) *DeleteQuery {
-	last := q.Conditions[len(q.Conditions)-1]
-	if strings.Contains(last.Query, "AND") || strings.Contains(last.Query, "OR") {
+	q = q.mutable()
+	last, ok := lastCondition(q.Conditions)
+	if !ok {
+		q.setErr(queryError("WHERE requires a FROM clause"))
+		return q
+	}
+	if continuesWhere(last) {
 		q.Conditions = append(q.Conditions, Condition{
-			TypeQuery: "continue_where",
+			TypeQuery: ConditionContinueWhere,
 			Query:     where,
 		})
 		return q
 	}
 
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "where",
+		TypeQuery: ConditionWhere,
 		Query:     where,
 	})
 	return q
@@ -60,6 +129,7 @@ func (q *DeleteQuery) Where(where string) *DeleteQuery {
 // - from: Nombre de la columna
 // - to: Valor a comparar
 func (q *DeleteQuery) WhereEquals(from string, to string) *DeleteQuery {
+	q = q.mutable()
 	q = q.Where(from + " = " + to)
 	return q
 }
@@ -69,15 +139,42 @@ func (q *DeleteQuery) WhereEquals(from string, to string) *DeleteQuery {
 // - from: Nombre de la columna
 // - to: Valor a comparar
 func (q *DeleteQuery) WhereNotEquals(from string, to string) *DeleteQuery {
+	q = q.mutable()
 	q = q.Where(from + " != " + to)
 	return q
 }
 
+// WhereValue añade una condición WHERE comparando una columna con un valor
+// de Go, que se convierte en literal SQL (ver Dialect.Literal).
+//
+// Ejemplo: WhereValue("total", ">=", 100.5)
+func (q *DeleteQuery) WhereValue(column string, operator string, value any) *DeleteQuery {
+	q = q.mutable()
+	return q.Where(buildComparison(column, operator, value, q.bind))
+}
+
+// WhereEq añade una condición de igualdad con un valor de Go.
+// Un valor nil genera IS NULL.
+//
+// Ejemplo: WhereEq("nombre", "Juan") => nombre = 'Juan'
+func (q *DeleteQuery) WhereEq(column string, value any) *DeleteQuery {
+	q = q.mutable()
+	return q.WhereValue(column, "=", value)
+}
+
+// WhereNotEq añade una condición de desigualdad con un valor de Go.
+// Un valor nil genera IS NOT NULL.
+func (q *DeleteQuery) WhereNotEq(column string, value any) *DeleteQuery {
+	q = q.mutable()
+	return q.WhereValue(column, "!=", value)
+}
+
 // Like añade una condición WHERE con operador LIKE.
 //
 // - from: Nombre de la columna
 // - to: Patrón de búsqueda
 func (q *DeleteQuery) Like(from string, to string) *DeleteQuery {
+	q = q.mutable()
 	q = q.Where(from + " LIKE " + "'" + to + "'")
 	return q
 }
@@ -87,33 +184,68 @@ func (q *DeleteQuery) Like(from string, to string) *DeleteQuery {
 // - from: Nombre de la columna
 // - to: Patrón de búsqueda
 func (q *DeleteQuery) NotLike(from string, to string) *DeleteQuery {
+	q = q.mutable()
 	q = q.Where(from + " NOT LIKE " + "'" + to + "'")
 	return q
 }
 
 // Or añade un operador OR lógico entre condiciones WHERE.
 func (q *DeleteQuery) Or() *DeleteQuery {
-	q.Conditions = append(q.Conditions, Condition{TypeQuery: "args", Query: "OR"})
+	q = q.mutable()
+	q.Conditions = append(q.Conditions, Condition{TypeQuery: ConditionArgs, Query: "OR"})
 	return q
 }
 
 // And añade un operador AND lógico entre condiciones WHERE.
 func (q *DeleteQuery) And() *DeleteQuery {
-	q.Conditions = append(q.Conditions, Condition{TypeQuery: "args", Query: "AND"})
+	q = q.mutable()
+	q.Conditions = append(q.Conditions, Condition{TypeQuery: ConditionArgs, Query: "AND"})
 	return q
 }
 
+// Err devuelve el primer error registrado al encadenar métodos, si existe.
+func (q *DeleteQuery) Err() error {
+	return q.err
+}
+
+// Validate comprueba que la consulta empiece con From y que las condiciones
+// WHERE aparezcan después de la tabla.
+func (q *DeleteQuery) Validate() error {
+	if q.err != nil {
+		return q.err
+	}
+	if first, ok := firstCondition(q.Conditions); ok && first.TypeQuery != ConditionDelete {
+		return queryError("DELETE must start with From")
+	}
+	return validateWhereOrder(q.Conditions, ConditionDelete)
+}
+
 // BuildSQL construye y devuelve la cadena SQL completa para la consulta DELETE.
 //
 // Retorna:
 //   - string: La consulta SQL completa
 //   - string vacío si no hay condiciones definidas
-func (q *DeleteQuery) BuildSQL() string {
+//   - error: El primer error registrado o de validación (ver Validate)
+func (q *DeleteQuery) BuildSQL() (string, error) {
+	if err := q.Validate(); err != nil {
+		return "", err
+	}
 	conditions := q.Conditions
 	if len(conditions) == 0 {
-		return ""
+		return "", nil
 	}
 	query := "DELETE FROM "
+	if q.softDeleteColumn != "" && !q.unscoped {
+		// el borrado lógico es un UPDATE de la columna de las filas no borradas
+		conditions = scopeSoftDelete(conditions, q.softDeleteColumn)
+		conditions[0] = Condition{
+			TypeQuery: ConditionFromUpdate,
+			Query:     conditions[0].Query,
+			Where:     conditions[0].Where,
+			Args:      q.softDeleteColumn + " = getdate()",
+		}
+		query = "UPDATE "
+	}
 	length := len(conditions)
 
 	for i := range length {
@@ -123,7 +255,24 @@ func (q *DeleteQuery) BuildSQL() string {
 		}
 		query += strings.TrimRight(conditions[i].BuildQueryStr(i+1 >= length, true), " ") + end
 	}
-	return query
+	return query, nil
+}
+
+// BuildSQLWithArgs implementa QueryBuilder. En modo argumentos (ver UseArgs)
+// devuelve los valores de cada marcador ?, en orden; si no, args es nil.
+func (q *DeleteQuery) BuildSQLWithArgs() (string, []any, error) {
+	query, err := q.BuildSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	return query, slices.Clone(q.args), nil
+}
+
+// setErr registra el error si todavía no hay uno.
+func (q *DeleteQuery) setErr(err error) {
+	if q.err == nil {
+		q.err = err
+	}
 }
 
 // getDeleteSchema obtiene el esquema apropiado para una tabla basado en la configuración.
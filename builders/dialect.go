@@ -0,0 +1,197 @@
+package gosybasebuilder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialect indica la variante de Sybase para la que se genera el SQL.
+// Controla la sintaxis de paginación, el formato de los identificadores
+// entre comillas y el formato de los literales de fecha.
+type Dialect int
+
+const (
+	// DialectASE genera SQL para SAP ASE (Adaptive Server Enterprise).
+	// Pagina con SET ROWCOUNT y no soporta offset.
+	DialectASE Dialect = iota
+	// DialectSQLAnywhere genera SQL para SAP SQL Anywhere (ASA).
+	// Pagina con TOP n START AT m.
+	DialectSQLAnywhere
+	// DialectIQ genera SQL para SAP IQ, que comparte la sintaxis de SQL Anywhere.
+	DialectIQ
+)
+
+const (
+	aseDateFormat      = "20060102 15:04:05.000"
+	anywhereDateFormat = "2006-01-02 15:04:05.000"
+)
+
+// String devuelve el nombre legible del dialecto.
+func (d Dialect) String() string {
+	switch d {
+	case DialectASE:
+		return "ASE"
+	case DialectSQLAnywhere:
+		return "SQL Anywhere"
+	case DialectIQ:
+		return "IQ"
+	default:
+		return "Dialect(" + strconv.Itoa(int(d)) + ")"
+	}
+}
+
+// SupportsOffset indica si el dialecto puede omitir registros (START AT).
+func (d Dialect) SupportsOffset() bool {
+	return d != DialectASE
+}
+
+// QuoteIdentifier encierra un identificador (tabla, columna) entre los
+// delimitadores del dialecto. Los identificadores compuestos como
+// "esquema.tabla" se delimitan por partes.
+//
+// Ejemplo: QuoteIdentifier("dbo.user") => [dbo].[user] en ASE
+func (d Dialect) QuoteIdentifier(identifier string) string {
+	parts := strings.Split(identifier, ".")
+	for i, part := range parts {
+		if d == DialectASE {
+			parts[i] = "[" + strings.ReplaceAll(part, "]", "]]") + "]"
+			continue
+		}
+		parts[i] = "\"" + strings.ReplaceAll(part, "\"", "\"\"") + "\""
+	}
+	return strings.Join(parts, ".")
+}
+
+// DateLiteral convierte una fecha en un literal SQL entre comillas simples
+// con el formato que el dialecto interpreta sin ambigüedad.
+//
+// Ejemplo: '20240131 13:45:00.000' en ASE, '2024-01-31 13:45:00.000' en SQL Anywhere
+func (d Dialect) DateLiteral(date time.Time) string {
+	if d == DialectASE {
+		return "'" + date.Format(aseDateFormat) + "'"
+	}
+	return "'" + date.Format(anywhereDateFormat) + "'"
+}
+
+// Literal convierte un valor de Go en un literal SQL del dialecto:
+//   - nil y punteros nulos => NULL
+//   - string => entre comillas simples, duplicando las comillas internas
+//   - bool => 1 o 0
+//   - enteros y flotantes => su representación decimal
+//   - time.Time => DateLiteral
+//   - []byte => literal hexadecimal 0x...
+//
+// Cualquier otro tipo se convierte con fmt.Sprint y se trata como string.
+func (d Dialect) Literal(value any) string {
+	if value == nil {
+		return "NULL"
+	}
+
+	switch v := value.(type) {
+	case string:
+		return quoteString(v)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case time.Time:
+		return d.DateLiteral(v)
+	case []byte:
+		if v == nil {
+			return "NULL"
+		}
+		return "0x" + hex.EncodeToString(v)
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if reflected.IsNil() {
+			return "NULL"
+		}
+		return d.Literal(reflected.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(reflected.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(reflected.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(reflected.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(reflected.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return d.Literal(reflected.Bool())
+	case reflect.String:
+		return quoteString(reflected.String())
+	}
+	return quoteString(fmt.Sprint(value))
+}
+
+// quoteString encierra una cadena entre comillas simples duplicando las
+// comillas simples internas, que es el escape estándar de Sybase.
+func quoteString(str string) string {
+	return "'" + EscapeString(str) + "'"
+}
+
+// Bind reemplaza cada marcador ? de la consulta por el literal del argumento
+// correspondiente (ver Literal). Los ? dentro de literales, identificadores
+// delimitados o comentarios no se consideran marcadores.
+func (d Dialect) Bind(query string, args ...any) (string, error) {
+	var out strings.Builder
+	next := 0
+	for i := 0; i < len(query); i++ {
+		if end := skipComment(query, i); end > i {
+			out.WriteString(query[i:end])
+			i = end - 1
+			continue
+		}
+		switch query[i] {
+		case '\'', '"', '[':
+			end := skipQuoted(query, i)
+			out.WriteString(query[i:end])
+			i = end - 1
+		case '?':
+			if next >= len(args) {
+				return "", queryError("missing argument for placeholder %d", next+1)
+			}
+			out.WriteString(d.Literal(args[next]))
+			next++
+		default:
+			out.WriteByte(query[i])
+		}
+	}
+	if next != len(args) {
+		return "", queryError("%d placeholders for %d arguments", next, len(args))
+	}
+	return out.String(), nil
+}
+
+// CountPlaceholders devuelve el número de marcadores ? de la consulta (ver
+// Placeholders).
+func CountPlaceholders(query string) int {
+	return len(Placeholders(query))
+}
+
+// Placeholders devuelve las posiciones de los marcadores ? de la consulta,
+// sin contar los que están dentro de literales, identificadores delimitados
+// o comentarios, igual que Bind.
+func Placeholders(query string) []int {
+	var positions []int
+	for i := 0; i < len(query); i++ {
+		if end := skipComment(query, i); end > i {
+			i = end - 1
+			continue
+		}
+		switch query[i] {
+		case '\'', '"', '[':
+			i = skipQuoted(query, i) - 1
+		case '?':
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
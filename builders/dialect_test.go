@@ -0,0 +1,52 @@
+package gosybasebuilder
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBindSkipsCommentsAndLiterals(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		args  []any
+		want  string
+	}{
+		{"line comment", "SELECT a -- why?\nFROM t WHERE id = ?", []any{1}, "SELECT a -- why?\nFROM t WHERE id = 1"},
+		{"line comment at the end", "SELECT a FROM t WHERE id = ? -- ok?", []any{1}, "SELECT a FROM t WHERE id = 1 -- ok?"},
+		{"block comment with apostrophe", "SELECT a /* don't */ FROM t WHERE id = ? AND n = 'x'", []any{1}, "SELECT a /* don't */ FROM t WHERE id = 1 AND n = 'x'"},
+		{"unclosed block comment", "SELECT a FROM t WHERE id = ? /* ?", []any{1}, "SELECT a FROM t WHERE id = 1 /* ?"},
+		{"comment markers in literal", "SELECT '--?' AS a, ? AS b", []any{2}, "SELECT '--?' AS a, 2 AS b"},
+		{"quoted identifiers", `SELECT "a?", [b?] FROM t WHERE c = ?`, []any{"x"}, `SELECT "a?", [b?] FROM t WHERE c = 'x'`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := DialectASE.Bind(test.query, test.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Fatalf("Bind = %q, want %q", got, test.want)
+			}
+			if count := CountPlaceholders(test.query); count != len(test.args) {
+				t.Fatalf("CountPlaceholders = %d, want %d", count, len(test.args))
+			}
+		})
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"SELECT ? /* ? */ -- ?\n, ?", []int{7, 24}},
+		{"SELECT 'it''s ?' , ?", []int{19}},
+		{"SELECT a - ? FROM t", []int{11}},
+	}
+	for _, test := range tests {
+		if got := Placeholders(test.query); !slices.Equal(got, test.want) {
+			t.Fatalf("Placeholders(%q) = %v, want %v", test.query, got, test.want)
+		}
+	}
+}
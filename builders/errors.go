@@ -0,0 +1,56 @@
+package gosybasebuilder
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidQuery envuelve todos los errores de construcción y validación de
+// los builders, de forma que puedan identificarse con errors.Is.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// queryError crea un error de construcción que envuelve ErrInvalidQuery.
+func queryError(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{ErrInvalidQuery}, args...)...)
+}
+
+// lastCondition devuelve la última condición añadida, o false si no hay ninguna.
+func lastCondition(conditions []Condition) (Condition, bool) {
+	if len(conditions) == 0 {
+		return Condition{}, false
+	}
+	return conditions[len(conditions)-1], true
+}
+
+// continuesWhere indica si la condición es el operador AND u OR de And/Or,
+// tras el que la siguiente condición continúa el WHERE en lugar de abrirlo.
+// Se decide por el tipo de la condición: el texto de un FROM o de otra
+// condición puede contener AND u OR.
+func continuesWhere(condition Condition) bool {
+	return condition.TypeQuery == ConditionArgs && (condition.Query == "AND" || condition.Query == "OR")
+}
+
+// validateWhereOrder comprueba que las cláusulas WHERE, JOIN, GROUP BY y
+// ORDER BY aparezcan después de la cláusula que define la tabla (fromType).
+func validateWhereOrder(conditions []Condition, fromType ConditionType) error {
+	seenFrom := false
+	for _, condition := range conditions {
+		switch condition.TypeQuery {
+		case fromType:
+			seenFrom = true
+		case ConditionWhere, ConditionContinueWhere, ConditionJoin, ConditionGroupBy, ConditionOrder, ConditionContinueOrder:
+			if !seenFrom {
+				return queryError("%s clause before the table clause", condition.TypeQuery)
+			}
+		}
+	}
+	return nil
+}
+
+// firstCondition devuelve la primera condición añadida, o false si no hay ninguna.
+func firstCondition(conditions []Condition) (Condition, bool) {
+	if len(conditions) == 0 {
+		return Condition{}, false
+	}
+	return conditions[0], true
+}
@@ -0,0 +1,195 @@
+package gosybasebuilder
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ProcParam representa un parámetro con nombre de un procedimiento almacenado.
+type ProcParam struct {
+	Name   string // Nombre del parámetro, incluyendo @ (ej: "@id")
+	Value  any    // Valor del parámetro de entrada, convertido a literal
+	Output bool   // Indica si es un parámetro de salida (output)
+	Type   string // Tipo de Sybase para declarar la variable de salida (opcional)
+}
+
+// ExecProcQuery representa la ejecución de un procedimiento almacenado
+// con parámetros con nombre y marcadores de salida.
+type ExecProcQuery struct {
+	Procedure string
+	Params    []ProcParam
+	Schemas   map[string]string
+	dialect   Dialect
+	useArgs   bool
+	immutable bool
+}
+
+// NewExec crea una nueva instancia de ExecProcQuery para el procedimiento indicado.
+// Ejemplo: NewExec("sp_totals").Param("@id", 5).OutParam("@total").As("money")
+func NewExec(procedure string) *ExecProcQuery {
+	return &ExecProcQuery{Procedure: procedure, Params: []ProcParam{}, Schemas: map[string]string{}}
+}
+
+// DefineSchemas configura los esquemas de base de datos para los procedimientos.
+// La clave "general" aplica a todos los procedimientos.
+func (q *ExecProcQuery) DefineSchemas(schemas map[string]string) *ExecProcQuery {
+	q = q.mutable()
+	q.Schemas = schemas
+	return q
+}
+
+// Clone devuelve una copia independiente de la sentencia, incluyendo sus
+// parámetros y esquemas.
+func (q *ExecProcQuery) Clone() *ExecProcQuery {
+	clone := *q
+	clone.Params = slices.Clone(q.Params)
+	clone.Schemas = maps.Clone(q.Schemas)
+	return &clone
+}
+
+// Immutable devuelve una copia de la consulta en modo inmutable: cada método
+// encadenado devuelve una nueva copia en lugar de modificar la consulta, por
+// lo que una consulta base puede compartirse entre goroutines sin riesgo.
+// Ejemplo: base := NewExec("sp_totales").Immutable(); a := base.Param("@id", 1)
+func (q *ExecProcQuery) Immutable() *ExecProcQuery {
+	clone := q.Clone()
+	clone.immutable = true
+	return clone
+}
+
+// mutable devuelve la consulta a modificar: una copia en modo inmutable o
+// la misma consulta en caso contrario.
+func (q *ExecProcQuery) mutable() *ExecProcQuery {
+	if !q.immutable {
+		return q
+	}
+	return q.Clone()
+}
+
+// UseArgs activa el modo argumentos: los valores de los parámetros de
+// entrada se generan como marcadores ? y se devuelven en BuildSQLWithArgs
+// (ver Database.Prepare).
+func (q *ExecProcQuery) UseArgs() *ExecProcQuery {
+	q = q.mutable()
+	q.useArgs = true
+	return q
+}
+
+// WithDialect establece la variante de Sybase para la que se genera el SQL.
+// Por defecto se usa DialectASE.
+func (q *ExecProcQuery) WithDialect(dialect Dialect) *ExecProcQuery {
+	q = q.mutable()
+	q.dialect = dialect
+	return q
+}
+
+// Param añade un parámetro de entrada. El valor se convierte en literal SQL
+// (ver Dialect.Literal).
+// Ejemplo: Param("@id", 5) => @id = 5
+func (q *ExecProcQuery) Param(name string, value any) *ExecProcQuery {
+	q = q.mutable()
+	q.Params = append(q.Params, ProcParam{Name: paramName(name), Value: value})
+	return q
+}
+
+// OutParam añade un parámetro de salida, que se pasa como "@total = @total output".
+// Si la variable no se declara en el mismo lote, usar As para indicar su tipo.
+func (q *ExecProcQuery) OutParam(name string) *ExecProcQuery {
+	q = q.mutable()
+	q.Params = append(q.Params, ProcParam{Name: paramName(name), Output: true})
+	return q
+}
+
+// As establece el tipo de Sybase del último parámetro de salida añadido.
+// Con un tipo definido, la variable se declara antes del exec y su valor se
+// devuelve como una fila adicional (una columna por parámetro, sin la @).
+// Ejemplo: OutParam("@total").As("money")
+func (q *ExecProcQuery) As(sqlType string) *ExecProcQuery {
+	q = q.mutable()
+	if len(q.Params) == 0 || !q.Params[len(q.Params)-1].Output {
+		return q
+	}
+	q.Params[len(q.Params)-1].Type = sqlType
+	return q
+}
+
+// Validate comprueba que el procedimiento tenga nombre y que ningún
+// parámetro se repita.
+func (q *ExecProcQuery) Validate() error {
+	if q.Procedure == "" {
+		return queryError("exec without procedure name")
+	}
+	seen := make(map[string]bool, len(q.Params))
+	for _, param := range q.Params {
+		if seen[param.Name] {
+			return queryError("duplicated parameter %s", param.Name)
+		}
+		seen[param.Name] = true
+	}
+	return nil
+}
+
+// BuildSQL construye y devuelve la sentencia exec completa.
+// Retorna el error de validación si la sentencia es inválida (ver Validate).
+func (q *ExecProcQuery) BuildSQL() (string, error) {
+	query, _, err := q.build()
+	return query, err
+}
+
+// BuildSQLWithArgs implementa QueryBuilder. En modo argumentos (ver UseArgs)
+// devuelve los valores de cada marcador ?, en orden; si no, args es nil.
+func (q *ExecProcQuery) BuildSQLWithArgs() (string, []any, error) {
+	return q.build()
+}
+
+// build construye la sentencia exec y los argumentos de sus marcadores.
+func (q *ExecProcQuery) build() (string, []any, error) {
+	if err := q.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	var declarations, params, outputs []string
+	var args []any
+	for _, param := range q.Params {
+		if !param.Output {
+			params = append(params, param.Name+" = "+bindValue(q.dialect, q.useArgs, &args, param.Value))
+			continue
+		}
+		params = append(params, param.Name+" = "+param.Name+" output")
+		if param.Type != "" {
+			declarations = append(declarations, "declare "+param.Name+" "+param.Type)
+			outputs = append(outputs, param.Name+" as "+strings.TrimPrefix(param.Name, "@"))
+		}
+	}
+
+	query := "exec " + getExecSchema(q.Procedure, q)
+	if len(params) > 0 {
+		query += " " + strings.Join(params, ", ")
+	}
+	if len(declarations) > 0 {
+		query = strings.Join(declarations, " ") + " " + query + " select " + strings.Join(outputs, ", ")
+	}
+	return query + ";", args, nil
+}
+
+// paramName asegura que el nombre del parámetro empiece con @.
+func paramName(name string) string {
+	if strings.HasPrefix(name, "@") {
+		return name
+	}
+	return "@" + name
+}
+
+// getExecSchema aplica los esquemas definidos al nombre del procedimiento.
+func getExecSchema(procedure string, q *ExecProcQuery) string {
+	schema := q.Schemas[procedure]
+	if schema == "" {
+		schema = q.Schemas["general"]
+	}
+
+	if schema == "" {
+		return procedure
+	}
+	return schema + "." + procedure
+}
@@ -0,0 +1,223 @@
+package gosybasebuilder
+
+import (
+	"strings"
+	"unicode"
+)
+
+// clauseKeywords son las palabras clave que inician una nueva línea al
+// formatear. Las de varias palabras van primero para que tengan prioridad.
+var clauseKeywords = []string{
+	"SET ROWCOUNT", "INSERT INTO", "DELETE FROM", "INNER JOIN", "LEFT JOIN", "RIGHT JOIN",
+	"GROUP BY", "ORDER BY", "AT ISOLATION", "UNION ALL",
+	"SELECT", "UPDATE", "FROM", "WHERE", "HAVING", "VALUES", "SET", "UNION", "COMPUTE",
+	"AND", "OR",
+}
+
+// indentedKeywords son las palabras clave que se indentan bajo la cláusula anterior.
+var indentedKeywords = map[string]bool{"AND": true, "OR": true}
+
+// Format devuelve el SQL con una cláusula por línea, pensado para logs y
+// depuración de consultas largas. Los literales, los identificadores entre
+// delimitadores y las subconsultas entre paréntesis se mantienen intactos.
+//
+// Ejemplo:
+//
+//	SELECT a, b
+//	FROM t
+//	WHERE a = 1
+//	  AND b = 'x';
+func Format(sql string) string {
+	var out strings.Builder
+	depth := 0
+	betweenPending := false
+
+	for i := 0; i < len(sql); {
+		switch sql[i] {
+		case '\'', '"', '[':
+			end := skipQuoted(sql, i)
+			out.WriteString(sql[i:end])
+			i = end
+			continue
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ';':
+			out.WriteByte(';')
+			i++
+			for i < len(sql) && sql[i] == ' ' {
+				i++
+			}
+			if i < len(sql) {
+				out.WriteByte('\n')
+			}
+			continue
+		}
+
+		if depth == 0 && isWordStart(sql, i) {
+			if keyword := matchKeyword(sql, i); keyword != "" {
+				upper := strings.ToUpper(keyword)
+				if upper == "AND" && betweenPending {
+					betweenPending = false
+				} else if out.Len() > 0 && !strings.HasSuffix(out.String(), "\n") {
+					trimTrailingSpaces(&out)
+					out.WriteByte('\n')
+					if indentedKeywords[upper] {
+						out.WriteString("  ")
+					}
+				}
+				out.WriteString(keyword)
+				i += len(keyword)
+				continue
+			}
+			if hasWordAt(sql, i, "BETWEEN") {
+				betweenPending = true
+			}
+		}
+
+		out.WriteByte(sql[i])
+		i++
+	}
+	return out.String()
+}
+
+// BuildSQLPretty construye la consulta y la devuelve formateada con Format.
+func (q *SelectQuery) BuildSQLPretty() (string, error) {
+	query, err := q.BuildSQL()
+	return Format(query), err
+}
+
+// BuildSQLPretty construye la consulta y la devuelve formateada con Format.
+func (q *InsertQuery) BuildSQLPretty() (string, error) {
+	query, err := q.BuildSQL()
+	return Format(query), err
+}
+
+// BuildSQLPretty construye la consulta y la devuelve formateada con Format.
+func (q *UpdateQuery) BuildSQLPretty() (string, error) {
+	query, err := q.BuildSQL()
+	return Format(query), err
+}
+
+// BuildSQLPretty construye la consulta y la devuelve formateada con Format.
+func (q *DeleteQuery) BuildSQLPretty() (string, error) {
+	query, err := q.BuildSQL()
+	return Format(query), err
+}
+
+// BuildSQLPretty construye la sentencia y la devuelve con una columna por línea.
+func (q *CreateTableQuery) BuildSQLPretty() (string, error) {
+	query, err := q.BuildSQL()
+	if err != nil || query == "" {
+		return query, err
+	}
+	open := strings.Index(query, "(")
+	body := strings.TrimSuffix(query[open+1:], ");")
+	return query[:open] + "(\n  " + strings.Join(splitTopLevel(body), ",\n  ") + "\n);", nil
+}
+
+// BuildSQLPretty construye la sentencia y la devuelve formateada con Format.
+func (q *ExecProcQuery) BuildSQLPretty() (string, error) {
+	query, err := q.BuildSQL()
+	return Format(query), err
+}
+
+// skipQuoted devuelve la posición siguiente al final del literal o
+// identificador delimitado que empieza en start. Las comillas duplicadas
+// se consideran parte del literal.
+func skipQuoted(sql string, start int) int {
+	closing := sql[start]
+	if closing == '[' {
+		closing = ']'
+	}
+	for i := start + 1; i < len(sql); i++ {
+		if sql[i] != closing {
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == closing {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(sql)
+}
+
+// skipComment devuelve la posición siguiente al comentario que empieza en
+// start, o start si no empieza ninguno. Un comentario -- termina antes de su
+// salto de línea.
+func skipComment(sql string, start int) int {
+	switch {
+	case strings.HasPrefix(sql[start:], "--"):
+		if end := strings.IndexByte(sql[start:], '\n'); end >= 0 {
+			return start + end
+		}
+		return len(sql)
+	case strings.HasPrefix(sql[start:], "/*"):
+		if end := strings.Index(sql[start+2:], "*/"); end >= 0 {
+			return start + 2 + end + 2
+		}
+		return len(sql)
+	}
+	return start
+}
+
+// splitTopLevel separa por comas que no estén dentro de paréntesis ni literales.
+func splitTopLevel(str string) []string {
+	var parts []string
+	depth, last := 0, 0
+	for i := 0; i < len(str); i++ {
+		switch str[i] {
+		case '\'', '"', '[':
+			i = skipQuoted(str, i) - 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(str[last:i]))
+				last = i + 1
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(str[last:]))
+}
+
+// matchKeyword devuelve la palabra clave (con su capitalización original)
+// que empieza en la posición i, o cadena vacía si no hay ninguna.
+func matchKeyword(sql string, i int) string {
+	for _, keyword := range clauseKeywords {
+		if hasWordAt(sql, i, keyword) {
+			return sql[i : i+len(keyword)]
+		}
+	}
+	return ""
+}
+
+// hasWordAt indica si la palabra aparece completa en la posición i, sin
+// distinguir mayúsculas de minúsculas.
+func hasWordAt(sql string, i int, word string) bool {
+	end := i + len(word)
+	if end > len(sql) || !strings.EqualFold(sql[i:end], word) {
+		return false
+	}
+	return end == len(sql) || !isWordChar(rune(sql[end]))
+}
+
+// isWordStart indica si en la posición i empieza una palabra.
+func isWordStart(sql string, i int) bool {
+	return isWordChar(rune(sql[i])) && (i == 0 || !isWordChar(rune(sql[i-1])))
+}
+
+func isWordChar(r rune) bool {
+	return r == '_' || r == '@' || r == '#' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// trimTrailingSpaces elimina los espacios al final del contenido escrito.
+func trimTrailingSpaces(out *strings.Builder) {
+	trimmed := strings.TrimRight(out.String(), " ")
+	out.Reset()
+	out.WriteString(trimmed)
+}
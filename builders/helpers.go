@@ -0,0 +1,147 @@
+package gosybasebuilder
+
+import (
+	"reflect"
+	"strings"
+)
+
+// EscapeString escapa un valor para incrustarlo dentro de un literal SQL
+// entre comillas simples, duplicando las comillas simples internas.
+// Ejemplo: EscapeString("O'Hara") devuelve la cadena con la comilla duplicada
+func EscapeString(str string) string {
+	return strings.ReplaceAll(str, "'", "''")
+}
+
+// buildComparison construye una comparación entre una columna y un valor.
+// El valor se convierte con bind (literal o marcador ?). Las comparaciones
+// de igualdad con nil se traducen a IS NULL / IS NOT NULL.
+func buildComparison(column string, operator string, value any, bind func(any) string) string {
+	if isNull(value) {
+		switch operator {
+		case "=":
+			return column + " IS NULL"
+		case "!=", "<>":
+			return column + " IS NOT NULL"
+		}
+	}
+	return column + " " + operator + " " + bind(value)
+}
+
+// bindValue convierte un valor en literal SQL o, en modo argumentos, en un
+// marcador ? añadiendo el valor a args.
+func bindValue(dialect Dialect, useArgs bool, args *[]any, value any) string {
+	if !useArgs {
+		return dialect.Literal(value)
+	}
+	*args = append(*args, value)
+	return "?"
+}
+
+// isNull indica si el valor es nil o un puntero nulo.
+func isNull(value any) bool {
+	if value == nil {
+		return true
+	}
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return reflected.IsNil()
+	}
+	return false
+}
+
+// StructField describe un campo de struct mapeado a una columna mediante la
+// etiqueta `db`. Las opciones de la etiqueta se indican tras una coma:
+//   - identity: columna IDENTITY, se omite en INSERT y UPDATE
+//   - omitempty: se omite si el campo está en su valor cero
+type StructField struct {
+	Column    string // Nombre de la columna (etiqueta `db` o nombre del campo)
+	Field     string // Nombre del campo en el struct
+	Value     any    // Valor actual del campo
+	Identity  bool   // La etiqueta incluye la opción identity
+	OmitEmpty bool   // La etiqueta incluye la opción omitempty
+	Zero      bool   // El campo está en su valor cero
+}
+
+// StructFields recorre los campos exportados de un struct (o puntero a struct)
+// y devuelve su mapeo a columnas. Los campos con `db:"-"` se ignoran y los
+// structs embebidos sin etiqueta se recorren como si sus campos fueran propios.
+// Retorna nil si v no es un struct.
+func StructFields(v any) []StructField {
+	return structFields(reflect.ValueOf(v))
+}
+
+// structFields recorre el reflect.Value de StructFields. Los structs embebidos
+// se recorren sobre su reflect.Value y no con Interface(), que falla cuando el
+// tipo embebido no es exportado (`type base struct{ ID int }`).
+func structFields(value reflect.Value) []StructField {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []StructField
+	valueType := value.Type()
+	for i := range valueType.NumField() {
+		field := valueType.Field(i)
+		fieldValue := value.Field(i)
+		tag := field.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+
+		if field.Anonymous && tag == "" {
+			fields = append(fields, structFields(fieldValue)...)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, StructField{
+			Column:    name,
+			Field:     field.Name,
+			Value:     fieldValue.Interface(),
+			Identity:  hasTagOption(options, "identity"),
+			OmitEmpty: hasTagOption(options, "omitempty"),
+			Zero:      fieldValue.IsZero(),
+		})
+	}
+	return fields
+}
+
+// structColumns devuelve los nombres de columna y valores de un struct para
+// escribirlos (INSERT o UPDATE). Las columnas identity se omiten siempre, las
+// omitempty cuando están en cero, y todas las que están en cero si skipZero es true.
+func structColumns(v any, skipZero bool) ([]string, []any) {
+	var columns []string
+	var values []any
+	for _, field := range StructFields(v) {
+		if field.Identity || (field.Zero && (skipZero || field.OmitEmpty)) {
+			continue
+		}
+		columns = append(columns, field.Column)
+		values = append(values, field.Value)
+	}
+	return columns, values
+}
+
+// hasTagOption indica si la lista de opciones de una etiqueta contiene la opción.
+func hasTagOption(options string, option string) bool {
+	for _, current := range strings.Split(options, ",") {
+		if strings.TrimSpace(current) == option {
+			return true
+		}
+	}
+	return false
+}
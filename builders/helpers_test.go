@@ -0,0 +1,37 @@
+package gosybasebuilder
+
+import "testing"
+
+type auditFields struct {
+	CreatedBy string `db:"created_by"`
+}
+
+type base struct {
+	ID int `db:"id,identity"`
+	auditFields
+}
+
+type customer struct {
+	base
+	Name string `db:"name"`
+}
+
+func TestStructColumnsWithUnexportedEmbedded(t *testing.T) {
+	value := customer{base: base{ID: 7, auditFields: auditFields{CreatedBy: "ana"}}, Name: "Juan"}
+
+	update, err := NewUpdate().From("customers").SetStruct(value).WhereEq("id", 7).BuildSQL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "UPDATE customers SET created_by = 'ana', name = 'Juan' WHERE id = 7; "; update != want {
+		t.Fatalf("SetStruct = %q, want %q", update, want)
+	}
+
+	insert, err := NewInsert().InsertTo("customers").ValuesStruct(&value).BuildSQL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "INSERT INTO customers (created_by, name) VALUES ('ana', 'Juan');"; insert != want {
+		t.Fatalf("ValuesStruct = %q, want %q", insert, want)
+	}
+}
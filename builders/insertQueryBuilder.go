@@ -1,6 +1,9 @@
 package gosybasebuilder
 
 import (
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -12,6 +15,15 @@ import (
 type InsertQuery struct {
 	Conditions []Condition
 	Schemas    map[string]string
+	dialect    Dialect
+	rowCount   int
+	err        error
+	useArgs    bool
+	args       []any
+
+	columnCount int   // número de columnas definidas con ToColumn/ToColumns
+	valueCounts []int // número de valores de cada fila, para Validate
+	immutable   bool
 }
 
 // New crea y devuelve una nueva instancia de InsertQuery inicializada.
@@ -28,10 +40,64 @@ func NewInsert() *InsertQuery {
 // Retorna:
 //   - *InsertQuery: El mismo objeto InsertQuery para permitir encadenamiento de métodos
 func (q *InsertQuery) DefineSchemas(schemas map[string]string) *InsertQuery {
+	q = q.mutable()
 	q.Schemas = schemas
 	return q
 }
 
+// Clone devuelve una copia independiente de la consulta, incluyendo sus
+// condiciones y esquemas, para reutilizar una consulta base sin modificarla.
+func (q *InsertQuery) Clone() *InsertQuery {
+	clone := *q
+	clone.Conditions = slices.Clone(q.Conditions)
+	clone.Schemas = maps.Clone(q.Schemas)
+	clone.args = slices.Clone(q.args)
+	clone.valueCounts = slices.Clone(q.valueCounts)
+	return &clone
+}
+
+// Immutable devuelve una copia de la consulta en modo inmutable: cada método
+// encadenado devuelve una nueva copia en lugar de modificar la consulta, por
+// lo que una consulta base puede compartirse entre goroutines sin riesgo.
+// Ejemplo: base := NewInsert().InsertTo("t").ToColumns("id").Immutable(); a := base.ValuesOf(1)
+func (q *InsertQuery) Immutable() *InsertQuery {
+	clone := q.Clone()
+	clone.immutable = true
+	return clone
+}
+
+// mutable devuelve la consulta a modificar: una copia en modo inmutable o
+// la misma consulta en caso contrario.
+func (q *InsertQuery) mutable() *InsertQuery {
+	if !q.immutable {
+		return q
+	}
+	return q.Clone()
+}
+
+// UseArgs activa el modo argumentos: los valores de Go (WhereValue, WhereEq,
+// ValuesOf, ValuesRows) se generan como marcadores ? y se devuelven en
+// BuildSQLWithArgs, para ejecutar la misma consulta con distintos valores
+// (ver Database.Prepare).
+func (q *InsertQuery) UseArgs() *InsertQuery {
+	q = q.mutable()
+	q.useArgs = true
+	return q
+}
+
+// bind convierte un valor en literal o marcador según el modo argumentos.
+func (q *InsertQuery) bind(value any) string {
+	return bindValue(q.dialect, q.useArgs, &q.args, value)
+}
+
+// WithDialect establece la variante de Sybase para la que se genera el SQL.
+// Por defecto se usa DialectASE.
+func (q *InsertQuery) WithDialect(dialect Dialect) *InsertQuery {
+	q = q.mutable()
+	q.dialect = dialect
+	return q
+}
+
 // InsertTo especifica la tabla de destino para la inserción.
 // Parámetros:
 //   - to: Nombre de la tabla donde se insertarán los datos
@@ -39,7 +105,9 @@ func (q *InsertQuery) DefineSchemas(schemas map[string]string) *InsertQuery {
 // Retorna:
 //   - *InsertQuery: El mismo objeto InsertQuery para permitir encadenamiento de métodos
 func (q *InsertQuery) InsertTo(to string) *InsertQuery {
-	q.Conditions = append(q.Conditions, Condition{TypeQuery: "args", Query: getInsertSchema(to, q)})
+	q = q.mutable()
+	qualified := getInsertSchema(to, q)
+	q.Conditions = append(q.Conditions, Condition{TypeQuery: ConditionArgs, Query: qualified, table: to, qualified: qualified})
 	return q
 }
 
@@ -50,8 +118,10 @@ func (q *InsertQuery) InsertTo(to string) *InsertQuery {
 // Retorna:
 //   - *InsertQuery: El mismo objeto InsertQuery para permitir encadenamiento de métodos
 func (q *InsertQuery) ToColumn(column string) *InsertQuery {
+	q = q.mutable()
+	q.columnCount = 1
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "columns",
+		TypeQuery: ConditionColumns,
 		Query:     " (" + *trim(column) + ")",
 	})
 	return q
@@ -64,76 +134,223 @@ func (q *InsertQuery) ToColumn(column string) *InsertQuery {
 // Retorna:
 //   - *InsertQuery: El mismo objeto InsertQuery para permitir encadenamiento de métodos
 func (q *InsertQuery) ToColumns(columns ...string) *InsertQuery {
+	q = q.mutable()
+	q.columnCount = len(columns)
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "columns",
+		TypeQuery: ConditionColumns,
 		Query:     " (" + *trim(strings.Join(columns, ", ")) + ")",
 	})
 	return q
 }
 
 // Values especifica los valores a insertar para múltiples columnas.
+// Si se llama antes de InsertTo, se registra un error (ver Err).
 // Parámetros:
 //   - values: Valores a insertar (deben coincidir en número y orden con las columnas especificadas)
 //
 // Retorna:
 //   - *InsertQuery: El mismo objeto InsertQuery para permitir encadenamiento de métodos
 func (q *InsertQuery) Values(values ...string) *InsertQuery {
-	last := q.Conditions[len(q.Conditions)-1]
-	if last.TypeQuery == "continue_insertions" {
+	q = q.mutable()
+	last, ok := lastCondition(q.Conditions)
+	if !ok {
+		q.setErr(queryError("VALUES requires InsertTo"))
+		return q
+	}
+	q.valueCounts = append(q.valueCounts, len(values))
+	if last.TypeQuery == ConditionContinueInsertions {
 		q.Conditions = append(q.Conditions, Condition{
-			TypeQuery: "continue_insertions",
+			TypeQuery: ConditionContinueInsertions,
 			Query:     "(" + *trim(strings.Join(values, ", ")) + ")",
 		})
 		return q
 	}
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "to_value",
+		TypeQuery: ConditionToValue,
 		Query:     "(" + *trim(strings.Join(values, ", ")) + ")",
 	})
 	return q
 }
 
+// ValuesOf especifica los valores a insertar como valores de Go, que se
+// convierten en literales SQL según el dialecto (ver Dialect.Literal).
+// Parámetros:
+//   - values: Valores a insertar (string, números, bool, time.Time, []byte o nil)
+//
+// Retorna:
+//   - *InsertQuery: El mismo objeto InsertQuery para permitir encadenamiento de métodos
+func (q *InsertQuery) ValuesOf(values ...any) *InsertQuery {
+	q = q.mutable()
+	literals := make([]string, len(values))
+	for i, value := range values {
+		literals[i] = q.bind(value)
+	}
+	return q.Values(literals...)
+}
+
+// ValuesStruct define las columnas y los valores a insertar a partir de los
+// campos de un struct (ver StructFields). Los punteros nulos se insertan como
+// NULL y se omiten las columnas `db:",identity"` y las `db:",omitempty"` en cero.
+// Parámetros:
+//   - v: Struct o puntero a struct con etiquetas `db`
+//
+// Retorna:
+//   - *InsertQuery: El mismo objeto InsertQuery para permitir encadenamiento de métodos
+func (q *InsertQuery) ValuesStruct(v any) *InsertQuery {
+	q = q.mutable()
+	columns, values := structColumns(v, false)
+	if len(columns) == 0 {
+		q.setErr(queryError("struct without columns to insert"))
+		return q
+	}
+	return q.ToColumns(columns...).ValuesOf(values...)
+}
+
+// FromSelect inserta las filas devueltas por una consulta SELECT en lugar de
+// valores literales. Si la consulta tiene límite en ASE, el INSERT completo se
+// envuelve con SET ROWCOUNT.
+//
+// Ejemplo: InsertTo("orders_archive").ToColumns("id", "total").FromSelect(sel)
+// => INSERT INTO orders_archive (id, total) SELECT id, total FROM orders ...;
+func (q *InsertQuery) FromSelect(sel *SelectQuery) *InsertQuery {
+	q = q.mutable()
+	if sel == nil || len(sel.Conditions) == 0 {
+		return q
+	}
+	if err := sel.Validate(); err != nil {
+		q.setErr(err)
+		return q
+	}
+	if sel.dialect == DialectASE && sel.hasLimit {
+		q.rowCount = sel.limit
+	}
+	q.args = append(q.args, sel.args...)
+	subquery := strings.TrimSuffix(sel.buildSelect(), ";")
+	q.Conditions = append(q.Conditions, Condition{
+		TypeQuery: ConditionArgs,
+		Query:     " " + subquery,
+		sub:       sel.Clone(),
+		subquery:  subquery,
+	})
+	return q
+}
+
+// ValuesRows especifica varias filas de valores a insertar de una sola vez.
+// Cada valor se convierte en un literal SQL según el dialecto (ver Dialect.Literal),
+// por lo que no es necesario escaparlos previamente.
+//
+// En SQL Anywhere e IQ se genera un único VALUES con varias filas; en ASE,
+// que no lo soporta, se genera un lote de INSERT de una sola fila.
+//
+// Ejemplo: ValuesRows([][]any{{1, "Juan"}, {2, nil}})
+func (q *InsertQuery) ValuesRows(rows [][]any) *InsertQuery {
+	q = q.mutable()
+	for i, row := range rows {
+		if i > 0 {
+			q = q.And()
+		}
+		q = q.ValuesOf(row...)
+	}
+	return q
+}
+
 // And permite agregar múltiples conjuntos de valores en una sola consulta INSERT.
 // Retorna:
 //   - *InsertQuery: El mismo objeto InsertQuery para permitir encadenamiento de métodos
 func (q *InsertQuery) And() *InsertQuery {
+	q = q.mutable()
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "continue_insertions",
+		TypeQuery: ConditionContinueInsertions,
 		Query:     ", ",
 	})
 	return q
 }
 
 // Value especifica un solo valor a insertar (para una sola columna).
+// Si se llama antes de InsertTo, se registra un error (ver Err).
 // Parámetros:
 //   - value: Valor a insertar
 //
 // Retorna:
 //   - *InsertQuery: El mismo objeto InsertQuery para permitir encadenamiento de métodos
 func (q *InsertQuery) Value(value string) *InsertQuery {
-	last := q.Conditions[len(q.Conditions)-1]
+	q = q.mutable()
+	last, ok := lastCondition(q.Conditions)
+	if !ok {
+		q.setErr(queryError("VALUES requires InsertTo"))
+		return q
+	}
+	q.valueCounts = append(q.valueCounts, 1)
 
-	if last.TypeQuery == "continue_insertions" {
+	if last.TypeQuery == ConditionContinueInsertions {
 		q.Conditions = append(q.Conditions, Condition{
-			TypeQuery: "continue_insertions",
+			TypeQuery: ConditionContinueInsertions,
 			Query:     "(" + *trim(value) + ")",
 		})
 		return q
 	}
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "to_value",
+		TypeQuery: ConditionToValue,
 		Query:     "(" + *trim(value) + ")",
 	})
 	return q
 }
 
+// Err devuelve el primer error registrado al encadenar métodos, si existe.
+func (q *InsertQuery) Err() error {
+	return q.err
+}
+
+// Validate comprueba que la consulta empiece con InsertTo, que las columnas
+// se definan antes de los valores y que cada fila tenga tantos valores como
+// columnas definidas.
+// Retorna:
+//   - error: El primer error encontrado, o nil si la consulta es válida
+func (q *InsertQuery) Validate() error {
+	if q.err != nil {
+		return q.err
+	}
+	if first, ok := firstCondition(q.Conditions); ok && first.TypeQuery != ConditionArgs {
+		return queryError("INSERT must start with InsertTo")
+	}
+
+	seenValues := false
+	for _, condition := range q.Conditions {
+		switch condition.TypeQuery {
+		case ConditionToValue, ConditionContinueInsertions:
+			seenValues = true
+		case ConditionColumns:
+			if seenValues {
+				return queryError("columns defined after VALUES")
+			}
+		}
+	}
+
+	if q.columnCount == 0 {
+		return nil
+	}
+	for i, count := range q.valueCounts {
+		if count != q.columnCount {
+			return queryError("row %d has %d values for %d columns", i+1, count, q.columnCount)
+		}
+	}
+	return nil
+}
+
 // BuildSQL construye y devuelve la cadena SQL completa para la consulta de inserción.
 // Retorna:
 //   - string: La consulta SQL completa terminada con punto y coma
-func (q *InsertQuery) BuildSQL() string {
+//   - error: El primer error registrado o de validación (ver Validate)
+func (q *InsertQuery) BuildSQL() (string, error) {
+	if err := q.Validate(); err != nil {
+		return "", err
+	}
 	conditions := q.Conditions
 	if len(conditions) == 0 {
-		return ""
+		return "", nil
+	}
+	if q.dialect == DialectASE && q.hasMultipleRows() {
+		return q.buildBatch(), nil
 	}
 	query := "INSERT INTO "
 	length := len(conditions)
@@ -142,7 +359,7 @@ func (q *InsertQuery) BuildSQL() string {
 		var end string = ""
 		if i+1 >= length {
 			end = ";"
-		} else if q.Conditions[i].TypeQuery == "continue_insertions" {
+		} else if q.Conditions[i].TypeQuery == ConditionContinueInsertions {
 			query += conditions[i].BuildQueryStr(false, true)
 			continue
 		}
@@ -150,7 +367,56 @@ func (q *InsertQuery) BuildSQL() string {
 		query += *trimRight(conditions[i].BuildQueryStr(false, true)) + end
 
 	}
-	return query
+	if q.rowCount > 0 {
+		return "SET ROWCOUNT " + strconv.Itoa(q.rowCount) + " " + query + " SET ROWCOUNT 0", nil
+	}
+	return query, nil
+}
+
+// BuildSQLWithArgs implementa QueryBuilder. En modo argumentos (ver UseArgs)
+// devuelve los valores de cada marcador ?, en orden; si no, args es nil.
+func (q *InsertQuery) BuildSQLWithArgs() (string, []any, error) {
+	query, err := q.BuildSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	return query, slices.Clone(q.args), nil
+}
+
+// setErr registra el error si todavía no hay uno.
+func (q *InsertQuery) setErr(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
+
+// hasMultipleRows indica si la consulta inserta más de una fila en un solo VALUES.
+func (q *InsertQuery) hasMultipleRows() bool {
+	for _, condition := range q.Conditions {
+		if condition.TypeQuery == ConditionContinueInsertions {
+			return true
+		}
+	}
+	return false
+}
+
+// buildBatch construye un lote de INSERT de una sola fila, repitiendo la tabla
+// y las columnas para cada fila. Es la forma de insertar varias filas en ASE.
+func (q *InsertQuery) buildBatch() string {
+	var prefix string
+	var statements []string
+	for _, condition := range q.Conditions {
+		switch condition.TypeQuery {
+		case ConditionToValue, ConditionContinueInsertions:
+			if condition.Query == ", " {
+				continue
+			}
+			statements = append(statements, "INSERT INTO "+prefix+" VALUES "+condition.Query+";")
+		default:
+			prefix += *trimRight(condition.BuildQueryStr(false, true))
+		}
+	}
+	return strings.Join(statements, " ")
 }
 
 // getInsertSchema obtiene el esquema apropiado para una tabla basado en la configuración.
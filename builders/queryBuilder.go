@@ -0,0 +1,19 @@
+package gosybasebuilder
+
+// QueryBuilder es implementado por todos los builders y permite ejecutar las
+// consultas construidas directamente (ver Database.QueryBuilder y Database.ExecBuilder).
+//
+// Por defecto los valores se incrustan como literales en el SQL y args es nil.
+// En modo argumentos (UseArgs) el SQL lleva marcadores ? y args sus valores.
+type QueryBuilder interface {
+	BuildSQLWithArgs() (string, []any, error)
+}
+
+var (
+	_ QueryBuilder = (*SelectQuery)(nil)
+	_ QueryBuilder = (*InsertQuery)(nil)
+	_ QueryBuilder = (*UpdateQuery)(nil)
+	_ QueryBuilder = (*DeleteQuery)(nil)
+	_ QueryBuilder = (*CreateTableQuery)(nil)
+	_ QueryBuilder = (*ExecProcQuery)(nil)
+)
@@ -0,0 +1,111 @@
+package gosybasebuilder
+
+import (
+	"maps"
+	"strings"
+)
+
+// SchemaResolvable lo implementan los constructores cuyos esquemas pueden
+// resolverse al ejecutar la consulta en lugar de al construirla, por ejemplo
+// para elegir el esquema de cada cliente en una aplicación multi-tenant.
+type SchemaResolvable interface {
+	QueryBuilder
+	ResolveSchemas(schemas map[string]string) QueryBuilder
+}
+
+var (
+	_ SchemaResolvable = (*SelectQuery)(nil)
+	_ SchemaResolvable = (*InsertQuery)(nil)
+	_ SchemaResolvable = (*UpdateQuery)(nil)
+	_ SchemaResolvable = (*DeleteQuery)(nil)
+	_ SchemaResolvable = (*CreateTableQuery)(nil)
+	_ SchemaResolvable = (*ExecProcQuery)(nil)
+)
+
+// ResolveSchemas devuelve una copia de la consulta cuyas tablas se califican
+// con schemas, con el mismo formato que DefineSchemas. Los esquemas definidos
+// en la propia consulta tienen prioridad sobre los recibidos.
+func (q *SelectQuery) ResolveSchemas(schemas map[string]string) QueryBuilder {
+	clone := q.Clone()
+	clone.Schemas = mergeSchemas(schemas, q.Schemas)
+	requalify(clone.Conditions, schemas, func(table string) string { return getSelectSchema(table, clone) })
+	return clone
+}
+
+// ResolveSchemas devuelve una copia de la consulta cuya tabla se califica
+// con schemas (ver SelectQuery.ResolveSchemas).
+func (q *InsertQuery) ResolveSchemas(schemas map[string]string) QueryBuilder {
+	clone := q.Clone()
+	clone.Schemas = mergeSchemas(schemas, q.Schemas)
+	requalify(clone.Conditions, schemas, func(table string) string { return getInsertSchema(table, clone) })
+	return clone
+}
+
+// ResolveSchemas devuelve una copia de la consulta cuya tabla se califica
+// con schemas (ver SelectQuery.ResolveSchemas).
+func (q *UpdateQuery) ResolveSchemas(schemas map[string]string) QueryBuilder {
+	clone := q.Clone()
+	clone.Schemas = mergeSchemas(schemas, q.Schemas)
+	requalify(clone.Conditions, schemas, func(table string) string { return getUpdateSchema(table, clone) })
+	return clone
+}
+
+// ResolveSchemas devuelve una copia de la consulta cuya tabla se califica
+// con schemas (ver SelectQuery.ResolveSchemas).
+func (q *DeleteQuery) ResolveSchemas(schemas map[string]string) QueryBuilder {
+	clone := q.Clone()
+	clone.Schemas = mergeSchemas(schemas, q.Schemas)
+	requalify(clone.Conditions, schemas, func(table string) string { return getDeleteSchema(table, clone) })
+	return clone
+}
+
+// ResolveSchemas devuelve una copia de la consulta cuya tabla se califica
+// con schemas (ver SelectQuery.ResolveSchemas).
+func (q *CreateTableQuery) ResolveSchemas(schemas map[string]string) QueryBuilder {
+	clone := q.Clone()
+	clone.Schemas = mergeSchemas(schemas, q.Schemas)
+	return clone
+}
+
+// ResolveSchemas devuelve una copia de la llamada cuyo procedimiento se
+// califica con schemas (ver SelectQuery.ResolveSchemas).
+func (q *ExecProcQuery) ResolveSchemas(schemas map[string]string) QueryBuilder {
+	clone := q.Clone()
+	clone.Schemas = mergeSchemas(schemas, q.Schemas)
+	return clone
+}
+
+// mergeSchemas combina los esquemas resueltos con los de la consulta, que
+// tienen prioridad.
+func mergeSchemas(resolved map[string]string, own map[string]string) map[string]string {
+	merged := make(map[string]string, len(resolved)+len(own))
+	maps.Copy(merged, resolved)
+	for table, schema := range own {
+		if schema != "" {
+			merged[table] = schema
+		}
+	}
+	return merged
+}
+
+// requalify vuelve a calificar las tablas de las condiciones con qualify.
+// Las tablas derivadas y las consultas de INSERT ... SELECT se resuelven
+// con schemas, como si se ejecutaran por separado.
+func requalify(conditions []Condition, schemas map[string]string, qualify func(table string) string) {
+	for i, condition := range conditions {
+		if condition.sub != nil {
+			resolved := condition.sub.ResolveSchemas(schemas).(*SelectQuery)
+			subquery := strings.TrimSuffix(resolved.buildSelect(), ";")
+			conditions[i].Query = strings.Replace(condition.Query, condition.subquery, subquery, 1)
+			conditions[i].sub = resolved
+			conditions[i].subquery = subquery
+			continue
+		}
+		if condition.table == "" {
+			continue
+		}
+		qualified := qualify(condition.table)
+		conditions[i].Query = strings.Replace(condition.Query, condition.qualified, qualified, 1)
+		conditions[i].qualified = qualified
+	}
+}
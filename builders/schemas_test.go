@@ -0,0 +1,47 @@
+package gosybasebuilder
+
+import "testing"
+
+func TestResolveSchemasQualifiesDerivedTables(t *testing.T) {
+	schemas := map[string]string{"general": "tenant1"}
+	orders := NewSelect().SelectColumns("id", "total").From("orders").WhereEq("year", 2024)
+	archived := NewSelect().DefineSchemas(map[string]string{"general": "archive"}).SelectColumns("id", "total").From("orders")
+
+	tests := []struct {
+		name  string
+		query SchemaResolvable
+		want  string
+	}{
+		{
+			"derived table",
+			NewSelect().SelectColumns("*").FromSubquery(orders, "o").Join("JOIN", "customers c", "c.id = o.id"),
+			"SELECT * FROM (SELECT id, total FROM tenant1.orders WHERE year = 2024) o JOIN tenant1.customers c ON c.id = o.id;",
+		},
+		{
+			"nested derived tables",
+			NewSelect().SelectColumns("*").FromSubquery(NewSelect().SelectColumns("*").FromSubquery(orders, "o"), "d"),
+			"SELECT * FROM (SELECT * FROM (SELECT id, total FROM tenant1.orders WHERE year = 2024) o) d;",
+		},
+		{
+			"derived table with its own schemas",
+			NewSelect().SelectColumns("*").FromSubquery(archived, "o"),
+			"SELECT * FROM (SELECT id, total FROM archive.orders) o;",
+		},
+		{
+			"insert from select",
+			NewInsert().InsertTo("orders_copy").ToColumns("id", "total").FromSelect(orders),
+			"INSERT INTO tenant1.orders_copy (id, total) SELECT id, total FROM tenant1.orders WHERE year = 2024;",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, _, err := test.query.ResolveSchemas(schemas).BuildSQLWithArgs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Fatalf("BuildSQL = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package gosybasebuilder
 
 import (
+	"maps"
+	"math"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -11,6 +15,38 @@ type SelectQuery struct {
 	Schemas                  map[string]string
 	lastColumnConditionIndex int
 	shouldEscape             bool
+	limit                    int
+	offset                   int
+	hasLimit                 bool
+	hasOffset                bool
+	dialect                  Dialect
+	lockHint                 LockHint
+	err                      error
+	useArgs                  bool
+	args                     []any
+	immutable                bool
+	softDeleteColumn         string
+	unscoped                 bool
+}
+
+// LockHint representa una indicación de bloqueo para una consulta SELECT.
+type LockHint string
+
+const (
+	// LockHoldLock mantiene los bloqueos compartidos hasta terminar la transacción.
+	LockHoldLock LockHint = "HOLDLOCK"
+	// LockNoHoldLock libera los bloqueos compartidos apenas se leen las filas.
+	LockNoHoldLock LockHint = "NOHOLDLOCK"
+	// LockReadPast omite las filas bloqueadas por otras transacciones en lugar de esperar.
+	LockReadPast LockHint = "READPAST"
+	// LockReadUncommitted lee sin bloquear (lecturas sucias), útil para reportes.
+	LockReadUncommitted LockHint = "AT ISOLATION READ UNCOMMITTED"
+)
+
+// isIsolation indica si la indicación se aplica a toda la consulta
+// (AT ISOLATION) en lugar de a la tabla principal.
+func (h LockHint) isIsolation() bool {
+	return strings.HasPrefix(string(h), "AT ISOLATION")
 }
 
 // New crea una nueva instancia de SelectQuery inicializada y vacía.
@@ -22,11 +58,81 @@ func NewSelect() *SelectQuery {
 // El parámetro 'schemas' es un mapa donde las claves son nombres de tabla y los valores son nombres de esquema.
 // La clave especial "general" aplica un esquema por defecto a todas las tablas.
 func (q *SelectQuery) DefineSchemas(schemas map[string]string) *SelectQuery {
+	q = q.mutable()
 	q.Schemas = schemas
 	return q
 }
 
+// Clone devuelve una copia independiente de la consulta, incluyendo sus
+// condiciones y esquemas, para reutilizar una consulta base sin modificarla.
+func (q *SelectQuery) Clone() *SelectQuery {
+	clone := *q
+	clone.Conditions = slices.Clone(q.Conditions)
+	clone.Schemas = maps.Clone(q.Schemas)
+	clone.args = slices.Clone(q.args)
+	return &clone
+}
+
+// Immutable devuelve una copia de la consulta en modo inmutable: cada método
+// encadenado devuelve una nueva copia en lugar de modificar la consulta, por
+// lo que una consulta base puede compartirse entre goroutines sin riesgo.
+// Ejemplo: base := NewSelect().SelectColumns("id").From("t").Immutable(); a := base.WhereEq("id", 1)
+func (q *SelectQuery) Immutable() *SelectQuery {
+	clone := q.Clone()
+	clone.immutable = true
+	return clone
+}
+
+// mutable devuelve la consulta a modificar: una copia en modo inmutable o
+// la misma consulta en caso contrario.
+func (q *SelectQuery) mutable() *SelectQuery {
+	if !q.immutable {
+		return q
+	}
+	return q.Clone()
+}
+
+// UseArgs activa el modo argumentos: los valores de Go (WhereValue, WhereEq,
+// WhereNotEq) se generan como marcadores ? y se devuelven en
+// BuildSQLWithArgs, para ejecutar la misma consulta con distintos valores
+// (ver Database.Prepare).
+func (q *SelectQuery) UseArgs() *SelectQuery {
+	q = q.mutable()
+	q.useArgs = true
+	return q
+}
+
+// bind convierte un valor en literal o marcador según el modo argumentos.
+func (q *SelectQuery) bind(value any) string {
+	return bindValue(q.dialect, q.useArgs, &q.args, value)
+}
+
+// WithDialect establece la variante de Sybase para la que se genera el SQL.
+// Por defecto se usa DialectASE.
+func (q *SelectQuery) WithDialect(dialect Dialect) *SelectQuery {
+	q = q.mutable()
+	q.dialect = dialect
+	return q
+}
+
+// WithLock añade una indicación de bloqueo a la consulta.
+// HOLDLOCK, NOHOLDLOCK y READPAST se aplican a la tabla del FROM, mientras que
+// AT ISOLATION se añade al final de la consulta. Una nueva llamada reemplaza la anterior.
+//
+// Ejemplo: WithLock(LockReadUncommitted) para que un reporte no bloquee escrituras
+func (q *SelectQuery) WithLock(hint LockHint) *SelectQuery {
+	q = q.mutable()
+	q.lockHint = hint
+	return q
+}
+
+// Escape activa el escape SQL de los valores recibidos como cadenas:
+// WhereEquals y WhereNotEquals tratan el valor como string y lo encierran
+// entre comillas simples, y Like y NotLike escapan el patrón. En ambos casos
+// las comillas simples internas se duplican (ver EscapeString).
+// Las columnas y el resto de fragmentos SQL no se modifican.
 func (q *SelectQuery) Escape() *SelectQuery {
+	q = q.mutable()
 	q.shouldEscape = true
 	return q
 }
@@ -34,8 +140,9 @@ func (q *SelectQuery) Escape() *SelectQuery {
 // SelectColumns especifica las columnas a seleccionar en la consulta.
 // Acepta múltiples columnas como argumentos variables.
 func (q *SelectQuery) SelectColumns(columns ...string) *SelectQuery {
+	q = q.mutable()
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "columns",
+		TypeQuery: ConditionColumns,
 		Query:     strings.Join(columns, ", "),
 	})
 	q.lastColumnConditionIndex = len(columns) - 1
@@ -45,56 +152,122 @@ func (q *SelectQuery) SelectColumns(columns ...string) *SelectQuery {
 // From establece la tabla principal para la consulta.
 // Aplica automáticamente el esquema correspondiente si fue definido.
 func (q *SelectQuery) From(from string) *SelectQuery {
-	q.Conditions = append(q.Conditions, Condition{TypeQuery: "from", Query: getSelectSchema(from, q)})
+	q = q.mutable()
+	qualified := getSelectSchema(from, q)
+	q.Conditions = append(q.Conditions, Condition{TypeQuery: ConditionFrom, Query: qualified, table: from, qualified: qualified})
+	return q
+}
+
+// FromSubquery establece como tabla principal una tabla derivada a partir de
+// otra consulta SELECT. El alias es obligatorio en Sybase.
+// En ASE la subconsulta no puede tener límite, ya que SET ROWCOUNT afectaría
+// a toda la consulta.
+//
+// Ejemplo: FromSubquery(sub, "ventas") => FROM (SELECT ...) ventas
+func (q *SelectQuery) FromSubquery(sub *SelectQuery, alias string) *SelectQuery {
+	q = q.mutable()
+	switch {
+	case sub == nil || len(sub.Conditions) == 0:
+		q.setErr(queryError("empty derived table"))
+		return q
+	case alias == "":
+		q.setErr(queryError("derived table without alias"))
+		return q
+	case sub.dialect == DialectASE && sub.hasLimit:
+		q.setErr(queryError("LIMIT in a derived table isn't supported by %s", sub.dialect))
+		return q
+	}
+	if err := sub.Validate(); err != nil {
+		q.setErr(err)
+		return q
+	}
+
+	q.args = append(q.args, sub.args...)
+	subquery := strings.TrimSuffix(sub.buildSelect(), ";")
+	q.Conditions = append(q.Conditions, Condition{
+		TypeQuery: ConditionFrom,
+		Query:     "(" + subquery + ") " + alias,
+		sub:       sub.Clone(),
+		subquery:  subquery,
+	})
+	return q
+}
+
+// FromWithIndex establece la tabla principal forzando el uso de un índice,
+// útil en ASE cuando el optimizador elige un plan incorrecto.
+//
+// Ejemplo: FromWithIndex("orders o", "idx_orders_date") => FROM orders o (index idx_orders_date)
+func (q *SelectQuery) FromWithIndex(from string, indexName string) *SelectQuery {
+	q = q.mutable()
+	qualified := getSelectSchema(from, q)
+	q.Conditions = append(q.Conditions, Condition{
+		TypeQuery: ConditionFrom,
+		Query:     qualified,
+		Args:      " (index " + indexName + ")",
+		table:     from,
+		qualified: qualified,
+	})
 	return q
 }
 
 // GroupBy añade una cláusula GROUP BY a la consulta.
 // Ignora la operación si no se proporcionan columnas.
 func (q *SelectQuery) GroupBy(columns ...string) *SelectQuery {
+	q = q.mutable()
 	if len(columns) == 1 && columns[0] == "" {
 		return q
 	}
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "groupBy",
+		TypeQuery: ConditionGroupBy,
 		Query:     strings.Join(columns, ","),
 	})
 	return q
 }
 
 // Limit establece el límite de registros a devolver.
-// Ignora la operación si el límite está vacío.
-func (q *SelectQuery) Limit(limit string) *SelectQuery {
-	if limit == "" {
+// Un límite negativo se registra como error (ver Err).
+//
+// La paginación se genera al construir la consulta, justo después de SELECT,
+// sin importar en qué punto de la cadena se haya llamado.
+func (q *SelectQuery) Limit(limit int) *SelectQuery {
+	q = q.mutable()
+	if limit < 0 {
+		q.setErr(queryError("negative limit %d", limit))
 		return q
 	}
-	q.Conditions = append(q.Conditions, Condition{TypeQuery: "limit", Query: limit})
+	q.limit = limit
+	q.hasLimit = true
 	return q
 }
 
-// Offset establece el desplazamiento inicial para los resultados.
-// Ignora la operación si el offset está vacío.
-func (q *SelectQuery) Offset(offset string) *SelectQuery {
-	if offset == "" {
+// Offset establece cuántos registros se omiten antes de devolver resultados.
+// Un offset negativo se registra como error (ver Err).
+//
+// El offset es 0-based: Offset(10) devuelve a partir del registro 11.
+// DialectASE no soporta offset, por lo que en ese dialecto Validate falla.
+func (q *SelectQuery) Offset(offset int) *SelectQuery {
+	q = q.mutable()
+	if offset < 0 {
+		q.setErr(queryError("negative offset %d", offset))
 		return q
 	}
-	q.Conditions = append(q.Conditions, Condition{TypeQuery: "offset", Query: offset})
+	q.offset = offset
+	q.hasOffset = true
 	return q
 }
 
 // Count añade una función COUNT para una columna específica.
 // Ignora la operación si la columna está vacía.
 func (q *SelectQuery) Count(column string) *SelectQuery {
+	q = q.mutable()
 	if column == "" {
 		return q
 	}
-	last := q.Conditions[len(q.Conditions)-1]
-
-	if last.TypeQuery == "columns" {
+	if last, ok := lastCondition(q.Conditions); ok && last.TypeQuery == ConditionColumns {
 		q.lastColumnConditionIndex++
 	}
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "columns",
+		TypeQuery: ConditionColumns,
 		Query:     "COUNT (" + column + ")",
 	})
 	return q
@@ -102,6 +275,7 @@ func (q *SelectQuery) Count(column string) *SelectQuery {
 
 // CountDistinct añade una función COUNT(DISTINCT) para una columna específica.
 func (q *SelectQuery) CountDistinct(column string) *SelectQuery {
+	q = q.mutable()
 	if column == "" {
 		return q
 	}
@@ -110,8 +284,9 @@ func (q *SelectQuery) CountDistinct(column string) *SelectQuery {
 
 // Distinct aplica DISTINCT a todas las columnas seleccionadas.
 func (q *SelectQuery) Distinct() *SelectQuery {
+	q = q.mutable()
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "args",
+		TypeQuery: ConditionArgs,
 		Query:     "DISTINCT",
 	})
 	return q
@@ -119,24 +294,39 @@ func (q *SelectQuery) Distinct() *SelectQuery {
 
 // DistinctExact aplica DISTINCT solo a una columna específica.
 func (q *SelectQuery) DistinctExact(column string) *SelectQuery {
+	q = q.mutable()
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "args",
+		TypeQuery: ConditionArgs,
 		Query:     "DISTINCT (" + column + ")",
 	})
 	return q
 }
 
 // OrderBy añade una cláusula ORDER BY para una columna con tipo de orden específico.
+// La columna puede ser también una expresión (ej: "UPPER(nombre)").
+// El tipo de orden debe ser ASC, DESC o vacío; cualquier otro valor se
+// registra como error (ver Err).
 func (q *SelectQuery) OrderBy(column string, orderType string) *SelectQuery {
+	q = q.mutable()
 	if column == "" {
 		return q
 	}
 
-	last := q.Conditions[len(q.Conditions)-1]
+	orderType = strings.ToUpper(strings.TrimSpace(orderType))
+	if orderType != "" && orderType != "ASC" && orderType != "DESC" {
+		q.setErr(queryError("invalid ORDER BY direction %q", orderType))
+		return q
+	}
+
+	last, ok := lastCondition(q.Conditions)
+	if !ok {
+		q.setErr(queryError("ORDER BY requires a FROM clause"))
+		return q
+	}
 
-	if last.TypeQuery == "order" || last.TypeQuery == "continue_order" {
+	if last.TypeQuery == ConditionOrder || last.TypeQuery == ConditionContinueOrder {
 		q.Conditions = append(q.Conditions, Condition{
-			TypeQuery: "continue_order",
+			TypeQuery: ConditionContinueOrder,
 			Query:     ", " + column,
 			Args:      orderType,
 		})
@@ -144,36 +334,87 @@ func (q *SelectQuery) OrderBy(column string, orderType string) *SelectQuery {
 	}
 
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "order",
+		TypeQuery: ConditionOrder,
 		Query:     column,
 		Args:      orderType,
 	})
 	return q
 }
 
+// OrderByPosition añade ORDER BY por la posición (1-based) de una columna
+// del SELECT. Una posición menor que 1 se registra como error (ver Err).
+// Ejemplo: OrderByPosition(2, "DESC") => ORDER BY 2 DESC
+func (q *SelectQuery) OrderByPosition(position int, orderType string) *SelectQuery {
+	q = q.mutable()
+	if position < 1 {
+		q.setErr(queryError("invalid ORDER BY position %d", position))
+		return q
+	}
+	return q.OrderBy(strconv.Itoa(position), orderType)
+}
+
+// OrderByNullsLast añade ORDER BY ascendente dejando los NULL al final.
+// Sybase no soporta NULLS LAST, por lo que se emula ordenando antes por un CASE.
+// Ejemplo: OrderByNullsLast("fecha") => ORDER BY CASE WHEN fecha IS NULL THEN 1 ELSE 0 END ASC, fecha ASC
+func (q *SelectQuery) OrderByNullsLast(column string) *SelectQuery {
+	q = q.mutable()
+	if column == "" {
+		return q
+	}
+	return q.OrderBy("CASE WHEN "+column+" IS NULL THEN 1 ELSE 0 END", "ASC").OrderBy(column, "ASC")
+}
+
+// ComputeBy añade la cláusula COMPUTE de Sybase, que devuelve filas con
+// agregados por cada grupo además de las filas de detalle. Si se indican
+// columnas BY, la consulta debe tener un ORDER BY que empiece por ellas.
+// Las filas de cada tipo pueden separarse con RawResponse.SplitCompute.
+//
+// Ejemplo: ComputeBy([]string{"sum(total)"}, []string{"cliente"}) => COMPUTE sum(total) BY cliente
+func (q *SelectQuery) ComputeBy(aggregates []string, byColumns []string) *SelectQuery {
+	q = q.mutable()
+	if len(aggregates) == 0 {
+		q.setErr(queryError("COMPUTE without aggregates"))
+		return q
+	}
+	q.Conditions = append(q.Conditions, Condition{
+		TypeQuery: ConditionCompute,
+		Query:     strings.Join(aggregates, ", "),
+		Args:      strings.Join(byColumns, ", "),
+	})
+	return q
+}
+
 // OrderByAsc añade ORDER BY con orden ascendente para una columna.
 func (q *SelectQuery) OrderByAsc(column string) *SelectQuery {
+	q = q.mutable()
 	return q.OrderBy(column, "ASC")
 }
 
 // OrderByDesc añade ORDER BY con orden descendente para una columna.
 func (q *SelectQuery) OrderByDesc(column string) *SelectQuery {
+	q = q.mutable()
 	return q.OrderBy(column, "DESC")
 }
 
 // Where añade una condición WHERE a la consulta.
+// Si se llama antes de From, se registra un error (ver Err).
 func (q *SelectQuery) Where(where string) *SelectQuery {
-	last := q.Conditions[len(q.Conditions)-1]
-	if strings.Contains(last.Query, "AND") || strings.Contains(last.Query, "OR") {
+	q = q.mutable()
+	last, ok := lastCondition(q.Conditions)
+	if !ok {
+		q.setErr(queryError("WHERE requires a FROM clause"))
+		return q
+	}
+	if continuesWhere(last) {
 		q.Conditions = append(q.Conditions, Condition{
-			TypeQuery: "continue_where",
+			TypeQuery: ConditionContinueWhere,
 			Query:     where,
 		})
 		return q
 	}
 
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "where",
+		TypeQuery: ConditionWhere,
 		Query:     where,
 	})
 	return q
@@ -181,94 +422,353 @@ func (q *SelectQuery) Where(where string) *SelectQuery {
 
 // WhereEquals añade una condición de igualdad (=) al WHERE.
 func (q *SelectQuery) WhereEquals(from string, to string) *SelectQuery {
-	q = q.Where(from + " = " + to)
+	q = q.mutable()
+	q = q.Where(from + " = " + q.escapeValue(to))
 	return q
 }
 
 // WhereNotEquals añade una condición de desigualdad (!=) al WHERE.
 func (q *SelectQuery) WhereNotEquals(from string, to string) *SelectQuery {
-	q = q.Where(from + " != " + to)
+	q = q.mutable()
+	q = q.Where(from + " != " + q.escapeValue(to))
 	return q
 }
 
+// WhereValue añade una condición WHERE comparando una columna con un valor
+// de Go, que se convierte en literal SQL (ver Dialect.Literal).
+//
+// Ejemplo: WhereValue("total", ">=", 100.5)
+func (q *SelectQuery) WhereValue(column string, operator string, value any) *SelectQuery {
+	q = q.mutable()
+	return q.Where(buildComparison(column, operator, value, q.bind))
+}
+
+// WhereEq añade una condición de igualdad con un valor de Go.
+// Un valor nil genera IS NULL.
+//
+// Ejemplo: WhereEq("nombre", "Juan") => nombre = 'Juan'
+func (q *SelectQuery) WhereEq(column string, value any) *SelectQuery {
+	q = q.mutable()
+	return q.WhereValue(column, "=", value)
+}
+
+// WhereNotEq añade una condición de desigualdad con un valor de Go.
+// Un valor nil genera IS NOT NULL.
+func (q *SelectQuery) WhereNotEq(column string, value any) *SelectQuery {
+	q = q.mutable()
+	return q.WhereValue(column, "!=", value)
+}
+
 // Like añade una condición LIKE al WHERE.
 func (q *SelectQuery) Like(from string, to string) *SelectQuery {
-	q = q.Where(from + " LIKE " + "'" + to + "'")
+	q = q.mutable()
+	q = q.Where(from + " LIKE " + "'" + q.escapePattern(to) + "'")
 	return q
 }
 
 // NotLike añade una condición NOT LIKE al WHERE.
 func (q *SelectQuery) NotLike(from string, to string) *SelectQuery {
-	q = q.Where(from + " NOT LIKE " + "'" + to + "'")
+	q = q.mutable()
+	q = q.Where(from + " NOT LIKE " + "'" + q.escapePattern(to) + "'")
 	return q
 }
 
 // Or añade un operador OR lógico entre condiciones WHERE.
 func (q *SelectQuery) Or() *SelectQuery {
-	q.Conditions = append(q.Conditions, Condition{TypeQuery: "args", Query: "OR"})
+	q = q.mutable()
+	q.Conditions = append(q.Conditions, Condition{TypeQuery: ConditionArgs, Query: "OR"})
 	return q
 }
 
 // And añade un operador AND lógico entre condiciones WHERE.
 func (q *SelectQuery) And() *SelectQuery {
-	q.Conditions = append(q.Conditions, Condition{TypeQuery: "args", Query: "AND"})
+	q = q.mutable()
+	q.Conditions = append(q.Conditions, Condition{TypeQuery: ConditionArgs, Query: "AND"})
 	return q
 }
 
 // Join añade un JOIN genérico con tipo, tabla y condición de unión.
 func (q *SelectQuery) Join(typeJoin string, from string, comparison string) *SelectQuery {
+	q = q.mutable()
+	qualified := getSelectSchema(from, q)
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "join",
-		Query:     typeJoin + " " + getSelectSchema(from, q),
+		TypeQuery: ConditionJoin,
+		Query:     typeJoin + " " + qualified,
 		Where:     comparison,
+		table:     from,
+		qualified: qualified,
 	})
 	return q
 }
 
 // InnerJoin añade un INNER JOIN con tabla y condición de unión.
 func (q *SelectQuery) InnerJoin(from string, comparison string) *SelectQuery {
-	q.Join("INNER JOIN", from, comparison)
-	return q
+	q = q.mutable()
+	return q.Join("INNER JOIN", from, comparison)
 }
 
 // LeftJoin añade un LEFT JOIN con tabla y condición de unión.
 func (q *SelectQuery) LeftJoin(from string, comparison string) *SelectQuery {
-	q.Join("LEFT JOIN", from, comparison)
-	return q
+	q = q.mutable()
+	return q.Join("LEFT JOIN", from, comparison)
 }
 
 // RightJoin añade un RIGHT JOIN con tabla y condición de unión.
 func (q *SelectQuery) RightJoin(from string, comparison string) *SelectQuery {
-	q.Join("RIGHT JOIN", from, comparison)
-	return q
+	q = q.mutable()
+	return q.Join("RIGHT JOIN", from, comparison)
+}
+
+// Err devuelve el primer error registrado al encadenar métodos, si existe.
+func (q *SelectQuery) Err() error {
+	return q.err
+}
+
+// Validate comprueba el orden de las cláusulas (FROM antes de WHERE, JOIN,
+// GROUP BY y ORDER BY; ORDER BY antes de COMPUTE BY) y que la paginación
+// sea compatible con el dialecto.
+func (q *SelectQuery) Validate() error {
+	if q.err != nil {
+		return q.err
+	}
+	if q.hasOffset && q.offset > 0 && !q.dialect.SupportsOffset() {
+		return queryError("OFFSET isn't supported by %s", q.dialect)
+	}
+
+	hasOrder := false
+	for _, condition := range q.Conditions {
+		switch {
+		case condition.TypeQuery == ConditionOrder:
+			hasOrder = true
+		case condition.TypeQuery == ConditionCompute && condition.Args != "" && !hasOrder:
+			return queryError("COMPUTE BY requires a previous ORDER BY")
+		}
+	}
+	return validateWhereOrder(q.Conditions, ConditionFrom)
 }
 
 // BuildSQL construye y devuelve la cadena SQL completa.
-func (q *SelectQuery) BuildSQL() string {
-	conditions := q.Conditions
-	if len(conditions) == 0 {
-		return ""
+// Retorna el primer error registrado o de validación (ver Validate).
+func (q *SelectQuery) BuildSQL() (string, error) {
+	if err := q.Validate(); err != nil {
+		return "", err
+	}
+	if len(q.Conditions) == 0 {
+		return "", nil
+	}
+	if q.dialect == DialectASE {
+		return q.buildRowCount(q.buildSelect()), nil
+	}
+	return q.buildSelect(), nil
+}
+
+// BuildSQLWithArgs implementa QueryBuilder. En modo argumentos (ver UseArgs)
+// devuelve los valores de cada marcador ?, en orden; si no, args es nil.
+func (q *SelectQuery) BuildSQLWithArgs() (string, []any, error) {
+	query, err := q.BuildSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	return query, slices.Clone(q.args), nil
+}
+
+// BuildExists construye una comprobación de existencia que devuelve 1 si la
+// consulta tiene alguna fila y 0 si no, sin leer sus filas. La consulta se
+// incrusta sin ORDER BY, COMPUTE, paginación ni AT ISOLATION, que no
+// cambian el resultado o no se admiten en una subconsulta.
+// Ejemplo: IF EXISTS (SELECT id FROM users WHERE age = 3) SELECT 1 ELSE SELECT 0
+func (q *SelectQuery) BuildExists() (string, error) {
+	if err := q.Validate(); err != nil {
+		return "", err
+	}
+	if len(q.Conditions) == 0 {
+		return "", queryError("EXISTS requires a query")
+	}
+	subquery := strings.TrimSuffix(q.unordered().buildSelect(), ";")
+	return "IF EXISTS (" + subquery + ") SELECT 1 ELSE SELECT 0", nil
+}
+
+// BuildCount construye la consulta que cuenta las filas de la consulta, p.
+// ej. para el total de una paginación. Se eliminan ORDER BY, COMPUTE y la
+// paginación, y las columnas se reemplazan por COUNT(*). Con GROUP BY o
+// DISTINCT se cuentan los grupos o filas distintas mediante una tabla
+// derivada.
+// Ejemplo: SELECT COUNT(*) FROM users WHERE age = 3;
+func (q *SelectQuery) BuildCount() (string, error) {
+	if err := q.Validate(); err != nil {
+		return "", err
+	}
+	if len(q.Conditions) == 0 {
+		return "", queryError("COUNT requires a query")
+	}
+
+	inner := q.unordered()
+	groupBy := ""
+	distinct := false
+	for _, condition := range inner.Conditions {
+		switch {
+		case condition.TypeQuery == ConditionGroupBy:
+			groupBy = condition.Query
+		case isDistinct(condition):
+			distinct = true
+		}
+	}
+
+	switch {
+	case groupBy != "":
+		// las columnas del GROUP BY identifican cada grupo y tienen nombre,
+		// como exige una tabla derivada
+		inner.replaceColumns(groupBy)
+	case !distinct:
+		inner.replaceColumns("COUNT(*)")
+		return inner.buildSelect(), nil
+	}
+	derived := strings.TrimSuffix(inner.buildSelect(), ";")
+	return "SELECT COUNT(*) FROM (" + derived + ") gosybase_count;", nil
+}
+
+// replaceColumns reemplaza las columnas del SELECT por columns.
+func (q *SelectQuery) replaceColumns(columns string) {
+	index := slices.IndexFunc(q.Conditions, func(condition Condition) bool {
+		return condition.TypeQuery == ConditionColumns
+	})
+	if index < 0 {
+		return
+	}
+	q.Conditions = slices.DeleteFunc(q.Conditions, func(condition Condition) bool {
+		return condition.TypeQuery == ConditionColumns
+	})
+	q.Conditions = slices.Insert(q.Conditions, index, Condition{TypeQuery: ConditionColumns, Query: columns})
+	q.lastColumnConditionIndex = 0
+}
+
+// unordered devuelve una copia de la consulta sin ORDER BY, COMPUTE,
+// paginación ni AT ISOLATION, para incrustarla como subconsulta.
+func (q *SelectQuery) unordered() *SelectQuery {
+	clone := q.Clone()
+	clone.Conditions = slices.DeleteFunc(clone.Conditions, func(condition Condition) bool {
+		switch condition.TypeQuery {
+		case ConditionOrder, ConditionContinueOrder, ConditionCompute:
+			return true
+		}
+		return false
+	})
+	clone.hasLimit, clone.hasOffset = false, false
+	if clone.lockHint.isIsolation() {
+		clone.lockHint = ""
+	}
+	return clone
+}
+
+// setErr registra el error si todavía no hay uno.
+func (q *SelectQuery) setErr(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
+
+// buildSelect construye la sentencia SELECT sin el SET ROWCOUNT de ASE,
+// de forma que pueda incrustarse en otras consultas (INSERT ... SELECT).
+func (q *SelectQuery) buildSelect() string {
+	if q.softDeleteColumn != "" && !q.unscoped {
+		scoped := q.Clone()
+		scoped.Conditions = scopeSoftDelete(q.Conditions, q.softDeleteColumn)
+		q = scoped
+	}
+
+	var pagination string
+	if q.dialect != DialectASE {
+		pagination = q.buildPagination()
 	}
-	query := "SELECT "
+	return q.buildIsolation(q.buildColumns("SELECT ", pagination))
+}
+
+// buildIsolation añade la cláusula AT ISOLATION antes del punto y coma final.
+func (q *SelectQuery) buildIsolation(query string) string {
+	if q.lockHint == "" || !q.lockHint.isIsolation() {
+		return query
+	}
+	return strings.TrimSuffix(query, ";") + " " + string(q.lockHint) + ";"
+}
+
+// buildColumns añade las condiciones a la consulta, insertando la paginación
+// (si existe) justo después de SELECT o de SELECT DISTINCT.
+func (q *SelectQuery) buildColumns(query string, pagination string) string {
+	conditions := q.Conditions
 	length := len(conditions)
 
 	for i := range length {
 		end := ""
+		condition := conditions[i]
 
-		if conditions[i].TypeQuery == "columns" && i+1 < length && conditions[i+1].TypeQuery == "columns" {
-			end = ", "
+		if condition.TypeQuery == ConditionFrom && q.lockHint != "" && !q.lockHint.isIsolation() {
+			condition.Args += " " + string(q.lockHint)
+		}
+
+		// la paginación va justo después de SELECT (o de SELECT DISTINCT)
+		if pagination != "" && !isDistinct(conditions[i]) {
+			query += pagination
+			pagination = ""
 		}
 
-		if q.shouldEscape {
-			query += EscapeJSON(conditions[i].BuildQueryStr(i+1 >= length, true) + end)
-			continue
+		if conditions[i].TypeQuery == ConditionColumns && i+1 < length && conditions[i+1].TypeQuery == ConditionColumns {
+			end = ", "
 		}
 
-		query += conditions[i].BuildQueryStr(i+1 >= length, true) + end
+		query += condition.BuildQueryStr(i+1 >= length, true) + end
 	}
 	return query
 }
 
+// buildRowCount envuelve la consulta con SET ROWCOUNT, que es la forma de
+// limitar resultados en ASE. El límite se restablece al terminar la consulta;
+// si la consulta aborta el batch, lo restablece el puente antes de reutilizar
+// la conexión.
+func (q *SelectQuery) buildRowCount(query string) string {
+	if !q.hasLimit {
+		return query
+	}
+	return "SET ROWCOUNT " + strconv.Itoa(q.limit) + " " + query + " SET ROWCOUNT 0"
+}
+
+// buildPagination construye la cláusula TOP/START AT según el límite y el offset
+// definidos. START AT es 1-based, por lo que se suma uno al offset.
+func (q *SelectQuery) buildPagination() string {
+	var pagination string
+	if q.hasLimit {
+		pagination += "TOP " + strconv.Itoa(q.limit) + " "
+	}
+	if q.hasOffset && q.offset > 0 {
+		if !q.hasLimit {
+			// START AT requiere TOP, así que se usa el máximo permitido
+			pagination += "TOP " + strconv.Itoa(math.MaxInt32) + " "
+		}
+		pagination += "START AT " + strconv.Itoa(q.offset+1) + " "
+	}
+	return pagination
+}
+
+// isDistinct indica si la condición corresponde a un modificador DISTINCT.
+func isDistinct(condition Condition) bool {
+	return condition.TypeQuery == ConditionArgs && strings.HasPrefix(condition.Query, "DISTINCT")
+}
+
+// escapeValue devuelve el valor como literal de string si Escape está activo.
+func (q *SelectQuery) escapeValue(value string) string {
+	if !q.shouldEscape {
+		return value
+	}
+	return quoteString(value)
+}
+
+// escapePattern escapa las comillas del patrón LIKE si Escape está activo.
+func (q *SelectQuery) escapePattern(pattern string) string {
+	if !q.shouldEscape {
+		return pattern
+	}
+	return EscapeString(pattern)
+}
+
 // getSelectSchema aplica los esquemas definidos a los nombres de tabla.
 func getSelectSchema(from string, q *SelectQuery) string {
 	var schema string
@@ -290,8 +790,9 @@ func getSelectSchema(from string, q *SelectQuery) string {
 
 // comma añade una coma para separar elementos en la consulta.
 func (q *SelectQuery) comma() *SelectQuery {
+	q = q.mutable()
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "args",
+		TypeQuery: ConditionArgs,
 		Query:     ",",
 	})
 	return q
@@ -299,8 +800,9 @@ func (q *SelectQuery) comma() *SelectQuery {
 
 // whitespace añade un espacio en blanco en la consulta.
 func (q *SelectQuery) whitespace() *SelectQuery {
+	q = q.mutable()
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "args",
+		TypeQuery: ConditionArgs,
 		Query:     " ",
 	})
 	return q
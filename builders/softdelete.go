@@ -0,0 +1,82 @@
+package gosybasebuilder
+
+import "slices"
+
+// WithSoftDelete activa el borrado lógico con la columna indicada (por lo
+// general deleted_at): la consulta solo devuelve las filas en las que la
+// columna es NULL. El filtro se añade al construir la consulta, también en
+// BuildCount, BuildExists y como tabla derivada. Con JOIN, la columna debe
+// calificarse con el alias de la tabla. Unscoped lo desactiva.
+//
+// Ejemplo: NewSelect().SelectColumns("*").From("users").WithSoftDelete("deleted_at")
+// => SELECT * FROM users WHERE deleted_at IS NULL;
+func (q *SelectQuery) WithSoftDelete(column string) *SelectQuery {
+	q = q.mutable()
+	q.softDeleteColumn = column
+	return q
+}
+
+// Unscoped desactiva el borrado lógico de WithSoftDelete en esta consulta,
+// que devuelve también las filas borradas.
+func (q *SelectQuery) Unscoped() *SelectQuery {
+	q = q.mutable()
+	q.unscoped = true
+	return q
+}
+
+// WithSoftDelete activa el borrado lógico con la columna indicada (por lo
+// general deleted_at): en lugar de eliminar las filas, la consulta asigna
+// getdate() a la columna de las que todavía no están borradas. Unscoped lo
+// desactiva.
+//
+// Ejemplo: NewDelete().From("users").WhereEq("id", 1).WithSoftDelete("deleted_at")
+// => UPDATE users SET deleted_at = getdate() WHERE id = 1 AND deleted_at IS NULL;
+func (q *DeleteQuery) WithSoftDelete(column string) *DeleteQuery {
+	q = q.mutable()
+	q.softDeleteColumn = column
+	return q
+}
+
+// Unscoped desactiva el borrado lógico de WithSoftDelete en esta consulta,
+// que elimina las filas definitivamente.
+func (q *DeleteQuery) Unscoped() *DeleteQuery {
+	q = q.mutable()
+	q.unscoped = true
+	return q
+}
+
+// scopeSoftDelete devuelve una copia de las condiciones que excluye las
+// filas cuya columna de borrado lógico no es NULL. El filtro se añade al
+// final del WHERE, antes de GROUP BY, ORDER BY y COMPUTE, y un WHERE con OR
+// se agrupa entre paréntesis para que el filtro se aplique a todo él.
+func scopeSoftDelete(conditions []Condition, column string) []Condition {
+	end := slices.IndexFunc(conditions, func(condition Condition) bool {
+		switch condition.TypeQuery {
+		case ConditionGroupBy, ConditionOrder, ConditionContinueOrder, ConditionCompute:
+			return true
+		}
+		return false
+	})
+	if end < 0 {
+		end = len(conditions)
+	}
+
+	scoped := slices.Clone(conditions)
+	filter := column + " IS NULL"
+	start := slices.IndexFunc(scoped[:end], func(condition Condition) bool {
+		return condition.TypeQuery == ConditionWhere
+	})
+	if start < 0 {
+		return slices.Insert(scoped, end, Condition{TypeQuery: ConditionWhere, Query: filter})
+	}
+
+	if slices.ContainsFunc(scoped[start:end], func(condition Condition) bool {
+		return continuesWhere(condition) && condition.Query == "OR"
+	}) {
+		scoped[start].Query = "(" + scoped[start].Query
+		scoped[end-1].Query += ")"
+	}
+	return slices.Insert(scoped, end,
+		Condition{TypeQuery: ConditionArgs, Query: "AND"},
+		Condition{TypeQuery: ConditionContinueWhere, Query: filter})
+}
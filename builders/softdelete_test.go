@@ -0,0 +1,73 @@
+package gosybasebuilder
+
+import "testing"
+
+func TestSoftDelete(t *testing.T) {
+	users := NewSelect().SelectColumns("*").From("users").WithSoftDelete("deleted_at").Immutable()
+
+	tests := []struct {
+		name  string
+		query interface{ BuildSQL() (string, error) }
+		want  string
+	}{
+		{
+			"select without where",
+			users,
+			"SELECT * FROM users WHERE deleted_at IS NULL;",
+		},
+		{
+			"select with where and order",
+			users.WhereEq("age", 18).OrderByAsc("name"),
+			"SELECT * FROM users WHERE age = 18 AND deleted_at IS NULL ORDER BY name ASC;",
+		},
+		{
+			"select with or",
+			users.WhereEq("age", 18).Or().WhereEq("age", 21),
+			"SELECT * FROM users WHERE (age = 18 OR age = 21) AND deleted_at IS NULL;",
+		},
+		{
+			"unscoped select",
+			users.WhereEq("age", 18).Unscoped(),
+			"SELECT * FROM users WHERE age = 18;",
+		},
+		{
+			"derived table",
+			NewSelect().SelectColumns("*").FromSubquery(users, "u"),
+			"SELECT * FROM (SELECT * FROM users WHERE deleted_at IS NULL) u;",
+		},
+		{
+			"delete",
+			NewDelete().From("users").WhereEq("id", 1).WithSoftDelete("deleted_at"),
+			"UPDATE users SET deleted_at = getdate() WHERE id = 1 AND deleted_at IS NULL;",
+		},
+		{
+			"delete without where",
+			NewDelete().From("users").WithSoftDelete("deleted_at"),
+			"UPDATE users SET deleted_at = getdate() WHERE deleted_at IS NULL;",
+		},
+		{
+			"unscoped delete",
+			NewDelete().From("users").WhereEq("id", 1).WithSoftDelete("deleted_at").Unscoped(),
+			"DELETE FROM users WHERE id = 1;",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.query.BuildSQL()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Fatalf("BuildSQL = %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	count, err := users.WhereEq("age", 18).BuildCount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "SELECT COUNT(*) FROM users WHERE age = 18 AND deleted_at IS NULL;"; count != want {
+		t.Fatalf("BuildCount = %q, want %q", count, want)
+	}
+}
@@ -1,6 +1,9 @@
 package gosybasebuilder
 
 import (
+	"maps"
+	"slices"
+	"sort"
 	"strings"
 )
 
@@ -8,6 +11,11 @@ import (
 type UpdateQuery struct {
 	Conditions []Condition
 	Schemas    map[string]string
+	dialect    Dialect
+	err        error
+	useArgs    bool
+	args       []any
+	immutable  bool
 }
 
 // New crea una nueva instancia de UpdateQuery inicializada vacía
@@ -19,41 +27,140 @@ func NewUpdate() *UpdateQuery {
 // Ejemplo: map[string]string{"usuarios": "esquema_auth"}
 // La clave "general" aplica a todas las tablas
 func (q *UpdateQuery) DefineSchemas(schemas map[string]string) *UpdateQuery {
+	q = q.mutable()
 	q.Schemas = schemas
 	return q
 }
 
+// Clone devuelve una copia independiente de la consulta, incluyendo sus
+// condiciones y esquemas, para reutilizar una consulta base sin modificarla.
+func (q *UpdateQuery) Clone() *UpdateQuery {
+	clone := *q
+	clone.Conditions = slices.Clone(q.Conditions)
+	clone.Schemas = maps.Clone(q.Schemas)
+	clone.args = slices.Clone(q.args)
+	return &clone
+}
+
+// Immutable devuelve una copia de la consulta en modo inmutable: cada método
+// encadenado devuelve una nueva copia en lugar de modificar la consulta, por
+// lo que una consulta base puede compartirse entre goroutines sin riesgo.
+// Ejemplo: base := NewUpdate().From("t").Set("activo", false).Immutable(); a := base.WhereEq("id", 1)
+func (q *UpdateQuery) Immutable() *UpdateQuery {
+	clone := q.Clone()
+	clone.immutable = true
+	return clone
+}
+
+// mutable devuelve la consulta a modificar: una copia en modo inmutable o
+// la misma consulta en caso contrario.
+func (q *UpdateQuery) mutable() *UpdateQuery {
+	if !q.immutable {
+		return q
+	}
+	return q.Clone()
+}
+
+// UseArgs activa el modo argumentos: los valores de Go (WhereValue, WhereEq,
+// WhereNotEq, Set) se generan como marcadores ? y se devuelven en
+// BuildSQLWithArgs, para ejecutar la misma consulta con distintos valores
+// (ver Database.Prepare).
+func (q *UpdateQuery) UseArgs() *UpdateQuery {
+	q = q.mutable()
+	q.useArgs = true
+	return q
+}
+
+// bind convierte un valor en literal o marcador según el modo argumentos.
+func (q *UpdateQuery) bind(value any) string {
+	return bindValue(q.dialect, q.useArgs, &q.args, value)
+}
+
+// WithDialect establece la variante de Sybase para la que se genera el SQL.
+// Por defecto se usa DialectASE.
+func (q *UpdateQuery) WithDialect(dialect Dialect) *UpdateQuery {
+	q = q.mutable()
+	q.dialect = dialect
+	return q
+}
+
 // From establece la tabla principal para la actualización
 // Aplica automáticamente el esquema configurado si existe
 func (q *UpdateQuery) From(from string) *UpdateQuery {
-	q.Conditions = append(q.Conditions, Condition{TypeQuery: "from_update", Query: getUpdateSchema(from, q)})
+	q = q.mutable()
+	qualified := getUpdateSchema(from, q)
+	q.Conditions = append(q.Conditions, Condition{TypeQuery: ConditionFromUpdate, Query: qualified, table: from, qualified: qualified})
 	return q
 }
 
 // SelectColumn especifica una columna y su nuevo valor para actualizar
 // Ejemplo: SelectColumn("nombre", "'Juan'")
 func (q *UpdateQuery) SelectColumn(column string, value string) *UpdateQuery {
+	q = q.mutable()
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "columns",
+		TypeQuery: ConditionColumns,
 		Query:     column + " = " + value,
 	})
 	return q
 }
 
+// Set especifica una columna y su nuevo valor como valor de Go, que se
+// convierte en literal SQL (ver Dialect.Literal).
+// Ejemplo: Set("nombre", "Juan") => nombre = 'Juan'
+func (q *UpdateQuery) Set(column string, value any) *UpdateQuery {
+	q = q.mutable()
+	return q.SelectColumn(column, q.bind(value))
+}
+
+// SetMap especifica varias columnas y sus nuevos valores a partir de un mapa.
+// Las columnas se ordenan alfabéticamente para generar siempre el mismo SQL.
+// Ejemplo: SetMap(map[string]any{"nombre": "Juan", "edad": 30})
+func (q *UpdateQuery) SetMap(values map[string]any) *UpdateQuery {
+	q = q.mutable()
+	columns := make([]string, 0, len(values))
+	for column := range values {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		q = q.Set(column, values[column])
+	}
+	return q
+}
+
+// SetStruct especifica las columnas a actualizar a partir de los campos de un struct.
+// Usa la etiqueta `db` como nombre de columna, ignora los campos con `db:"-"`
+// o `db:",identity"` y omite los campos en su valor cero (ver StructFields).
+// Ejemplo: SetStruct(User{Name: "Juan"}) con `db:"nombre"` => nombre = 'Juan'
+func (q *UpdateQuery) SetStruct(v any) *UpdateQuery {
+	q = q.mutable()
+	columns, values := structColumns(v, true)
+	for i, column := range columns {
+		q = q.Set(column, values[i])
+	}
+	return q
+}
+
 // Where añade una condición WHERE básica a la consulta
+// Si se llama antes de From, se registra un error (ver Err)
 // Ejemplo: Where("edad > 18")
 func (q *UpdateQuery) Where(where string) *UpdateQuery {
-	last := q.Conditions[len(q.Conditions)-1]
-	if strings.Contains(last.Query, "AND") || strings.Contains(last.Query, "OR") {
+	q = q.mutable()
+	last, ok := lastCondition(q.Conditions)
+	if !ok {
+		q.setErr(queryError("WHERE requires a FROM clause"))
+		return q
+	}
+	if continuesWhere(last) {
 		q.Conditions = append(q.Conditions, Condition{
-			TypeQuery: "continue_where",
+			TypeQuery: ConditionContinueWhere,
 			Query:     where,
 		})
 		return q
 	}
 
 	q.Conditions = append(q.Conditions, Condition{
-		TypeQuery: "where",
+		TypeQuery: ConditionWhere,
 		Query:     where,
 	})
 	return q
@@ -62,6 +169,7 @@ func (q *UpdateQuery) Where(where string) *UpdateQuery {
 // WhereEquals añade una condición WHERE de igualdad
 // Ejemplo: WhereEquals("id", "5")
 func (q *UpdateQuery) WhereEquals(from string, to string) *UpdateQuery {
+	q = q.mutable()
 	q = q.Where(from + " = " + to)
 	return q
 }
@@ -69,13 +177,40 @@ func (q *UpdateQuery) WhereEquals(from string, to string) *UpdateQuery {
 // WhereNotEquals añade una condición WHERE de desigualdad
 // Ejemplo: WhereNotEquals("estado", "'inactivo'")
 func (q *UpdateQuery) WhereNotEquals(from string, to string) *UpdateQuery {
+	q = q.mutable()
 	q = q.Where(from + " != " + to)
 	return q
 }
 
+// WhereValue añade una condición WHERE comparando una columna con un valor
+// de Go, que se convierte en literal SQL (ver Dialect.Literal).
+//
+// Ejemplo: WhereValue("total", ">=", 100.5)
+func (q *UpdateQuery) WhereValue(column string, operator string, value any) *UpdateQuery {
+	q = q.mutable()
+	return q.Where(buildComparison(column, operator, value, q.bind))
+}
+
+// WhereEq añade una condición de igualdad con un valor de Go.
+// Un valor nil genera IS NULL.
+//
+// Ejemplo: WhereEq("nombre", "Juan") => nombre = 'Juan'
+func (q *UpdateQuery) WhereEq(column string, value any) *UpdateQuery {
+	q = q.mutable()
+	return q.WhereValue(column, "=", value)
+}
+
+// WhereNotEq añade una condición de desigualdad con un valor de Go.
+// Un valor nil genera IS NOT NULL.
+func (q *UpdateQuery) WhereNotEq(column string, value any) *UpdateQuery {
+	q = q.mutable()
+	return q.WhereValue(column, "!=", value)
+}
+
 // Like añade una condición WHERE con operador LIKE
 // Ejemplo: Like("nombre", "%Juan%")
 func (q *UpdateQuery) Like(from string, to string) *UpdateQuery {
+	q = q.mutable()
 	q = q.Where(from + " LIKE " + "'" + to + "'")
 	return q
 }
@@ -83,6 +218,7 @@ func (q *UpdateQuery) Like(from string, to string) *UpdateQuery {
 // NotLike añade una condición WHERE con operador NOT LIKE
 // Ejemplo: NotLike("email", "%@dominio.com")
 func (q *UpdateQuery) NotLike(from string, to string) *UpdateQuery {
+	q = q.mutable()
 	q = q.Where(from + " NOT LIKE " + "'" + to + "'")
 	return q
 }
@@ -90,37 +226,96 @@ func (q *UpdateQuery) NotLike(from string, to string) *UpdateQuery {
 // Or añade un operador OR entre condiciones WHERE
 // Debe usarse entre llamadas a Where()
 func (q *UpdateQuery) Or() *UpdateQuery {
-	q.Conditions = append(q.Conditions, Condition{TypeQuery: "args", Query: "OR"})
+	q = q.mutable()
+	q.Conditions = append(q.Conditions, Condition{TypeQuery: ConditionArgs, Query: "OR"})
 	return q
 }
 
 // And añade un operador AND entre condiciones WHERE
 // Debe usarse entre llamadas a Where()
 func (q *UpdateQuery) And() *UpdateQuery {
-	q.Conditions = append(q.Conditions, Condition{TypeQuery: "args", Query: "AND"})
+	q = q.mutable()
+	q.Conditions = append(q.Conditions, Condition{TypeQuery: ConditionArgs, Query: "AND"})
 	return q
 }
 
+// Err devuelve el primer error registrado al encadenar métodos, si existe
+func (q *UpdateQuery) Err() error {
+	return q.err
+}
+
+// Validate comprueba que la consulta empiece con From, que tenga al menos
+// una columna a actualizar y que las columnas aparezcan antes del WHERE
+func (q *UpdateQuery) Validate() error {
+	if q.err != nil {
+		return q.err
+	}
+	if len(q.Conditions) == 0 {
+		return nil
+	}
+	if q.Conditions[0].TypeQuery != ConditionFromUpdate {
+		return queryError("UPDATE must start with From")
+	}
+
+	hasColumns, seenWhere := false, false
+	for _, condition := range q.Conditions {
+		switch condition.TypeQuery {
+		case ConditionColumns:
+			if seenWhere {
+				return queryError("SET column after the WHERE clause")
+			}
+			hasColumns = true
+		case ConditionWhere, ConditionContinueWhere:
+			seenWhere = true
+		}
+	}
+	if !hasColumns {
+		return queryError("UPDATE without columns to set")
+	}
+	return nil
+}
+
 // BuildSQL construye y devuelve la consulta SQL completa
-// Retorna cadena vacía si no hay condiciones definidas
-func (q *UpdateQuery) BuildSQL() string {
+// Retorna cadena vacía si no hay condiciones definidas, o el primer error
+// registrado o de validación (ver Validate)
+func (q *UpdateQuery) BuildSQL() (string, error) {
+	if err := q.Validate(); err != nil {
+		return "", err
+	}
 	conditions := q.Conditions
 	if len(conditions) == 0 {
-		return ""
+		return "", nil
 	}
 	query := "UPDATE "
 	length := len(conditions)
 
 	for i := range length {
 		var connector string
-		if conditions[i].TypeQuery == "columns" && i+1 < length && conditions[i+1].TypeQuery == "columns" {
+		if conditions[i].TypeQuery == ConditionColumns && i+1 < length && conditions[i+1].TypeQuery == ConditionColumns {
 			connector = ", "
 		} else {
 			connector = " "
 		}
 		query += strings.TrimRight(conditions[i].BuildQueryStr(i+1 >= length, true), " ") + connector
 	}
-	return query
+	return query, nil
+}
+
+// BuildSQLWithArgs implementa QueryBuilder. En modo argumentos (ver UseArgs)
+// devuelve los valores de cada marcador ?, en orden; si no, args es nil.
+func (q *UpdateQuery) BuildSQLWithArgs() (string, []any, error) {
+	query, err := q.BuildSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	return query, slices.Clone(q.args), nil
+}
+
+// setErr registra el error si todavía no hay uno
+func (q *UpdateQuery) setErr(err error) {
+	if q.err == nil {
+		q.err = err
+	}
 }
 
 // getUpdateSchema aplica los esquemas definidos a los nombres de tabla
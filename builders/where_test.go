@@ -0,0 +1,43 @@
+package gosybasebuilder
+
+import "testing"
+
+func TestWhereOpensClauseAfterFromWithAndOr(t *testing.T) {
+	tests := []struct {
+		name  string
+		query interface{ BuildSQL() (string, error) }
+		want  string
+	}{
+		{
+			"select from derived table",
+			NewSelect().SelectColumns("*").From("(SELECT id FROM t WHERE a = 1 AND b = 2) d").Where("id > 5"),
+			"SELECT * FROM (SELECT id FROM t WHERE a = 1 AND b = 2) d WHERE id > 5;",
+		},
+		{
+			"select from table with OR in its name",
+			NewSelect().SelectColumns("*").From("ORDERS").Where("a = 1 AND b = 2"),
+			"SELECT * FROM ORDERS WHERE a = 1 AND b = 2;",
+		},
+		{
+			"select continued with And",
+			NewSelect().SelectColumns("*").From("t").Where("a = 1").And().Where("b = 2"),
+			"SELECT * FROM t WHERE a = 1 AND b = 2;",
+		},
+		{
+			"delete from table with OR in its name",
+			NewDelete().From("ORDERS").Where("id = 1"),
+			"DELETE FROM ORDERS WHERE id = 1;",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.query.BuildSQL()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Fatalf("BuildSQL = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
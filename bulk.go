@@ -0,0 +1,229 @@
+package gosybase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultBulkBatchSize is the number of rows per batch when
+// BulkOptions.BatchSize is not set.
+const defaultBulkBatchSize = 1000
+
+// BulkErrorPolicy decides what BulkInsert does when a batch fails.
+type BulkErrorPolicy int
+
+const (
+	BulkAbort     BulkErrorPolicy = iota // Roll back the failing batch and stop (default)
+	BulkSkipBatch                        // Roll back the failing batch and continue with the next one
+	BulkRowByRow                         // Retry the failing batch row by row, skipping the rows that fail
+)
+
+// BulkOptions configures BulkInsert.
+type BulkOptions struct {
+	BatchSize int             // Rows per batch, each one inserted in its own transaction (default: 1000)
+	OnError   BulkErrorPolicy // What to do when a batch fails (default: BulkAbort)
+}
+
+// BulkResult summarizes a BulkInsert.
+type BulkResult struct {
+	Inserted int64   // Rows inserted
+	Failed   int64   // Rows skipped because of an error
+	Errors   []error // Errors of the skipped batches, or *BulkRowError with BulkRowByRow
+}
+
+// BulkRowError is the error of a row skipped by BulkInsert with BulkRowByRow.
+type BulkRowError struct {
+	Row    int64 // Position of the row in the channel, starting at 0
+	Values []any
+	Err    error
+}
+
+func (e *BulkRowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *BulkRowError) Unwrap() error {
+	return e.Err
+}
+
+// BulkInsert loads the rows received from rows into the columns of table,
+// until rows is closed. Rows are grouped in batches sent as a single
+// request and committed in their own transaction, which is much faster
+// than executing one INSERT per row.
+//
+// With BulkAbort the first failing batch is rolled back and its error is
+// returned; the rows still sent to the channel are discarded. With the
+// other policies the errors are collected in the result.
+//
+//	rows := make(chan []any)
+//	go func() {
+//		defer close(rows)
+//		for _, user := range users {
+//			rows <- []any{user.ID, user.Name}
+//		}
+//	}()
+//	result, err := db.BulkInsert("users", []string{"id", "name"}, rows, gosybase.BulkOptions{BatchSize: 500})
+func (ds *Database) BulkInsert(table string, columns []string, rows <-chan []any, opts BulkOptions) (BulkResult, error) {
+	var result BulkResult
+	if len(columns) == 0 {
+		return result, errors.New("BulkInsert requires at least one column")
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBulkBatchSize
+	}
+
+	batch := make([][]any, 0, batchSize)
+	var batchStart int64
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		defer func() {
+			batchStart += int64(len(batch))
+			batch = batch[:0]
+		}()
+
+		err := ds.insertBatch(table, columns, batch)
+		if err == nil {
+			result.Inserted += int64(len(batch))
+			return nil
+		}
+
+		switch opts.OnError {
+		case BulkSkipBatch:
+			result.Failed += int64(len(batch))
+			result.Errors = append(result.Errors, err)
+		case BulkRowByRow:
+			for i, row := range batch {
+				if err := ds.insertBatch(table, columns, [][]any{row}); err != nil {
+					result.Failed++
+					result.Errors = append(result.Errors, &BulkRowError{Row: batchStart + int64(i), Values: row, Err: err})
+					continue
+				}
+				result.Inserted++
+			}
+		default:
+			result.Failed += int64(len(batch))
+			return err
+		}
+		return nil
+	}
+
+	for row := range rows {
+		batch = append(batch, row)
+		if len(batch) < batchSize {
+			continue
+		}
+		if err := flush(); err != nil {
+			// don't leave the producer blocked on the channel
+			go func() {
+				for range rows {
+				}
+			}()
+			return result, err
+		}
+	}
+	return result, flush()
+}
+
+// insertBatch inserts rows in a single transaction.
+func (ds *Database) insertBatch(table string, columns []string, rows [][]any) error {
+	query, err := ds.NewInsert().InsertTo(table).ToColumns(columns...).ValuesRows(rows).BuildSQL()
+	if err != nil {
+		return err
+	}
+
+	tx, err := ds.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(query); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertManyAttempts is the number of times InsertMany sends a batch that
+// fails with a transient error.
+const insertManyAttempts = 3
+
+// transientMarkers are fragments of the messages of errors that may not
+// happen again on retry: deadlocks (1205), lock wait timeouts (12205) and
+// lost bridge connections.
+var transientMarkers = []string{
+	"deadlock",
+	"could not acquire a lock",
+	"connection is closed",
+	"the bridge was stopped before responding",
+}
+
+// BatchResult is the outcome of a batch of InsertMany.
+type BatchResult struct {
+	Start    int   // Position in rows of the first row of the batch
+	Rows     int   // Rows of the batch
+	Attempts int   // Times the batch was sent
+	Err      error // Error of the last attempt, nil if the batch was committed
+}
+
+// InsertMany inserts rows into the columns of table in batches of
+// batchSize rows (default: 1000), each one committed in its own
+// transaction. A batch failing with a transient error, such as a deadlock,
+// is retried up to 3 times; a batch that still fails is rolled back and
+// the next ones are inserted anyway. The result reports the outcome of
+// every batch, and the error joins those of the failed ones.
+//
+// It sits between BulkInsert, which streams rows from a channel, and the
+// repositories, which insert one struct at a time.
+//
+//	results, err := db.InsertMany("users", []string{"id", "name"}, [][]any{{1, "a"}, {2, "b"}}, 500)
+func (ds *Database) InsertMany(table string, columns []string, rows [][]any, batchSize int) ([]BatchResult, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("InsertMany requires at least one column")
+	}
+	if batchSize <= 0 {
+		batchSize = defaultBulkBatchSize
+	}
+
+	var results []BatchResult
+	var errs []error
+	for start := 0; start < len(rows); start += batchSize {
+		batch := rows[start:min(start+batchSize, len(rows))]
+		result := BatchResult{Start: start, Rows: len(batch)}
+		for result.Attempts < insertManyAttempts {
+			if result.Attempts > 0 {
+				time.Sleep(time.Duration(result.Attempts) * 100 * time.Millisecond)
+			}
+			result.Attempts++
+			result.Err = ds.insertBatch(table, columns, batch)
+			if result.Err == nil || !transient(result.Err) {
+				break
+			}
+		}
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("batch at row %d: %w", start, result.Err))
+		}
+		results = append(results, result)
+	}
+	return results, errors.Join(errs...)
+}
+
+// transient indicates if err may not happen again on retry.
+func transient(err error) bool {
+	if errors.Is(err, ErrConnectionChanged) || errors.Is(err, ErrBridgeRestarted) {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	for _, marker := range transientMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
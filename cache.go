@@ -0,0 +1,342 @@
+package gosybase
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	sybase "github.com/CatHood0/Go-Sybase/internal"
+)
+
+// defaultCacheSize is the number of results kept by the default in-memory
+// cache until SetCacheSize is called.
+const defaultCacheSize = 1000
+
+// Cache stores the results of QueryCached, serialized as JSON. Implement it
+// to keep results in Redis, groupcache, etc. instead of the in-memory
+// default (see SetCache). Failures should be reported as cache misses.
+// Results read back from JSON hold its types: typed values such as
+// time.Time and []byte come back as strings.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+	Delete(key string)
+}
+
+// responseCache is implemented by caches that keep the results themselves
+// instead of their JSON, so a hit returns the same types as a miss.
+type responseCache interface {
+	getResponse(key string) (*RawResponse, bool)
+	setResponse(key string, response *RawResponse, ttl time.Duration)
+}
+
+// MemoryCache is the default Cache: an in-memory LRU cache with per-entry TTL.
+// It keeps copies of the QueryCached results rather than their JSON.
+type MemoryCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // most recently used first
+}
+
+type cacheEntry struct {
+	key      string
+	value    []byte
+	response *RawResponse // set by setResponse instead of value
+	expires  time.Time
+}
+
+// NewMemoryCache creates a MemoryCache holding at most size entries.
+func NewMemoryCache(size int) *MemoryCache {
+	return &MemoryCache{size: max(size, 0), entries: map[string]*list.Element{}, order: list.New()}
+}
+
+// Get returns the value stored under key, unless it expired. Results
+// stored by QueryCached are returned serialized as JSON.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	entry, ok := c.get(key)
+	if !ok {
+		return nil, false
+	}
+	if entry.response != nil {
+		value, err := json.Marshal(entry.response)
+		return value, err == nil
+	}
+	return entry.value, true
+}
+
+// Set stores value under key for ttl, evicting the least recently used
+// entries if the cache is full.
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	c.set(&cacheEntry{key: key, value: value, expires: time.Now().Add(ttl)})
+}
+
+// getResponse returns a copy of the result stored under key by
+// setResponse, so the caller can modify or Release it.
+func (c *MemoryCache) getResponse(key string) (*RawResponse, bool) {
+	entry, ok := c.get(key)
+	if !ok || entry.response == nil {
+		return nil, false
+	}
+	return cloneResponse(entry.response), true
+}
+
+// setResponse stores a copy of response under key for ttl.
+func (c *MemoryCache) setResponse(key string, response *RawResponse, ttl time.Duration) {
+	c.set(&cacheEntry{key: key, response: cloneResponse(response), expires: time.Now().Add(ttl)})
+}
+
+func (c *MemoryCache) get(key string) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry, true
+}
+
+func (c *MemoryCache) set(entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[entry.key]; ok {
+		c.remove(element)
+	}
+	c.entries[entry.key] = c.order.PushFront(entry)
+	c.evict()
+}
+
+// Delete removes the value stored under key.
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Clear removes every entry.
+func (c *MemoryCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]*list.Element{}
+	c.order.Init()
+}
+
+// Resize changes the maximum number of entries, evicting the least
+// recently used ones if needed.
+func (c *MemoryCache) Resize(size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.size = max(size, 0)
+	c.evict()
+}
+
+// evict drops the least recently used entries until the cache fits its size.
+func (c *MemoryCache) evict() {
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *MemoryCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cacheEntry).key)
+}
+
+// QueryCached runs query like RawQuery, binding the ? placeholders to args,
+// and caches the result for ttl. Later calls with the same normalized SQL
+// and args return the cached result without reaching the server until it
+// expires or is invalidated.
+//
+//	db.QueryCached("SELECT name FROM countries WHERE code = ?", time.Minute, "DO")
+func (ds *Database) QueryCached(query string, ttl time.Duration, args ...any) (*RawResponse, error) {
+	bound, err := ds.dialect.Bind(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	key := normalizeSQL(bound)
+
+	cache := ds.queryCache()
+	if responses, ok := cache.(responseCache); ok {
+		if response, ok := responses.getResponse(key); ok {
+			return response, nil
+		}
+	} else if data, ok := cache.Get(key); ok {
+		var response sybase.RawResponse
+		if err := json.Unmarshal(data, &response); err == nil {
+			return &response, nil
+		}
+		cache.Delete(key)
+	}
+
+	// the key is only for lookups: normalizing may change what the SQL does
+	response, err := ds.RawQuery(bound)
+	if err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		return response, nil
+	}
+	if responses, ok := cache.(responseCache); ok {
+		responses.setResponse(key, response, ttl)
+		return response, nil
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing cached result: %v", err)
+	}
+	cache.Set(key, data, ttl)
+	return response, nil
+}
+
+// InvalidateCache removes the cached result of query with args, if any.
+func (ds *Database) InvalidateCache(query string, args ...any) error {
+	key, err := ds.cacheKey(query, args)
+	if err != nil {
+		return err
+	}
+	ds.queryCache().Delete(key)
+	return nil
+}
+
+// ClearCache removes every cached result. It has no effect on caches set
+// with SetCache that do not implement a Clear method.
+func (ds *Database) ClearCache() {
+	if clearer, ok := ds.queryCache().(interface{ Clear() }); ok {
+		clearer.Clear()
+	}
+}
+
+// SetCache replaces the cache used by QueryCached. A nil cache restores
+// the in-memory default.
+func (ds *Database) SetCache(cache Cache) {
+	ds.cacheMu.Lock()
+	defer ds.cacheMu.Unlock()
+	if cache == nil {
+		cache = NewMemoryCache(defaultCacheSize)
+	}
+	ds.cache = cache
+}
+
+// SetCacheSize sets the maximum number of results kept by the in-memory
+// cache. It has no effect on caches set with SetCache that do not
+// implement a Resize method.
+func (ds *Database) SetCacheSize(size int) {
+	if resizer, ok := ds.queryCache().(interface{ Resize(int) }); ok {
+		resizer.Resize(size)
+	}
+}
+
+func (ds *Database) queryCache() Cache {
+	ds.cacheMu.Lock()
+	defer ds.cacheMu.Unlock()
+	if ds.cache == nil {
+		ds.cache = NewMemoryCache(defaultCacheSize)
+	}
+	return ds.cache
+}
+
+// cacheKey binds args into query and normalizes the result, so queries
+// differing only in whitespace share the same entry.
+func (ds *Database) cacheKey(query string, args []any) (string, error) {
+	bound, err := ds.dialect.Bind(query, args...)
+	if err != nil {
+		return "", err
+	}
+	return normalizeSQL(bound), nil
+}
+
+// normalizeSQL collapses the whitespace and comments outside string
+// literals and drops the trailing semicolon.
+func normalizeSQL(sql string) string {
+	var normalized strings.Builder
+	normalized.Grow(len(sql))
+	space := false
+	for i := 0; i < len(sql); i++ {
+		if end := skipComment(sql, i); end > i {
+			space = true
+			i = end - 1
+			continue
+		}
+		switch char := sql[i]; {
+		case char == '\'':
+			end := skipLiteral(sql, i)
+			if space && normalized.Len() > 0 {
+				normalized.WriteByte(' ')
+			}
+			space = false
+			normalized.WriteString(sql[i:end])
+			i = end - 1
+		case char == ' ' || char == '\t' || char == '\n' || char == '\r':
+			space = true
+		default:
+			if space && normalized.Len() > 0 {
+				normalized.WriteByte(' ')
+			}
+			space = false
+			normalized.WriteByte(char)
+		}
+	}
+	return strings.TrimSpace(strings.TrimSuffix(normalized.String(), ";"))
+}
+
+// cloneResponse copies response and its rows, so a cached result is not
+// changed through the responses returned for it. Rows shared by Results
+// and ResultSets stay shared in the copy.
+func cloneResponse(response *RawResponse) *RawResponse {
+	clone := *response
+	rows := map[uintptr]map[string]any{}
+	cloneRows := func(set []map[string]any) []map[string]any {
+		if set == nil {
+			return nil
+		}
+		cloned := make([]map[string]any, len(set))
+		for i, row := range set {
+			if row == nil {
+				continue
+			}
+			key := reflect.ValueOf(row).Pointer()
+			if _, ok := rows[key]; !ok {
+				copied := make(map[string]any, len(row))
+				for column, value := range row {
+					if data, ok := value.([]byte); ok {
+						value = bytes.Clone(data)
+					}
+					copied[column] = value
+				}
+				rows[key] = copied
+			}
+			cloned[i] = rows[key]
+		}
+		return cloned
+	}
+
+	clone.ResultSets = nil
+	for _, set := range response.ResultSets {
+		clone.ResultSets = append(clone.ResultSets, cloneRows(set))
+	}
+	clone.Results = cloneRows(response.Results)
+	clone.Columns = nil
+	for _, columns := range response.Columns {
+		clone.Columns = append(clone.Columns, slices.Clone(columns))
+	}
+	clone.ColumnTypes = nil
+	for _, types := range response.ColumnTypes {
+		clone.ColumnTypes = append(clone.ColumnTypes, slices.Clone(types))
+	}
+	clone.Messages = slices.Clone(response.Messages)
+	return &clone
+}
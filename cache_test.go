@@ -0,0 +1,88 @@
+package gosybase_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+	"github.com/CatHood0/Go-Sybase/gosybasetest"
+)
+
+func TestQueryCachedRunsBoundSQL(t *testing.T) {
+	bridge := gosybasetest.NewBridge().Default(gosybasetest.Response{
+		Rows: []map[string]any{{"name": "Ana"}},
+	})
+	db := bridge.Open(builder.DialectASE)
+
+	query := "SELECT name -- the display name\nFROM users WHERE id = ?"
+	if _, err := db.QueryCached(query, time.Minute, 1); err != nil {
+		t.Fatal(err)
+	}
+	want := "SELECT name -- the display name\nFROM users WHERE id = 1"
+	if queries := bridge.Queries(); len(queries) != 1 || queries[0] != want {
+		t.Fatalf("queries = %q, want %q", queries, want)
+	}
+}
+
+func TestQueryCachedKeyIgnoresComments(t *testing.T) {
+	tests := []struct {
+		name    string
+		queries []string
+		runs    int
+	}{
+		{"line comment", []string{"SELECT name -- a\nFROM users", "SELECT name -- b\n  FROM users"}, 1},
+		{"block comment", []string{"SELECT name /* a */ FROM users", "SELECT name FROM users;"}, 1},
+		{"comment markers in literals", []string{"SELECT name FROM users WHERE n = '--a'", "SELECT name FROM users WHERE n = '--b'"}, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bridge := gosybasetest.NewBridge()
+			db := bridge.Open(builder.DialectASE)
+			for _, query := range test.queries {
+				if _, err := db.QueryCached(query, time.Minute); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if queries := bridge.Queries(); len(queries) != test.runs {
+				t.Fatalf("queries = %q, want %d runs", queries, test.runs)
+			}
+		})
+	}
+}
+
+func TestQueryCachedHitMatchesMiss(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	bridge := gosybasetest.NewBridge().Default(gosybasetest.Response{
+		Rows:    []map[string]any{{"id": 1.0, "balance": json.Number("12.50"), "created": created, "avatar": []byte{0x0a, 0xff}}},
+		Columns: []string{"id", "balance", "created", "avatar"},
+	})
+	db := bridge.Open(builder.DialectASE)
+
+	miss, err := db.QueryCached("SELECT * FROM users", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hit, err := db.QueryCached("SELECT * FROM users", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bridge.Queries()) != 1 {
+		t.Fatalf("queries = %q, want a cache hit", bridge.Queries())
+	}
+	if !reflect.DeepEqual(hit, miss) {
+		t.Fatalf("hit = %#v\nmiss = %#v", hit, miss)
+	}
+
+	// the cached result is a copy
+	hit.Results[0]["id"] = 2.0
+	hit.Results[0]["avatar"].([]byte)[0] = 0
+	again, err := db.QueryCached("SELECT * FROM users", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again, miss) {
+		t.Fatalf("cached result changed: %#v", again)
+	}
+}
@@ -0,0 +1,48 @@
+package gosybase
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCancelNotSupported is returned when the backend of a database cannot
+// cancel running statements.
+var ErrCancelNotSupported = errors.New("backend does not support cancelling statements")
+
+// Cancel cancels the statement of the request msgID (see ActiveQueries),
+// which then fails with the error reported by the server. It returns an
+// error if the request is not running.
+func (ds *Database) Cancel(msgID int) error {
+	canceler, ok := ds.db.(interface{ Cancel(msgID int) error })
+	if !ok {
+		return ErrCancelNotSupported
+	}
+	return canceler.Cancel(msgID)
+}
+
+// KillSpid kills the server process spid. With the TDSLink bridge the kill
+// is issued from a dedicated connection, so it works even when every pooled
+// connection is busy; other backends run it as a regular statement.
+//
+//	db.KillSpid(42)
+func (ds *Database) KillSpid(spid int) error {
+	if spid <= 0 {
+		return fmt.Errorf("invalid spid %d", spid)
+	}
+	if killer, ok := ds.db.(interface{ KillSpid(spid int) error }); ok {
+		return killer.KillSpid(spid)
+	}
+	_, err := ds.Exec(fmt.Sprintf("kill %d", spid))
+	return err
+}
+
+// Cancel cancels the statement the transaction is running, from another
+// goroutine. The transaction stays open; the server may have rolled it
+// back, so Rollback it.
+func (tx *Tx) Cancel() error {
+	canceler, ok := tx.tx.(interface{ Cancel() error })
+	if !ok {
+		return ErrCancelNotSupported
+	}
+	return canceler.Cancel()
+}
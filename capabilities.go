@@ -0,0 +1,37 @@
+package gosybase
+
+import sybase "github.com/CatHood0/Go-Sybase/internal"
+
+// Capability is an optional feature of the bridge. The client and the
+// bridge negotiate them the first time a request needs one, so a newer
+// client works against an older bridge jar and vice versa.
+type Capability = sybase.Capability
+
+const (
+	CapabilityCancel         = sybase.CapabilityCancel
+	CapabilityKill           = sybase.CapabilityKill
+	CapabilityReauthenticate = sybase.CapabilityReauthenticate
+	CapabilityHealth         = sybase.CapabilityHealth
+	CapabilitySessions       = sybase.CapabilitySessions
+	CapabilityMaxRows        = sybase.CapabilityMaxRows
+	CapabilityTraceID        = sybase.CapabilityTraceID
+	CapabilityPaging         = sybase.CapabilityPaging
+	CapabilityColumnar       = sybase.CapabilityColumnar
+)
+
+// ErrUnsupported is returned by the requests that need a feature the bridge
+// does not support, such as cancelling statements on a bridge older than
+// the client. Row limits and trace IDs degrade instead: the rows are
+// limited once received, and the trace IDs are dropped.
+var ErrUnsupported = sybase.ErrUnsupported
+
+// Capabilities returns the features supported by both the client and the
+// bridge, negotiating them if no request did yet. Backends without a
+// bridge return none.
+func (ds *Database) Capabilities() ([]Capability, error) {
+	negotiator, ok := ds.db.(interface{ Capabilities() ([]Capability, error) })
+	if !ok {
+		return nil, nil
+	}
+	return negotiator.Capabilities()
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"unicode"
+
+	gosybase "github.com/CatHood0/Go-Sybase"
+)
+
+// table is a table to generate a struct for.
+type table struct {
+	name    string
+	columns []gosybase.TableColumn
+}
+
+// initialisms are the words written in upper case in Go names.
+var initialisms = map[string]bool{
+	"ID": true, "URL": true, "URI": true, "API": true, "HTTP": true, "IP": true,
+	"SQL": true, "UUID": true, "JSON": true, "XML": true, "HTML": true,
+}
+
+// generate returns the formatted source of the structs of tables.
+func generate(pkg string, tables []table, repository bool) ([]byte, error) {
+	var body bytes.Buffer
+	var usesTime, usesRepository bool
+
+	for _, t := range tables {
+		name := goName(t.name)
+		fmt.Fprintf(&body, "// %s is a row of the %s table.\n", name, t.name)
+		fmt.Fprintf(&body, "type %s struct {\n", name)
+		var keys []string
+		for _, column := range t.columns {
+			goType := goType(column)
+			usesTime = usesTime || strings.Contains(goType, "time.")
+			fmt.Fprintf(&body, "\t%s %s `db:%q`\n", goName(column.Name), goType, column.Name)
+			if column.PrimaryKey {
+				keys = append(keys, column.Name)
+			}
+		}
+		body.WriteString("}\n\n")
+
+		if repository && len(keys) == 1 {
+			usesRepository = true
+			fmt.Fprintf(&body, "// New%[1]sRepository returns the repository of the %[2]s table.\n", name, t.name)
+			fmt.Fprintf(&body, "func New%[1]sRepository(db *gosybase.Database) *gosybase.Repository[%[1]s] {\n", name)
+			fmt.Fprintf(&body, "\treturn gosybase.NewRepository[%s](db, %q, %q)\n}\n\n", name, t.name, keys[0])
+		}
+	}
+
+	var source bytes.Buffer
+	source.WriteString("// Code generated by gosybase-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&source, "package %s\n\n", pkg)
+	switch {
+	case usesTime && usesRepository:
+		source.WriteString("import (\n\t\"time\"\n\n\tgosybase \"github.com/CatHood0/Go-Sybase\"\n)\n\n")
+	case usesTime:
+		source.WriteString("import \"time\"\n\n")
+	case usesRepository:
+		source.WriteString("import gosybase \"github.com/CatHood0/Go-Sybase\"\n\n")
+	}
+	source.Write(body.Bytes())
+	return format.Source(source.Bytes())
+}
+
+// goType returns the Go type of a column: a pointer for nullable columns,
+// except for []byte, whose nil value is NULL.
+func goType(column gosybase.TableColumn) string {
+	var goType string
+	switch strings.ToLower(column.Type) {
+	case "bit":
+		goType = "bool"
+	case "tinyint", "unsigned tinyint":
+		goType = "uint8"
+	case "smallint":
+		goType = "int16"
+	case "unsigned smallint":
+		goType = "uint16"
+	case "int", "integer":
+		goType = "int32"
+	case "unsigned int", "unsigned integer":
+		goType = "uint32"
+	case "bigint":
+		goType = "int64"
+	case "unsigned bigint":
+		goType = "uint64"
+	case "numeric", "decimal", "numericn", "decimaln":
+		if column.Scale == 0 && column.Precision <= 18 {
+			goType = "int64"
+		} else {
+			goType = "float64"
+		}
+	case "float", "double", "double precision", "money", "smallmoney", "floatn", "moneyn":
+		goType = "float64"
+	case "real":
+		goType = "float32"
+	case "datetime", "smalldatetime", "date", "time", "bigdatetime", "bigtime", "datetimn", "daten", "timen":
+		goType = "time.Time"
+	// timestamp is the row version of ASE, not a date
+	case "binary", "varbinary", "image", "timestamp", "long binary":
+		return "[]byte"
+	default:
+		goType = "string"
+	}
+	if column.Nullable {
+		return "*" + goType
+	}
+	return goType
+}
+
+// goName converts a table or column name to an exported Go name:
+// order_id => OrderID, customerName => CustomerName.
+func goName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, word := range words {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	goName := b.String()
+	if goName == "" || unicode.IsDigit([]rune(goName)[0]) {
+		goName = "T" + goName
+	}
+	return goName
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	gosybase "github.com/CatHood0/Go-Sybase"
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+// Query kinds of the -- name: annotations.
+const (
+	kindOne  = ":one"  // First: the first row or gosybase.ErrNoRows
+	kindMany = ":many" // Pluck: every row
+	kindExec = ":exec" // Stmt.Exec: no rows
+)
+
+var (
+	// nameAnnotation matches "-- name: GetUser :one".
+	nameAnnotation = regexp.MustCompile(`^--\s*name:\s*(\w+)\s+(:one|:many|:exec)\s*$`)
+	// tableReference matches the tables a query reads or writes.
+	tableReference = regexp.MustCompile(`(?i)\b(?:FROM|JOIN|UPDATE|INTO)\s+([A-Za-z_#][\w.]*)`)
+	// insertValues matches INSERT INTO t (columns) VALUES (values), whose
+	// placeholders take the name of their column.
+	insertValues = regexp.MustCompile(`(?is)INSERT\s+INTO\s+\S+\s*\(([^)]*)\)\s*VALUES\s*\(([^)]*)\)`)
+	// comparisons match the column compared with a placeholder at the end
+	// of the text before it: "u.id = ", "name LIKE ", "id IN (", and both
+	// bounds of "total BETWEEN ? AND ".
+	comparisons = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)([A-Za-z_][\w.]*)\s*(?:=|<>|!=|<=|>=|<|>|\bLIKE|\bIN\s*\(|\bBETWEEN)\s*$`),
+		regexp.MustCompile(`(?i)([A-Za-z_][\w.]*)\s+BETWEEN\s+\?\s+AND\s*$`),
+	}
+)
+
+// query is an annotated query of a .sql file.
+type query struct {
+	name    string
+	kind    string
+	sql     string
+	doc     []string // comment lines after the annotation
+	file    string
+	params  []field
+	columns []field
+}
+
+// field is a parameter or a result column of a query.
+type field struct {
+	name   string // Go name
+	column string // column name of the result
+	goType string
+}
+
+// parseQueryFiles reads the annotated queries of the .sql files of path, a
+// file or a directory.
+func parseQueryFiles(path string) ([]query, error) {
+	files := []string{path}
+	if info, err := os.Stat(path); err != nil {
+		return nil, err
+	} else if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.sql")); err != nil {
+			return nil, err
+		}
+	}
+
+	var queries []query
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parseQueries(filepath.Base(file), string(data))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, parsed...)
+	}
+	return queries, nil
+}
+
+// parseQueries splits a file into its queries, each one starting with a
+// "-- name: Name :kind" line. The comment lines right after it document the
+// generated function.
+func parseQueries(file, source string) ([]query, error) {
+	var queries []query
+	var current *query
+	var sql strings.Builder
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+		current.sql = strings.TrimSuffix(strings.TrimSpace(sql.String()), ";")
+		if current.sql == "" {
+			return fmt.Errorf("%s: query %s is empty", file, current.name)
+		}
+		queries = append(queries, *current)
+		sql.Reset()
+		return nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(source))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if match := nameAnnotation.FindStringSubmatch(line); match != nil {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			current = &query{name: goName(match[1]), kind: match[2], file: file}
+			continue
+		}
+		switch {
+		case current == nil:
+			continue
+		case strings.HasPrefix(line, "--") && sql.Len() == 0:
+			current.doc = append(current.doc, strings.TrimSpace(strings.TrimPrefix(line, "--")))
+		case line != "":
+			sql.WriteString(scanner.Text() + "\n")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
+
+// describe validates q against the server and fills its parameters and
+// result columns. The query runs with SET FMTONLY ON and NULL arguments,
+// so the server checks it and reports its columns without executing it.
+func describe(db *gosybase.Database, q *query) error {
+	columns := map[string]gosybase.TableColumn{}
+	for _, match := range tableReference.FindAllStringSubmatch(q.sql, -1) {
+		// temporary tables and derived tables can't be described
+		described, err := db.DescribeTable(match[1])
+		if err != nil {
+			continue
+		}
+		for _, column := range described {
+			columns[strings.ToLower(column.Name)] = column
+		}
+	}
+
+	positions := builder.Placeholders(q.sql)
+	nulls := make([]any, len(positions))
+	bound, err := db.Dialect().Bind(q.sql, nulls...)
+	if err != nil {
+		return err
+	}
+	response, err := db.RawQueryContext(gosybase.AllowUnsafe(context.Background()),
+		"SET FMTONLY ON "+bound+" SET FMTONLY OFF")
+	if err != nil {
+		return err
+	}
+
+	names := map[string]int{}
+	for i, column := range paramColumns(q.sql, positions) {
+		param := field{name: fmt.Sprintf("Arg%d", i+1), goType: "any"}
+		if column != "" {
+			param.name = goName(column)
+			if described, ok := columns[strings.ToLower(column)]; ok {
+				described.Nullable = false
+				param.goType = goType(described)
+			}
+		}
+		// a column compared twice (BETWEEN, ranges) gets numbered names
+		if names[param.name]++; names[param.name] > 1 {
+			param.name += fmt.Sprint(names[param.name])
+		}
+		q.params = append(q.params, param)
+	}
+
+	if q.kind == kindExec {
+		return nil
+	}
+	if len(response.Columns) == 0 || len(response.Columns[0]) == 0 {
+		return fmt.Errorf("the bridge reported no result columns for a %s query", q.kind)
+	}
+	for i, name := range response.Columns[0] {
+		column, ok := columns[strings.ToLower(name)]
+		if !ok {
+			// computed columns: the type reported by the bridge, nullable
+			column = gosybase.TableColumn{Name: name, Nullable: true}
+			if len(response.ColumnTypes) > 0 && i < len(response.ColumnTypes[0]) {
+				column.Type = response.ColumnTypes[0][i].DatabaseType
+			}
+		}
+		q.columns = append(q.columns, field{name: goName(name), column: name, goType: goType(column)})
+	}
+	return nil
+}
+
+// paramColumns returns the column each placeholder is compared with or
+// inserted into, or "" if unknown.
+func paramColumns(sql string, positions []int) []string {
+	columns := make([]string, len(positions))
+	if match := insertValues.FindStringSubmatchIndex(sql); match != nil {
+		names := strings.Split(sql[match[2]:match[3]], ",")
+		values := strings.Split(sql[match[4]:match[5]], ",")
+		offset := match[4]
+		for i, value := range values {
+			if i < len(names) && strings.TrimSpace(value) == "?" {
+				position := offset + strings.Index(value, "?")
+				for j, p := range positions {
+					if p == position {
+						columns[j] = strings.Trim(strings.TrimSpace(names[i]), "[]\"")
+					}
+				}
+			}
+			offset += len(value) + 1
+		}
+	}
+	for i, position := range positions {
+		if columns[i] != "" {
+			continue
+		}
+		for _, comparison := range comparisons {
+			match := comparison.FindStringSubmatch(sql[:position])
+			if match == nil {
+				continue
+			}
+			// drop the table alias: u.id => id
+			column := match[1]
+			if dot := strings.LastIndexByte(column, '.'); dot >= 0 {
+				column = column[dot+1:]
+			}
+			columns[i] = column
+			break
+		}
+	}
+	return columns
+}
+
+// generateQueries returns the formatted source of the functions running
+// queries.
+func generateQueries(pkg string, queries []query) ([]byte, error) {
+	var body bytes.Buffer
+	usesTime := false
+	for _, q := range queries {
+		for _, f := range append(q.params, q.columns...) {
+			usesTime = usesTime || strings.Contains(f.goType, "time.")
+		}
+		writeQuery(&body, q)
+	}
+
+	var source bytes.Buffer
+	source.WriteString("// Code generated by gosybase-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&source, "package %s\n\n", pkg)
+	if usesTime {
+		source.WriteString("import (\n\t\"time\"\n\n\tgosybase \"github.com/CatHood0/Go-Sybase\"\n)\n\n")
+	} else {
+		source.WriteString("import gosybase \"github.com/CatHood0/Go-Sybase\"\n\n")
+	}
+	source.Write(body.Bytes())
+	return format.Source(source.Bytes())
+}
+
+// writeQuery writes the constant, the types and the function of q.
+func writeQuery(w *bytes.Buffer, q query) {
+	constant := []rune(q.name)
+	constant[0] = unicode.ToLower(constant[0])
+	fmt.Fprintf(w, "const %s = %q\n\n", string(constant), q.sql)
+
+	signature := "db *gosybase.Database"
+	args := ""
+	if len(q.params) > 0 {
+		fmt.Fprintf(w, "// %sParams are the parameters of %[1]s.\n", q.name)
+		fmt.Fprintf(w, "type %sParams struct {\n", q.name)
+		for _, param := range q.params {
+			fmt.Fprintf(w, "\t%s %s\n", param.name, param.goType)
+			args += ", params." + param.name
+		}
+		w.WriteString("}\n\n")
+		signature += ", params " + q.name + "Params"
+	}
+
+	if q.kind != kindExec {
+		fmt.Fprintf(w, "// %sRow is a row returned by %[1]s.\n", q.name)
+		fmt.Fprintf(w, "type %sRow struct {\n", q.name)
+		for _, column := range q.columns {
+			fmt.Fprintf(w, "\t%s %s `db:%q`\n", column.name, column.goType, column.column)
+		}
+		w.WriteString("}\n\n")
+	}
+
+	if len(q.doc) > 0 {
+		for _, line := range q.doc {
+			fmt.Fprintf(w, "// %s\n", line)
+		}
+	} else {
+		fmt.Fprintf(w, "// %s runs the %[1]s query of %s.\n", q.name, q.file)
+	}
+	switch q.kind {
+	case kindOne:
+		fmt.Fprintf(w, "func %s(%s) (*%[1]sRow, error) {\n", q.name, signature)
+		fmt.Fprintf(w, "\treturn gosybase.First[%sRow](db, %s%s)\n}\n\n", q.name, string(constant), args)
+	case kindMany:
+		fmt.Fprintf(w, "func %s(%s) ([]%[1]sRow, error) {\n", q.name, signature)
+		fmt.Fprintf(w, "\treturn gosybase.Pluck[%sRow](db, %s%s)\n}\n\n", q.name, string(constant), args)
+	case kindExec:
+		fmt.Fprintf(w, "func %s(%s) error {\n", q.name, signature)
+		fmt.Fprintf(w, "\tstmt, err := db.Prepare(%s)\n\tif err != nil {\n\t\treturn err\n\t}\n", string(constant))
+		fmt.Fprintf(w, "\t_, err = stmt.Exec(%s)\n\treturn err\n}\n\n", strings.TrimPrefix(args, ", "))
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	gosybase "github.com/CatHood0/Go-Sybase"
+)
+
+// formats are the output formats of \format.
+var formats = []string{"table", "csv", "json"}
+
+func validFormat(format string) bool {
+	for _, f := range formats {
+		if format == f {
+			return true
+		}
+	}
+	return false
+}
+
+// resultSet is a result set of a response with its columns.
+type resultSet struct {
+	columns []string
+	rows    []map[string]any
+}
+
+// resultSets splits a response into its result sets. The columns are the
+// ones reported by a columnar bridge, or else the sorted keys of the rows.
+func resultSets(response *gosybase.RawResponse) []resultSet {
+	sets := response.ResultSets
+	if len(sets) == 0 && len(response.Results) > 0 {
+		sets = [][]map[string]any{response.Results}
+	}
+
+	result := make([]resultSet, len(sets))
+	for i, rows := range sets {
+		result[i].rows = rows
+		if i < len(response.Columns) && response.Columns[i] != nil {
+			result[i].columns = response.Columns[i]
+			continue
+		}
+		if len(rows) > 0 {
+			for column := range rows[0] {
+				result[i].columns = append(result[i].columns, column)
+			}
+			sort.Strings(result[i].columns)
+		}
+	}
+	return result
+}
+
+// print writes the result sets of response in the shell format.
+func (s *shell) print(response *gosybase.RawResponse) error {
+	for _, set := range resultSets(response) {
+		var err error
+		switch s.format {
+		case "csv":
+			single := &gosybase.RawResponse{Results: set.rows, Columns: [][]string{set.columns}}
+			err = single.WriteCSV(s.out, gosybase.CSVOptions{})
+		case "json":
+			err = s.printJSON(set)
+		default:
+			err = s.printTable(set)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printTable writes an aligned table followed by the row count, as isql.
+func (s *shell) printTable(set resultSet) error {
+	w := tabwriter.NewWriter(s.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(set.columns, "\t"))
+	dashes := make([]string, len(set.columns))
+	for i, column := range set.columns {
+		dashes[i] = strings.Repeat("-", max(len(column), 4))
+	}
+	fmt.Fprintln(w, strings.Join(dashes, "\t"))
+
+	fields := make([]string, len(set.columns))
+	for _, row := range set.rows {
+		for i, column := range set.columns {
+			fields[i] = formatValue(row[column])
+		}
+		fmt.Fprintln(w, strings.Join(fields, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(s.out, "\n(%d rows affected)\n", len(set.rows))
+	return err
+}
+
+// printJSON writes the rows as an indented JSON array of objects.
+func (s *shell) printJSON(set resultSet) error {
+	encoder := json.NewEncoder(s.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(set.rows)
+}
+
+// formatValue formats a value of a table cell.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05.000")
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case string:
+		// tabs and line breaks would break the alignment
+		return strings.NewReplacer("\t", " ", "\n", " ").Replace(v)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,36 @@
+package gosybase
+
+import sybase "github.com/CatHood0/Go-Sybase/internal"
+
+type (
+	// Config configures the connection opened by ConnectWithConfigs: the
+	// server and its credentials, the bridge pools and JVM, and the
+	// client-side supervision, limits and policies.
+	Config = sybase.Config
+	// TLSConfig configures the SSL/TLS connections of the bridge driver.
+	TLSConfig = sybase.TLSConfig
+	// AuthConfig configures how the bridge authenticates to the server.
+	AuthConfig = sybase.AuthConfig
+	// AuthMethod is the authentication method of AuthConfig.
+	AuthMethod = sybase.AuthMethod
+	// CredentialProvider returns the current credentials, e.g. from a
+	// secrets manager (see Config.Credentials and Reauthenticate).
+	CredentialProvider = sybase.CredentialProvider
+	// LogCategory is a category of bridge logs (see Config.SuppressLogs).
+	LogCategory = sybase.LogCategory
+	// RawOptions are the per-query options of the backends that support them.
+	RawOptions = sybase.RawOptions
+)
+
+const (
+	AuthPassword = sybase.AuthPassword
+	AuthKerberos = sybase.AuthKerberos
+	AuthLDAP     = sybase.AuthLDAP
+)
+
+const (
+	LogBridgeInfo      = sybase.LogBridgeInfo
+	LogBridgeError     = sybase.LogBridgeError
+	LogBridgeException = sybase.LogBridgeException
+	LogBridgeStderr    = sybase.LogBridgeStderr
+)
@@ -0,0 +1,37 @@
+package gosybase
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Count returns the number of rows of table matching condition, such as the
+// total of a paginated listing. The condition may use ? placeholders, bound
+// to args with the database dialect; an empty one counts every row. For
+// builders, run the statement of SelectQuery.BuildCount instead.
+//
+//	total, err := db.Count("users", "age > ? AND active = ?", 18, true)
+func (ds *Database) Count(table, condition string, args ...any) (int64, error) {
+	query := ds.NewSelect().SelectColumns("COUNT(*)").From(table)
+	if condition != "" {
+		bound, err := ds.dialect.Bind(condition, args...)
+		if err != nil {
+			return 0, err
+		}
+		query = query.Where("(" + bound + ")")
+	}
+
+	response, err := ds.QueryBuilder(query)
+	if err != nil {
+		return 0, err
+	}
+	if len(response.Results) == 0 {
+		return 0, errors.New("COUNT returned no result")
+	}
+
+	var count int64
+	if err := assignRow(reflect.ValueOf(&count).Elem(), response.Results[0], ""); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,36 @@
+package gosybase_test
+
+import (
+	"testing"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+	"github.com/CatHood0/Go-Sybase/gosybasetest"
+)
+
+func TestCountConditionWithAndOr(t *testing.T) {
+	tests := []struct {
+		table     string
+		condition string
+		args      []any
+		want      string
+	}{
+		{"ORDERS", "a = ? AND b = ?", []any{1, 2}, "SELECT COUNT(*) FROM ORDERS WHERE (a = 1 AND b = 2);"},
+		{"users", "a = ? OR b = ?", []any{1, 2}, "SELECT COUNT(*) FROM users WHERE (a = 1 OR b = 2);"},
+		{"ORDERS", "", nil, "SELECT COUNT(*) FROM ORDERS;"},
+	}
+	for _, test := range tests {
+		bridge := gosybasetest.NewBridge().Default(gosybasetest.Response{Rows: []map[string]any{{"count": 3.0}}})
+		db := bridge.Open(builder.DialectASE)
+
+		count, err := db.Count(test.table, test.condition, test.args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 3 {
+			t.Fatalf("count = %d", count)
+		}
+		if queries := bridge.Queries(); len(queries) != 1 || queries[0] != test.want {
+			t.Fatalf("queries = %q, want %q", queries, test.want)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package gosybase
+
+import "errors"
+
+// Reauthenticate rotates the credentials of a live database: the new ones
+// are fetched from Config.Credentials and applied to the pools of the
+// bridge, whose connections are replaced as they are released. In-flight
+// queries and transactions are not interrupted; if the server rejects the
+// new credentials the old ones stay in use.
+//
+//	db, err := gosybase.ConnectWithConfigs(gosybase.Config{
+//		Credentials: func() (string, string, error) { return vault.SybaseLogin() },
+//		...
+//	})
+//	// when the secret rotates
+//	err = db.Reauthenticate()
+func (ds *Database) Reauthenticate() error {
+	reauthenticator, ok := ds.db.(interface{ Reauthenticate() error })
+	if !ok {
+		return errors.New("backend does not support reauthentication")
+	}
+	return reauthenticator.Reauthenticate()
+}
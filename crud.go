@@ -0,0 +1,237 @@
+package gosybase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+// InsertStruct inserts v into table, mapping its fields to columns through
+// their `db` tags (see builder.StructFields). Nil pointers are inserted as
+// NULL and `db:",identity"` fields are skipped. It returns the identity
+// value generated by the insert, or 0 when the table has no identity column.
+func InsertStruct(db *Database, table string, v any) (int64, error) {
+	query, err := db.NewInsert().InsertTo(table).ValuesStruct(v).BuildSQL()
+	if err != nil {
+		return 0, err
+	}
+
+	// @@identity is connection scoped, so it must be read in the same
+	// batch; the batch is built, so it skips the strict mode checks
+	response, err := db.RawQueryContext(AllowUnsafe(context.Background()), query+" SELECT @@identity AS id")
+	if err != nil {
+		return 0, err
+	}
+
+	if len(response.Results) == 0 {
+		return 0, errors.New("insert didn't return the identity value")
+	}
+	return toInt64(response.Results[len(response.Results)-1]["id"])
+}
+
+// toInt64 converts a numeric value decoded from the bridge into an int64.
+func toInt64(value any) (int64, error) {
+	if value == nil {
+		return 0, nil
+	}
+	var number int64
+	err := convertAssign(reflect.ValueOf(&number).Elem(), value)
+	return number, err
+}
+
+// changeTracker is implemented by Tracked values so UpdateStruct can
+// restrict the SET clause to the modified columns.
+type changeTracker interface {
+	trackedValue() any
+	ChangedColumns() []string
+	Reset()
+}
+
+// Tracked wraps a struct pointer and remembers a deep copy of its column
+// values at the time it was tracked (or last reset), so UpdateStruct only
+// writes the columns that changed since then, including pointed values and
+// slices such as []byte modified in place.
+//
+//	user := gosybase.Track(&User{ID: 1, Name: "a", Email: "b"})
+//	user.Value.Name = "c"
+//	gosybase.UpdateStruct(db, "users", user, "id") // UPDATE users SET name = 'c' WHERE id = 1;
+type Tracked[T any] struct {
+	Value    *T
+	snapshot map[string]any
+}
+
+// Track starts tracking changes made to v.
+func Track[T any](v *T) *Tracked[T] {
+	tracked := &Tracked[T]{Value: v}
+	tracked.Reset()
+	return tracked
+}
+
+// ChangedColumns returns the columns whose value differs from the snapshot.
+func (t *Tracked[T]) ChangedColumns() []string {
+	var changed []string
+	for _, field := range builder.StructFields(t.Value) {
+		if previous, ok := t.snapshot[field.Column]; !ok || !reflect.DeepEqual(previous, field.Value) {
+			changed = append(changed, field.Column)
+		}
+	}
+	return changed
+}
+
+// Reset takes a new snapshot, marking every column as unchanged.
+func (t *Tracked[T]) Reset() {
+	t.snapshot = map[string]any{}
+	for _, field := range builder.StructFields(t.Value) {
+		t.snapshot[field.Column] = deepCopy(field.Value)
+	}
+}
+
+// deepCopy copies value following its pointers, slices, maps and arrays, so
+// in-place changes to the original don't reach the copy. Unexported struct
+// fields are copied as they are.
+func deepCopy(value any) any {
+	if value == nil {
+		return nil
+	}
+	return copyValue(reflect.ValueOf(value)).Interface()
+}
+
+func copyValue(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type().Elem())
+		copied.Elem().Set(copyValue(value.Elem()))
+		return copied
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(copyValue(value.Elem()))
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := range value.Len() {
+			copied.Index(i).Set(copyValue(value.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		for iter := value.MapRange(); iter.Next(); {
+			copied.SetMapIndex(iter.Key(), copyValue(iter.Value()))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(value.Type()).Elem()
+		for i := range value.Len() {
+			copied.Index(i).Set(copyValue(value.Index(i)))
+		}
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		for i := range value.NumField() {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(copyValue(value.Field(i)))
+			}
+		}
+		return copied
+	}
+	return value
+}
+
+func (t *Tracked[T]) trackedValue() any {
+	return t.Value
+}
+
+// UpdateStruct updates the row of table identified by the whereCols values
+// of v, setting every other mapped column (see builder.StructFields).
+// Identity and `omitempty` zero fields are never set. When v is a Tracked
+// value only its changed columns are set, and the snapshot is reset after
+// a successful update. If nothing changed no statement is executed.
+func UpdateStruct(db *Database, table string, v any, whereCols ...string) (any, error) {
+	if len(whereCols) == 0 {
+		return nil, errors.New("UpdateStruct requires at least one where column")
+	}
+
+	tracker, tracked := v.(changeTracker)
+	if tracked {
+		v = tracker.trackedValue()
+	}
+
+	fields := builder.StructFields(v)
+	if fields == nil {
+		return nil, fmt.Errorf("UpdateStruct expects a struct, got %T", v)
+	}
+
+	isWhere := make(map[string]bool, len(whereCols))
+	for _, column := range whereCols {
+		isWhere[column] = true
+	}
+	isChanged := map[string]bool{}
+	if tracked {
+		for _, column := range tracker.ChangedColumns() {
+			isChanged[column] = true
+		}
+	}
+
+	query := db.NewUpdate().From(table)
+	hasColumns := false
+	for _, field := range fields {
+		switch {
+		case isWhere[field.Column], field.Identity, field.OmitEmpty && field.Zero:
+			continue
+		case tracked && !isChanged[field.Column]:
+			continue
+		}
+		query = query.Set(field.Column, field.Value)
+		hasColumns = true
+	}
+
+	if !hasColumns {
+		return nil, nil
+	}
+
+	for i, column := range whereCols {
+		field, ok := findField(fields, column)
+		if !ok {
+			return nil, fmt.Errorf("where column %q isn't mapped in %T", column, v)
+		}
+		if i > 0 {
+			query = query.And()
+		}
+		query = query.WhereEq(column, field.Value)
+	}
+
+	result, err := db.ExecBuilder(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if tracked {
+		tracker.Reset()
+	}
+	return result, nil
+}
+
+// findField returns the struct field mapped to column.
+func findField(fields []builder.StructField, column string) (builder.StructField, bool) {
+	for _, field := range fields {
+		if field.Column == column {
+			return field, true
+		}
+	}
+	return builder.StructField{}, false
+}
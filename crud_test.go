@@ -0,0 +1,79 @@
+package gosybase_test
+
+import (
+	"testing"
+
+	gosybase "github.com/CatHood0/Go-Sybase"
+	builder "github.com/CatHood0/Go-Sybase/builders"
+	"github.com/CatHood0/Go-Sybase/gosybasetest"
+)
+
+type entity struct {
+	ID int `db:"id,identity"`
+}
+
+type account struct {
+	entity
+	Name string `db:"name"`
+}
+
+func TestStructHelpersWithUnexportedEmbedded(t *testing.T) {
+	bridge := gosybasetest.NewBridge().Default(gosybasetest.Response{Rows: []map[string]any{{"id": 7.0}}})
+	db := bridge.Open(builder.DialectASE)
+
+	id, err := gosybase.InsertStruct(db, "accounts", &account{Name: "Ana"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 7 {
+		t.Fatalf("id = %d, want 7", id)
+	}
+
+	tracked := gosybase.Track(&account{entity: entity{ID: 7}, Name: "Ana"})
+	tracked.Value.Name = "Eva"
+	if _, err := gosybase.UpdateStruct(db, "accounts", tracked, "id"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"INSERT INTO accounts (name) VALUES ('Ana'); SELECT @@identity AS id",
+		"UPDATE accounts SET name = 'Eva' WHERE id = 7; ",
+	}
+	queries := bridge.Queries()
+	if len(queries) != len(want) {
+		t.Fatalf("queries = %q", queries)
+	}
+	for i := range want {
+		if queries[i] != want[i] {
+			t.Fatalf("query %d = %q, want %q", i, queries[i], want[i])
+		}
+	}
+}
+
+type profile struct {
+	ID       int     `db:"id"`
+	Nickname *string `db:"nickname"`
+	Avatar   []byte  `db:"avatar"`
+	Name     string  `db:"name"`
+}
+
+func TestTrackedDetectsInPlaceChanges(t *testing.T) {
+	nickname := "ana"
+	tracked := gosybase.Track(&profile{ID: 1, Nickname: &nickname, Avatar: []byte{1, 2}, Name: "Ana"})
+	if changed := tracked.ChangedColumns(); len(changed) != 0 {
+		t.Fatalf("ChangedColumns = %q right after Track", changed)
+	}
+
+	*tracked.Value.Nickname = "eva"
+	tracked.Value.Avatar[0] = 9
+	changed := tracked.ChangedColumns()
+	if len(changed) != 2 || changed[0] != "nickname" || changed[1] != "avatar" {
+		t.Fatalf("ChangedColumns = %q, want [nickname avatar]", changed)
+	}
+
+	tracked.Reset()
+	tracked.Value.Avatar[1] = 9
+	if changed := tracked.ChangedColumns(); len(changed) != 1 || changed[0] != "avatar" {
+		t.Fatalf("ChangedColumns = %q after Reset, want [avatar]", changed)
+	}
+}
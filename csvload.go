@@ -0,0 +1,215 @@
+package gosybase
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVType is the Go type a CSV field is converted to before being inserted
+// by LoadCSV.
+type CSVType int
+
+const (
+	CSVAuto   CSVType = iota // Integer or decimal when the field is numeric, string otherwise (default)
+	CSVString                // Kept as is
+	CSVInt                   // Parsed as int64
+	CSVFloat                 // Parsed as float64
+	CSVBool                  // Parsed with strconv.ParseBool
+	CSVTime                  // Parsed with LoadCSVOptions.TimeLayout
+	CSVBinary                // Hex encoded, with or without the 0x prefix
+)
+
+// LoadCSVOptions configures LoadCSV.
+type LoadCSVOptions struct {
+	Delimiter   rune               // Field separator (default: ',')
+	Header      []string           // Header of files without one; by default it is read from the first line
+	Columns     map[string]string  // Header to table column mapping; when set, unmapped headers are ignored
+	Types       map[string]CSVType // Conversion of each table column (default: CSVAuto)
+	Null        string             // Field value loaded as NULL (default: empty field)
+	TimeLayout  string             // Layout of CSVTime fields (default: time.DateTime, then time.DateOnly)
+	BatchSize   int                // Rows per batch (see BulkOptions)
+	StopOnError bool               // Stop at the first rejected row instead of reporting it and going on
+}
+
+// RejectedRow is a CSV row LoadCSV could not load.
+type RejectedRow struct {
+	Line   int      // Line of the row in the file, starting at 1
+	Record []string // Fields of a row that could not be read or converted
+	Values []any    // Converted values of a row the server rejected
+	Err    error
+}
+
+// LoadCSVResult summarizes a LoadCSV.
+type LoadCSVResult struct {
+	Inserted int64
+	Rejected []RejectedRow
+}
+
+// LoadCSV loads the rows of the CSV read from r into table using
+// BulkInsert. Fields are converted to the type of their column (see
+// LoadCSVOptions.Types) and rows that cannot be converted or inserted are
+// reported in the result with their line, unless StopOnError is set.
+//
+//	result, err := db.LoadCSV("users", file, gosybase.LoadCSVOptions{
+//		Columns: map[string]string{"User ID": "id", "Full name": "name"},
+//		Types:   map[string]gosybase.CSVType{"id": gosybase.CSVInt},
+//	})
+func (ds *Database) LoadCSV(table string, r io.Reader, opts LoadCSVOptions) (LoadCSVResult, error) {
+	var result LoadCSVResult
+
+	reader := csv.NewReader(r)
+	if opts.Delimiter != 0 {
+		reader.Comma = opts.Delimiter
+	}
+
+	header := opts.Header
+	if len(header) == 0 {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return result, err
+		}
+		header = record
+	}
+	reader.FieldsPerRecord = len(header)
+
+	// positions of the loaded fields and their table columns
+	var (
+		fields  []int
+		columns []string
+	)
+	for i, name := range header {
+		column := strings.TrimSpace(name)
+		if opts.Columns != nil {
+			mapped, ok := opts.Columns[name]
+			if !ok || mapped == "" {
+				continue
+			}
+			column = mapped
+		}
+		fields = append(fields, i)
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return result, errors.New("LoadCSV found no columns to load")
+	}
+
+	var (
+		rows    = make(chan []any)
+		done    = make(chan struct{})
+		lines   []int // line of each row sent to BulkInsert
+		readErr error
+	)
+	go func() {
+		defer close(done)
+		defer close(rows)
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				var parseErr *csv.ParseError
+				if !errors.As(err, &parseErr) || opts.StopOnError {
+					readErr = err
+					return
+				}
+				result.Rejected = append(result.Rejected, RejectedRow{Line: parseErr.Line, Record: record, Err: err})
+				continue
+			}
+
+			line, _ := reader.FieldPos(0)
+			values, err := opts.convert(record, fields, columns)
+			if err != nil {
+				if opts.StopOnError {
+					readErr = fmt.Errorf("line %d: %w", line, err)
+					return
+				}
+				result.Rejected = append(result.Rejected, RejectedRow{Line: line, Record: record, Err: err})
+				continue
+			}
+			lines = append(lines, line)
+			rows <- values
+		}
+	}()
+
+	policy := BulkRowByRow
+	if opts.StopOnError {
+		policy = BulkAbort
+	}
+	bulk, err := ds.BulkInsert(table, columns, rows, BulkOptions{BatchSize: opts.BatchSize, OnError: policy})
+	<-done
+	result.Inserted = bulk.Inserted
+	if err != nil {
+		return result, err
+	}
+
+	for _, bulkErr := range bulk.Errors {
+		var rowErr *BulkRowError
+		if errors.As(bulkErr, &rowErr) {
+			result.Rejected = append(result.Rejected, RejectedRow{Line: lines[rowErr.Row], Values: rowErr.Values, Err: rowErr.Err})
+		}
+	}
+	return result, readErr
+}
+
+// convert turns the loaded fields of record into the values of their columns.
+func (opts LoadCSVOptions) convert(record []string, fields []int, columns []string) ([]any, error) {
+	values := make([]any, len(fields))
+	for i, field := range fields {
+		value, err := opts.convertField(record[field], opts.Types[columns[i]])
+		if err != nil {
+			return nil, fmt.Errorf("column %q: %w", columns[i], err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func (opts LoadCSVOptions) convertField(field string, kind CSVType) (any, error) {
+	if field == opts.Null {
+		return nil, nil
+	}
+
+	switch kind {
+	case CSVString:
+		return field, nil
+	case CSVInt:
+		return strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+	case CSVFloat:
+		return strconv.ParseFloat(strings.TrimSpace(field), 64)
+	case CSVBool:
+		return strconv.ParseBool(strings.TrimSpace(field))
+	case CSVTime:
+		layouts := []string{time.DateTime, time.DateOnly}
+		if opts.TimeLayout != "" {
+			layouts = []string{opts.TimeLayout}
+		}
+		var err error
+		for _, layout := range layouts {
+			var date time.Time
+			if date, err = time.Parse(layout, strings.TrimSpace(field)); err == nil {
+				return date, nil
+			}
+		}
+		return nil, err
+	case CSVBinary:
+		return hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(field), "0x"))
+	}
+
+	if number, err := strconv.ParseInt(field, 10, 64); err == nil {
+		return number, nil
+	}
+	if number, err := strconv.ParseFloat(field, 64); err == nil {
+		return number, nil
+	}
+	return field, nil
+}
@@ -0,0 +1,60 @@
+package gosybase
+
+import (
+	"context"
+	"fmt"
+
+	sybase "github.com/CatHood0/Go-Sybase/internal"
+)
+
+// Cursor reads the result of a query a page at a time (see OpenCursor).
+// With Borrowed set, the rows of each page are only valid until the next
+// call to Next or Close, which reuse their memory (see RawResponse.Release).
+type Cursor = sybase.Cursor
+
+// OpenCursor runs query and returns a cursor over its first result set.
+// The bridge keeps the result set open and sends a page of pageSize rows
+// on each Cursor.Next, which returns io.EOF after the last one, so neither
+// side holds more than a page in memory. The cursor keeps a pooled
+// connection until its last page is read or it is closed.
+//
+//	cursor, err := db.OpenCursor("SELECT * FROM audit", 5000)
+//	if err != nil {
+//		return err
+//	}
+//	defer cursor.Close()
+//	for {
+//		rows, err := cursor.Next()
+//		if errors.Is(err, io.EOF) {
+//			break
+//		}
+//		if err != nil {
+//			return err
+//		}
+//		export(rows)
+//	}
+//
+// Bridges older than the paging protocol return ErrUnsupported.
+func (ds *Database) OpenCursor(query string, pageSize int) (*Cursor, error) {
+	return ds.OpenCursorContext(context.Background(), query, pageSize)
+}
+
+// OpenCursorContext is like OpenCursor, applying the middleware and the tag
+// of ctx to query.
+func (ds *Database) OpenCursorContext(ctx context.Context, query string, pageSize int) (*Cursor, error) {
+	pager, ok := ds.db.(interface {
+		OpenCursor(sql string, pageSize int) (*Cursor, error)
+	})
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	query, err := ds.rewrite(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := pager.OpenCursor(query, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute the query by: %w", err)
+	}
+	return cursor, nil
+}
@@ -0,0 +1,44 @@
+package gosybase
+
+import sybase "github.com/CatHood0/Go-Sybase/internal"
+
+type (
+	// Event is a lifecycle event of the connection.
+	Event = sybase.Event
+	// EventType is the type of an Event.
+	EventType = sybase.EventType
+)
+
+const (
+	EventConnected        = sybase.EventConnected
+	EventDisconnected     = sybase.EventDisconnected
+	EventBridgeRestarted  = sybase.EventBridgeRestarted
+	EventSlowQuery        = sybase.EventSlowQuery
+	EventPoolExhausted    = sybase.EventPoolExhausted
+	EventLongRunningQuery = sybase.EventLongRunningQuery
+)
+
+// Events returns the stream of lifecycle events of the connection, for
+// applications that prefer a single stream over the OnDisconnect and
+// OnReconnect callbacks. There is a single channel per database; events are
+// dropped while its buffer is full. SlowQuery events require
+// Config.SlowQueryThreshold, and LongRunningQuery events
+// Config.WatchdogThreshold. Backends without events return nil.
+//
+//	go func() {
+//		for event := range db.Events() {
+//			switch event.Type {
+//			case gosybase.EventDisconnected:
+//				alert(event.Err)
+//			case gosybase.EventSlowQuery:
+//				log.Printf("slow query (%s): %s", event.Duration, event.Query.SQL)
+//			}
+//		}
+//	}()
+func (ds *Database) Events() <-chan Event {
+	source, ok := ds.db.(interface{ Events() <-chan Event })
+	if !ok {
+		return nil
+	}
+	return source.Events()
+}
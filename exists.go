@@ -0,0 +1,36 @@
+package gosybase
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Exists reports whether query returns any row, binding its ? placeholders
+// to args with the database dialect. The query runs wrapped in
+// IF EXISTS (...) SELECT 1 ELSE SELECT 0, so the server stops at the first
+// row instead of sending them all. For builders, run the statement of
+// SelectQuery.BuildExists instead.
+//
+//	taken, err := db.Exists("SELECT 1 FROM users WHERE login = ?", login)
+func (ds *Database) Exists(query string, args ...any) (bool, error) {
+	query, err := ds.bind(query, args...)
+	if err != nil {
+		return false, err
+	}
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+
+	response, err := ds.RawQuery("IF EXISTS (" + query + ") SELECT 1 ELSE SELECT 0")
+	if err != nil {
+		return false, err
+	}
+	if len(response.Results) == 0 {
+		return false, errors.New("EXISTS check returned no result")
+	}
+
+	var exists bool
+	if err := assignRow(reflect.ValueOf(&exists).Elem(), response.Results[0], ""); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
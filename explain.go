@@ -0,0 +1,61 @@
+package gosybase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+// Explain returns the query plan of query without executing it. The query
+// is sent between SET SHOWPLAN ON / SET NOEXEC ON and their OFF
+// counterparts on a session (see NewSession), whose connection is
+// discarded when it closes, so the options never reach a pooled
+// connection, not even when the query fails.
+//
+// The plan is read from the server messages, so it requires a TDSLink
+// bridge that forwards them (see libs/TDSLink) and supports sessions.
+// Only ASE supports SHOWPLAN.
+func (ds *Database) Explain(query string) (string, error) {
+	if ds.dialect != builder.DialectASE {
+		return "", fmt.Errorf("Explain is not supported by %s", ds.dialect)
+	}
+
+	// only the query is checked in strict mode, not the SET statements around it
+	ctx := context.Background()
+	if err := ds.lint(ctx, query); err != nil {
+		return "", err
+	}
+
+	session, err := ds.NewSession(SessionOptions{})
+	if err != nil {
+		return "", err
+	}
+	defer session.Close()
+
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	response, err := session.raw(AllowUnsafe(ctx), "SET SHOWPLAN ON\nSET NOEXEC ON\n"+query+"\nSET NOEXEC OFF\nSET SHOWPLAN OFF")
+	if err != nil {
+		return "", err
+	}
+
+	plan := parsePlan(response.Messages)
+	if plan == "" {
+		return "", errors.New("the server returned no query plan; make sure the TDSLink bridge forwards server messages")
+	}
+	return plan, nil
+}
+
+// parsePlan joins the SHOWPLAN messages into the plan text, dropping
+// trailing spaces and the blank lines around it.
+func parsePlan(messages []string) string {
+	var lines []string
+	for _, message := range messages {
+		for line := range strings.SplitSeq(message, "\n") {
+			lines = append(lines, strings.TrimRight(line, " \t\r"))
+		}
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
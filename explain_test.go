@@ -0,0 +1,66 @@
+package gosybase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+// sessionBackend records the queries sent to the pool and to its sessions.
+type sessionBackend struct {
+	pooled  []string
+	session []string
+	closed  bool
+	err     error
+}
+
+func (b *sessionBackend) Raw(sql string) (*RawResponse, error) {
+	b.pooled = append(b.pooled, sql)
+	return &RawResponse{}, nil
+}
+
+func (b *sessionBackend) Begin() (BackendTx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (b *sessionBackend) Disconnect() error {
+	return nil
+}
+
+func (b *sessionBackend) NewSession() (BackendSession, error) {
+	return backendSession{b}, nil
+}
+
+type backendSession struct {
+	*sessionBackend
+}
+
+func (s backendSession) Raw(sql string) (*RawResponse, error) {
+	s.session = append(s.session, sql)
+	return &RawResponse{}, s.err
+}
+
+func (s backendSession) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestExplainRunsOnSession(t *testing.T) {
+	backend := &sessionBackend{err: errors.New("syntax error")}
+	db := NewDatabase(backend, builder.DialectASE)
+
+	if _, err := db.Explain("SELECT * FROM orders WHERE"); err == nil {
+		t.Fatal("Explain succeeded with a failing query")
+	}
+	if len(backend.pooled) != 0 {
+		t.Fatalf("pooled queries = %q", backend.pooled)
+	}
+	if len(backend.session) != 1 || !strings.HasPrefix(backend.session[0], "SET SHOWPLAN ON\nSET NOEXEC ON\n") {
+		t.Fatalf("session queries = %q", backend.session)
+	}
+	if !backend.closed {
+		t.Fatal("the session wasn't closed")
+	}
+}
@@ -0,0 +1,39 @@
+package gosybase
+
+import (
+	"encoding/json"
+	"io"
+
+	sybase "github.com/CatHood0/Go-Sybase/internal"
+)
+
+// CSVOptions configures the CSV export of QueryToCSV and RawResponse.WriteCSV.
+type CSVOptions = sybase.CSVOptions
+
+// QueryToCSV runs query and writes its rows to w as CSV (see
+// RawResponse.WriteCSV). Without options, fields are comma separated, a
+// header row is written and NULL is written as an empty field.
+//
+//	db.QueryToCSV("SELECT id, name FROM users", os.Stdout, gosybase.CSVOptions{Delimiter: ';', Columns: []string{"id", "name"}})
+func (ds *Database) QueryToCSV(query string, w io.Writer, opts ...CSVOptions) error {
+	response, err := ds.RawQuery(query)
+	if err != nil {
+		return err
+	}
+
+	var options CSVOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	return response.WriteCSV(w, options)
+}
+
+// QueryToNDJSON runs query and writes each row to w as a JSON object on its
+// own line, as the rows are delivered, so extracts can be piped into tools
+// reading newline-delimited JSON.
+func (ds *Database) QueryToNDJSON(query string, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	return ds.Query(query, func(row map[string]any) error {
+		return encoder.Encode(row)
+	})
+}
@@ -0,0 +1,64 @@
+package gosybase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+// defaultGatherSize is the number of queries Gather runs at once when the
+// backend does not report its pool size, the default size of the bridge
+// pool.
+const defaultGatherSize = 10
+
+// Gather runs independent queries concurrently, at most as many at once as
+// the bridge pool has connections, and returns their responses in the order
+// of queries. A failed query leaves its response nil, or with only its
+// first rows on ErrTruncated; the error joins those of every failed query,
+// each one with its position. Queries not started when ctx is done fail
+// with its error.
+//
+//	responses, err := gosybase.Gather(ctx, db,
+//		db.NewSelect().Count("*").From("orders"),
+//		db.NewSelect().SelectColumns("name", "total").From("top_customers"),
+//	)
+func Gather(ctx context.Context, db *Database, queries ...builder.QueryBuilder) ([]*RawResponse, error) {
+	size := defaultGatherSize
+	if pool, ok := db.db.(interface{ PoolSize() int }); ok && pool.PoolSize() > 0 {
+		size = pool.PoolSize()
+	}
+
+	responses := make([]*RawResponse, len(queries))
+	errs := make([]error, len(queries))
+	slots := make(chan struct{}, size)
+	var wg sync.WaitGroup
+	for i, query := range queries {
+		if err := ctx.Err(); err != nil {
+			errs[i] = fmt.Errorf("query %d: %w", i, err)
+			continue
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = fmt.Errorf("query %d: %w", i, ctx.Err())
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			response, err := db.QueryBuilderContext(ctx, query)
+			responses[i] = response
+			if err != nil {
+				errs[i] = fmt.Errorf("query %d: %w", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return responses, errors.Join(errs...)
+}
@@ -0,0 +1,17 @@
+module github.com/CatHood0/Go-Sybase/goosesybase
+
+go 1.24.3
+
+require (
+	github.com/CatHood0/Go-Sybase v0.0.0
+	github.com/pressly/goose/v3 v3.22.1
+)
+
+require (
+	github.com/mfridman/interpolate v0.0.2 // indirect
+	github.com/sethvargo/go-retry v0.3.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+)
+
+replace github.com/CatHood0/Go-Sybase => ../
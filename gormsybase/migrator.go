@@ -0,0 +1,212 @@
+package gormsybase
+
+import (
+	"fmt"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+)
+
+// Migrator implements the GORM migrator with the ASE DDL and catalog
+// (sysobjects, syscolumns, sysindexes).
+type Migrator struct {
+	migrator.Migrator
+	dialect builder.Dialect
+}
+
+// FullDataTypeOf returns the column definition in the order ASE expects:
+// type, default, nullability and UNIQUE. Nullability is always explicit, as
+// ASE columns are NOT NULL by default.
+func (m Migrator) FullDataTypeOf(field *schema.Field) clause.Expr {
+	expr := clause.Expr{SQL: m.DataTypeOf(field)}
+	// identity columns cannot have a default nor a nullability
+	if field.AutoIncrement {
+		return expr
+	}
+
+	if field.HasDefaultValue {
+		switch {
+		case field.DefaultValueInterface != nil:
+			expr.SQL += " DEFAULT " + m.dialect.Literal(field.DefaultValueInterface)
+		case field.DefaultValue != "" && field.DefaultValue != "(-)":
+			expr.SQL += " DEFAULT " + field.DefaultValue
+		}
+	}
+	// bit columns cannot be null
+	if field.NotNull || field.DataType == schema.Bool {
+		expr.SQL += " NOT NULL"
+	} else {
+		expr.SQL += " NULL"
+	}
+	if field.Unique {
+		expr.SQL += " UNIQUE"
+	}
+	return expr
+}
+
+func (m Migrator) CurrentDatabase() (name string) {
+	m.DB.Raw("SELECT db_name()").Row().Scan(&name)
+	return name
+}
+
+func (m Migrator) GetTables() (tables []string, err error) {
+	err = m.DB.Raw("SELECT name FROM sysobjects WHERE type = 'U'").Scan(&tables).Error
+	return tables, err
+}
+
+func (m Migrator) HasTable(value any) bool {
+	var count int64
+	m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Raw("SELECT count(*) FROM sysobjects WHERE name = ? AND type = 'U'", stmt.Table).Row().Scan(&count)
+	})
+	return count > 0
+}
+
+// DropTable drops the tables that exist, in reverse order so the tables
+// referencing others go first. ASE has no DROP TABLE IF EXISTS.
+func (m Migrator) DropTable(values ...any) error {
+	for i := len(values) - 1; i >= 0; i-- {
+		if !m.HasTable(values[i]) {
+			continue
+		}
+		err := m.RunWithValue(values[i], func(stmt *gorm.Statement) error {
+			return m.DB.Exec("DROP TABLE ?", m.CurrentTable(stmt)).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenameTable renames a table with sp_rename. The names are table names or
+// models.
+func (m Migrator) RenameTable(oldName, newName any) error {
+	oldTable, err := m.tableName(oldName)
+	if err != nil {
+		return err
+	}
+	newTable, err := m.tableName(newName)
+	if err != nil {
+		return err
+	}
+	return m.DB.Exec("EXEC sp_rename ?, ?", oldTable, newTable).Error
+}
+
+// tableName returns the table of a model, or the name itself.
+func (m Migrator) tableName(value any) (string, error) {
+	if name, ok := value.(string); ok {
+		return name, nil
+	}
+	stmt := &gorm.Statement{DB: m.DB}
+	if err := stmt.Parse(value); err != nil {
+		return "", err
+	}
+	return stmt.Table, nil
+}
+
+func (m Migrator) HasColumn(value any, name string) bool {
+	var count int64
+	m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema != nil {
+			if field := stmt.Schema.LookUpField(name); field != nil {
+				name = field.DBName
+			}
+		}
+		return m.DB.Raw("SELECT count(*) FROM syscolumns WHERE id = object_id(?) AND name = ?",
+			stmt.Table, name).Row().Scan(&count)
+	})
+	return count > 0
+}
+
+// DropColumn drops a column. ASE writes ALTER TABLE ... DROP without COLUMN.
+func (m Migrator) DropColumn(value any, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema != nil {
+			if field := stmt.Schema.LookUpField(name); field != nil {
+				name = field.DBName
+			}
+		}
+		return m.DB.Exec("ALTER TABLE ? DROP ?", m.CurrentTable(stmt), clause.Column{Name: name}).Error
+	})
+}
+
+// AlterColumn changes the type of a column with ALTER TABLE ... MODIFY,
+// which cannot change its default.
+func (m Migrator) AlterColumn(value any, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema == nil {
+			return fmt.Errorf("unable to alter column %s without a model", name)
+		}
+		field := stmt.Schema.LookUpField(name)
+		if field == nil {
+			return fmt.Errorf("failed to look up field with name: %s", name)
+		}
+		nullability := " NULL"
+		if field.NotNull || field.DataType == schema.Bool {
+			nullability = " NOT NULL"
+		}
+		return m.DB.Exec("ALTER TABLE ? MODIFY ? ?", m.CurrentTable(stmt),
+			clause.Column{Name: field.DBName}, clause.Expr{SQL: m.DataTypeOf(field) + nullability}).Error
+	})
+}
+
+// RenameColumn renames a column with sp_rename.
+func (m Migrator) RenameColumn(value any, oldName, newName string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema != nil {
+			if field := stmt.Schema.LookUpField(oldName); field != nil {
+				oldName = field.DBName
+			}
+			if field := stmt.Schema.LookUpField(newName); field != nil {
+				newName = field.DBName
+			}
+		}
+		return m.DB.Exec("EXEC sp_rename ?, ?", stmt.Table+"."+oldName, newName).Error
+	})
+}
+
+func (m Migrator) HasIndex(value any, name string) bool {
+	var count int64
+	m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema != nil {
+			if index := stmt.Schema.LookIndex(name); index != nil {
+				name = index.Name
+			}
+		}
+		return m.DB.Raw("SELECT count(*) FROM sysindexes WHERE id = object_id(?) AND name = ?",
+			stmt.Table, name).Row().Scan(&count)
+	})
+	return count > 0
+}
+
+// DropIndex drops an index, named after its table (DROP INDEX table.index).
+func (m Migrator) DropIndex(value any, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema != nil {
+			if index := stmt.Schema.LookIndex(name); index != nil {
+				name = index.Name
+			}
+		}
+		return m.DB.Exec("DROP INDEX ?", clause.Column{Table: stmt.Table, Name: name}).Error
+	})
+}
+
+// RenameIndex renames an index with sp_rename.
+func (m Migrator) RenameIndex(value any, oldName, newName string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Exec("EXEC sp_rename ?, ?, 'index'", stmt.Table+"."+oldName, newName).Error
+	})
+}
+
+func (m Migrator) HasConstraint(value any, name string) bool {
+	var count int64
+	m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Raw("SELECT count(*) FROM sysconstraints c JOIN sysobjects o ON o.id = c.constrid"+
+			" WHERE c.tableid = object_id(?) AND o.name = ?", stmt.Table, name).Row().Scan(&count)
+	})
+	return count > 0
+}
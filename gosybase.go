@@ -1,20 +1,38 @@
 package gosybase
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"sync"
 
+	builder "github.com/CatHood0/Go-Sybase/builders"
 	sybase "github.com/CatHood0/Go-Sybase/internal"
 )
 
 type Database struct {
-	db        *sybase.Sybase
-	Connected bool
+	db             Backend
+	dialect        builder.Dialect
+	serverInfo     ServerInfo
+	schemaResolver SchemaResolver
+	rewriters      []Rewriter
+	cache          Cache
+	cacheMu        sync.Mutex
+	labels         Labels
+	logger         *slog.Logger
+	strict         bool
+	lowAllocation  bool // Config.LowAllocation: rows of callback APIs return to the pool
+	Connected      bool
 }
 
+// SchemaResolver computes the schemas of a request, in the format of the
+// builders' DefineSchemas map. It lets multi-tenant applications pick the
+// schema of each tenant from the request context.
+type SchemaResolver func(ctx context.Context) map[string]string
+
 func Connect(propertiesPath string, log bool, customTdsLink string) (*Database, error) {
-	sybaseDatabase, err := sybase.NewConnectionInstance(sybase.Config{
+	sybaseDatabase, err := sybase.NewConnectionInstance(Config{
 		Logs:          log,
 		TdsLink:       customTdsLink,
 		TdsProperties: propertiesPath,
@@ -31,13 +49,17 @@ func Connect(propertiesPath string, log bool, customTdsLink string) (*Database,
 		return nil, connErr
 	}
 
-	return &Database{
-		db:        sybaseDatabase,
+	ds := &Database{
+		db:        bridge{sybaseDatabase},
 		Connected: true,
-	}, nil
+	}
+	ds.detectServer()
+	return ds, nil
 }
 
-func ConnectWithConfigs(serverConfig sybase.Config) (*Database, error) {
+// ConnectWithConfigs starts the bridge with serverConfig and connects to the
+// server.
+func ConnectWithConfigs(serverConfig Config) (*Database, error) {
 	sybaseDatabase, err := sybase.NewConnectionInstance(serverConfig)
 
 	if err != nil {
@@ -51,34 +73,72 @@ func ConnectWithConfigs(serverConfig sybase.Config) (*Database, error) {
 		return nil, connErr
 	}
 
-	return &Database{
-		db: sybaseDatabase,
-	}, nil
+	ds := &Database{
+		db:            bridge{sybaseDatabase},
+		dialect:       serverConfig.Dialect,
+		logger:        serverConfig.Logger,
+		lowAllocation: serverConfig.LowAllocation,
+		Connected:     true,
+	}
+	ds.detectServer()
+	return ds, nil
+}
+
+// Dialect returns the Sybase variant the builders created
+// from this database generate SQL for. It is detected on connect
+// (see ServerInfo), falling back to Config.Dialect.
+func (ds *Database) Dialect() builder.Dialect {
+	return ds.dialect
+}
+
+// NewSelect creates a SelectQuery using the database dialect.
+func (ds *Database) NewSelect() *builder.SelectQuery {
+	return builder.NewSelect().WithDialect(ds.dialect)
+}
+
+// NewInsert creates an InsertQuery using the database dialect.
+func (ds *Database) NewInsert() *builder.InsertQuery {
+	return builder.NewInsert().WithDialect(ds.dialect)
+}
+
+// NewUpdate creates an UpdateQuery using the database dialect.
+func (ds *Database) NewUpdate() *builder.UpdateQuery {
+	return builder.NewUpdate().WithDialect(ds.dialect)
 }
 
-func (ds *Database) RawQuery(query string) (*sybase.RawResponse, error) {
+// NewDelete creates a DeleteQuery using the database dialect.
+func (ds *Database) NewDelete() *builder.DeleteQuery {
+	return builder.NewDelete().WithDialect(ds.dialect)
+}
+
+func (ds *Database) RawQuery(query string) (*RawResponse, error) {
+	return ds.RawQueryContext(context.Background(), query)
+}
+
+// RawQueryContext is like RawQuery, passing ctx to the rewriters (see Use).
+func (ds *Database) RawQueryContext(ctx context.Context, query string) (*RawResponse, error) {
 	if !ds.Connected {
 		return nil, errors.New("Database isn't connected")
 	}
 
-	response, err := ds.db.Raw(query)
+	response, err := ds.raw(ctx, query)
 
 	if err != nil {
-		log.Default().Print(err)
-		return nil, fmt.Errorf("unable to execute the query by: %s", err)
+		ds.Logger().Error("query failed", "error", err)
+		return nil, fmt.Errorf("unable to execute the query by: %w", err)
 	}
 
-	return response, nil
+	return response, truncated(response)
 }
 
 func (ds *Database) QueryFirst(query string) (map[string]any, error) {
 	data := map[string]any{}
 
-	response, err := ds.db.Raw(query)
+	response, err := ds.raw(context.Background(), query)
 
 	if err != nil {
-		log.Default().Print(err)
-		return data, fmt.Errorf("unable to execute the query by: %s", err)
+		ds.Logger().Error("query failed", "error", err)
+		return data, fmt.Errorf("unable to execute the query by: %w", err)
 	}
 
 	if len(response.Results) < 1 {
@@ -94,12 +154,13 @@ func (ds *Database) Query(query string, callback func(map[string]any) error) err
 	if !ds.Connected {
 		return errors.New("Database isn't connected")
 	}
-	response, err := ds.db.Raw(query)
+	response, err := ds.raw(context.Background(), query)
 
 	if err != nil {
-		log.Default().Print(err)
-		return fmt.Errorf("unable to execute the query by: %s", err)
+		ds.Logger().Error("query failed", "error", err)
+		return fmt.Errorf("unable to execute the query by: %w", err)
 	}
+	defer ds.release(response)
 
 	for _, result := range response.Results {
 		callErr := callback(result)
@@ -108,21 +169,92 @@ func (ds *Database) Query(query string, callback func(map[string]any) error) err
 		}
 	}
 
-	return nil
+	return truncated(response)
+}
+
+// release returns the rows of response to the pool once a callback API is
+// done with them, with Config.LowAllocation.
+func (ds *Database) release(response *RawResponse) {
+	if ds.lowAllocation {
+		response.Release()
+	}
 }
 
 func (ds *Database) Exec(query string) (any, error) {
+	return ds.ExecContext(context.Background(), query)
+}
+
+// ExecContext is like Exec, passing ctx to the rewriters (see Use).
+func (ds *Database) ExecContext(ctx context.Context, query string) (any, error) {
 	if !ds.Connected {
 		return nil, errors.New("Database isn't connected")
 	}
-	value, err := ds.db.Raw(query)
+	value, err := ds.raw(ctx, query)
 
 	if err != nil {
-		log.Default().Print(err)
-		return nil, fmt.Errorf("unable to execute the query by: %s", err)
+		ds.Logger().Error("query failed", "error", err)
+		return nil, fmt.Errorf("unable to execute the query by: %w", err)
 	}
 
-	return value, nil
+	return value, truncated(value)
+}
+
+// SetSchemaResolver registers the resolver used to qualify the tables of
+// the builders executed through QueryBuilderContext and ExecBuilderContext.
+// Schemas defined on the builder itself take precedence over the resolved ones.
+//
+//	db.SetSchemaResolver(func(ctx context.Context) map[string]string {
+//		return map[string]string{"general": tenantFrom(ctx)}
+//	})
+func (ds *Database) SetSchemaResolver(resolver SchemaResolver) {
+	ds.schemaResolver = resolver
+}
+
+// QueryBuilder builds the query and executes it, returning every row like RawQuery.
+func (ds *Database) QueryBuilder(qb builder.QueryBuilder) (*RawResponse, error) {
+	return ds.QueryBuilderContext(context.Background(), qb)
+}
+
+// QueryBuilderContext is like QueryBuilder, resolving the schemas of the
+// query for ctx (see SetSchemaResolver).
+func (ds *Database) QueryBuilderContext(ctx context.Context, qb builder.QueryBuilder) (*RawResponse, error) {
+	query, err := buildQuery(ds.resolveSchemas(ctx, qb), ds.dialect)
+	if err != nil {
+		return nil, err
+	}
+	// built SQL escapes its values, and ASE limits add a trailing SET ROWCOUNT
+	return ds.RawQueryContext(AllowUnsafe(ctx), query)
+}
+
+// ExecBuilder builds the statement and executes it like Exec.
+func (ds *Database) ExecBuilder(qb builder.QueryBuilder) (any, error) {
+	return ds.ExecBuilderContext(context.Background(), qb)
+}
+
+// ExecBuilderContext is like ExecBuilder, resolving the schemas of the
+// statement for ctx (see SetSchemaResolver).
+func (ds *Database) ExecBuilderContext(ctx context.Context, qb builder.QueryBuilder) (any, error) {
+	query, err := buildQuery(ds.resolveSchemas(ctx, qb), ds.dialect)
+	if err != nil {
+		return nil, err
+	}
+	return ds.ExecContext(AllowUnsafe(ctx), query)
+}
+
+// resolveSchemas applies the schemas returned by the resolver to qb.
+func (ds *Database) resolveSchemas(ctx context.Context, qb builder.QueryBuilder) builder.QueryBuilder {
+	if ds.schemaResolver == nil {
+		return qb
+	}
+	resolvable, ok := qb.(builder.SchemaResolvable)
+	if !ok {
+		return qb
+	}
+	schemas := ds.schemaResolver(ctx)
+	if len(schemas) == 0 {
+		return qb
+	}
+	return resolvable.ResolveSchemas(schemas)
 }
 
 func (ds *Database) Disconnect() error {
@@ -0,0 +1,215 @@
+// Package gosybasetest provides helpers to test code using gosybase:
+// fake backends (Bridge and Mock) for unit tests without a JVM or a Sybase
+// server, and StartASE for integration tests against a real one.
+package gosybasetest
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	gosybase "github.com/CatHood0/Go-Sybase"
+	builder "github.com/CatHood0/Go-Sybase/builders"
+	sybase "github.com/CatHood0/Go-Sybase/internal"
+)
+
+// Response is a canned response of the fake bridge.
+type Response struct {
+	Rows       []map[string]any   // Rows of the first result set
+	Columns    []string           // Column order of Rows, as a columnar bridge reports it (optional)
+	ResultSets [][]map[string]any // Additional result sets
+	Messages   []string           // Server messages (e.g. SHOWPLAN output)
+	Err        error              // Error returned instead of the rows
+	Delay      time.Duration      // Time to wait before responding
+}
+
+// raw waits for the delay and returns the response as the bridge would.
+func (r Response) raw() (*sybase.RawResponse, error) {
+	if r.Delay > 0 {
+		time.Sleep(r.Delay)
+	}
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	raw := &sybase.RawResponse{Results: []map[string]any{}, Messages: r.Messages}
+	if r.Rows != nil {
+		raw.ResultSets = append(raw.ResultSets, r.Rows)
+		if r.Columns != nil {
+			raw.Columns = make([][]string, 1+len(r.ResultSets))
+			raw.Columns[0] = r.Columns
+		}
+	}
+	raw.ResultSets = append(raw.ResultSets, r.ResultSets...)
+	for _, resultSet := range raw.ResultSets {
+		raw.Results = append(raw.Results, resultSet...)
+	}
+	return raw, nil
+}
+
+type route struct {
+	pattern  *regexp.Regexp
+	response Response
+}
+
+// Bridge is an in-memory fake of the TDSLink bridge implementing
+// gosybase.Backend. Queries are answered with the response of the first
+// route whose pattern matches them, or with the default response.
+// Transactions and sessions (see gosybase.Database.NewSession) send their
+// queries through the same routes.
+//
+//	bridge := gosybasetest.NewBridge().
+//		On(`FROM users`, gosybasetest.Response{Rows: []map[string]any{{"id": 1.0, "name": "Ana"}}}).
+//		On(`^DELETE`, gosybasetest.Response{Err: errors.New("permission denied")})
+//	db := bridge.Open(builder.DialectASE)
+//
+// Numbers in the rows should be float64, as they arrive from the real bridge.
+type Bridge struct {
+	mu           sync.Mutex
+	routes       []route
+	fallback     Response
+	queries      []string
+	disconnected bool
+}
+
+var _ gosybase.Backend = (*Bridge)(nil)
+
+// NewBridge creates a fake bridge answering every query with no rows.
+func NewBridge() *Bridge {
+	return &Bridge{}
+}
+
+// On answers the queries matching the regular expression pattern with
+// response. Routes are tried in the order they were added.
+func (b *Bridge) On(pattern string, response Response) *Bridge {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.routes = append(b.routes, route{pattern: regexp.MustCompile(pattern), response: response})
+	return b
+}
+
+// Default sets the response of the queries matching no route.
+func (b *Bridge) Default(response Response) *Bridge {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fallback = response
+	return b
+}
+
+// Open returns a Database running its queries on the fake bridge.
+func (b *Bridge) Open(dialect builder.Dialect) *gosybase.Database {
+	return gosybase.NewDatabase(b, dialect)
+}
+
+// Queries returns every query received, in order, including the
+// COMMIT TRANSACTION and ROLLBACK TRANSACTION ending transactions.
+func (b *Bridge) Queries() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.queries...)
+}
+
+// Reset forgets the received queries, keeping the routes.
+func (b *Bridge) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.queries = nil
+}
+
+// Raw records sql and returns its canned response.
+func (b *Bridge) Raw(sql string) (*sybase.RawResponse, error) {
+	b.mu.Lock()
+	if b.disconnected {
+		b.mu.Unlock()
+		return nil, errors.New("database isn't connected")
+	}
+	b.queries = append(b.queries, sql)
+	response := b.fallback
+	for _, route := range b.routes {
+		if route.pattern.MatchString(sql) {
+			response = route.response
+			break
+		}
+	}
+	b.mu.Unlock()
+
+	return response.raw()
+}
+
+// Begin starts a fake transaction whose queries go through Raw.
+func (b *Bridge) Begin() (gosybase.BackendTx, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.disconnected {
+		return nil, errors.New("database isn't connected")
+	}
+	return &transaction{bridge: b}, nil
+}
+
+// Disconnect makes every later query fail, like a closed bridge.
+func (b *Bridge) Disconnect() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.disconnected {
+		return errors.New("Database isn't connected")
+	}
+	b.disconnected = true
+	return nil
+}
+
+// NewSession opens a fake session whose queries go through Raw.
+func (b *Bridge) NewSession() (gosybase.BackendSession, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.disconnected {
+		return nil, errors.New("database isn't connected")
+	}
+	return &session{bridge: b}, nil
+}
+
+type session struct {
+	bridge *Bridge
+	closed bool
+}
+
+func (s *session) Raw(sql string) (*sybase.RawResponse, error) {
+	if s.closed {
+		return nil, errors.New("session has already been closed")
+	}
+	return s.bridge.Raw(sql)
+}
+
+func (s *session) Close() error {
+	s.closed = true
+	return nil
+}
+
+type transaction struct {
+	bridge    *Bridge
+	finalized bool
+}
+
+func (t *transaction) Raw(sql string) (*sybase.RawResponse, error) {
+	if t.finalized {
+		return nil, errors.New("transaction has already been committed or rolled back")
+	}
+	return t.bridge.Raw(sql)
+}
+
+func (t *transaction) Commit() error {
+	return t.finish("COMMIT TRANSACTION")
+}
+
+func (t *transaction) Rollback() error {
+	return t.finish("ROLLBACK TRANSACTION")
+}
+
+func (t *transaction) finish(sql string) error {
+	if _, err := t.Raw(sql); err != nil {
+		return fmt.Errorf("%s failed: %w", sql, err)
+	}
+	t.finalized = true
+	return nil
+}
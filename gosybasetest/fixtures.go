@@ -0,0 +1,289 @@
+package gosybasetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	gosybase "github.com/CatHood0/Go-Sybase"
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+// fixtureBatchSize is the number of INSERT statements sent per request.
+const fixtureBatchSize = 100
+
+// FixtureOptions configures LoadFixtures.
+type FixtureOptions struct {
+	// Unmarshal decodes the fixture files by extension (".json", ".yaml").
+	// JSON is built in; YAML needs a decoder, e.g. {".yml": yaml.Unmarshal}.
+	Unmarshal map[string]func(data []byte, v any) error
+}
+
+// LoadFixtures replaces the rows of the tables of the fixture files of
+// fsys. A file holds either the rows of the table it is named after
+// (users.json: [{"id": 1, "name": "Ana"}]) or an object with the rows of
+// several tables ({"users": [...], "orders": [...]}).
+//
+// The tables are emptied and filled in one transaction, in foreign key
+// order: referencing tables are emptied first and filled last. Explicit
+// values of identity columns are inserted with SET IDENTITY_INSERT. The
+// test fails on any error.
+//
+//	db := gosybasetest.StartASE(t)
+//	gosybasetest.LoadFixtures(t, db, os.DirFS("testdata/fixtures"))
+func LoadFixtures(t testing.TB, db *gosybase.Database, fsys fs.FS, opts ...FixtureOptions) {
+	t.Helper()
+	var options FixtureOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	fixtures, err := readFixtures(fsys, options)
+	if err != nil {
+		t.Fatalf("gosybasetest: %s", err)
+	}
+	if err := loadFixtures(db, fixtures); err != nil {
+		t.Fatalf("gosybasetest: unable to load the fixtures: %s", err)
+	}
+}
+
+// readFixtures returns the rows of each table of the files of fsys.
+func readFixtures(fsys fs.FS, options FixtureOptions) (map[string][]map[string]any, error) {
+	fixtures := map[string][]map[string]any{}
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		extension := strings.ToLower(path.Ext(name))
+		unmarshal := options.Unmarshal[extension]
+		if unmarshal == nil && extension == ".json" {
+			unmarshal = unmarshalJSON
+		}
+		if unmarshal == nil {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		var content any
+		if err := unmarshal(data, &content); err != nil {
+			return fmt.Errorf("invalid fixture %s: %w", name, err)
+		}
+
+		table := strings.TrimSuffix(path.Base(name), path.Ext(name))
+		switch content := content.(type) {
+		case []any:
+			return addFixture(fixtures, table, content, name)
+		case map[string]any:
+			for table, rows := range content {
+				list, ok := rows.([]any)
+				if !ok {
+					return fmt.Errorf("invalid fixture %s: the rows of %s must be a list", name, table)
+				}
+				if err := addFixture(fixtures, table, list, name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return fmt.Errorf("invalid fixture %s: expected a list of rows or an object of tables", name)
+	})
+	return fixtures, err
+}
+
+// unmarshalJSON decodes JSON keeping integers exact (see fixtureValue).
+func unmarshalJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func addFixture(fixtures map[string][]map[string]any, table string, rows []any, name string) error {
+	for _, row := range rows {
+		values, ok := row.(map[string]any)
+		if !ok {
+			return fmt.Errorf("invalid fixture %s: the rows of %s must be objects", name, table)
+		}
+		fixtures[table] = append(fixtures[table], values)
+	}
+	// a table without rows is still emptied
+	if fixtures[table] == nil {
+		fixtures[table] = []map[string]any{}
+	}
+	return nil
+}
+
+// loadFixtures empties and fills the tables in a transaction.
+func loadFixtures(db *gosybase.Database, fixtures map[string][]map[string]any) error {
+	tables := make([]string, 0, len(fixtures))
+	for table := range fixtures {
+		tables = append(tables, table)
+	}
+	order, err := foreignKeyOrder(db, tables)
+	if err != nil {
+		return err
+	}
+
+	dialect := db.Dialect()
+	var statements []string
+	for _, table := range slices.Backward(order) {
+		statements = append(statements, "DELETE FROM "+dialect.QuoteIdentifier(table))
+	}
+	for _, table := range order {
+		inserts, err := fixtureInserts(db, table, fixtures[table])
+		if err != nil {
+			return err
+		}
+		statements = append(statements, inserts...)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	for batch := range slices.Chunk(statements, fixtureBatchSize) {
+		if _, err := tx.Exec(strings.Join(batch, "\n")); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// fixtureInserts returns the INSERT statements of the rows of table, with
+// SET IDENTITY_INSERT around them on ASE when they set the identity column.
+func fixtureInserts(db *gosybase.Database, table string, rows []map[string]any) ([]string, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	dialect := db.Dialect()
+	quoted := dialect.QuoteIdentifier(table)
+
+	var statements []string
+	identity := false
+	for _, row := range rows {
+		columns := make([]string, 0, len(row))
+		for column := range row {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
+		names := make([]string, len(columns))
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			names[i] = dialect.QuoteIdentifier(column)
+			values[i] = dialect.Literal(fixtureValue(row[column]))
+		}
+		statements = append(statements, "INSERT INTO "+quoted+" ("+strings.Join(names, ", ")+
+			") VALUES ("+strings.Join(values, ", ")+")")
+	}
+
+	if dialect == builder.DialectASE {
+		columns, err := db.DescribeTable(table)
+		if err != nil {
+			return nil, err
+		}
+		for _, column := range columns {
+			if !column.Identity {
+				continue
+			}
+			for _, row := range rows {
+				if _, ok := row[column.Name]; ok {
+					identity = true
+				}
+			}
+		}
+	}
+	if identity {
+		statements = append([]string{"SET IDENTITY_INSERT " + quoted + " ON"}, statements...)
+		statements = append(statements, "SET IDENTITY_INSERT "+quoted+" OFF")
+	}
+	return statements, nil
+}
+
+// fixtureValue converts the numbers of the decoders to integers when
+// possible: ASE doesn't convert strings to numbers implicitly.
+func fixtureValue(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	case map[string]any, []any:
+		// nested values are stored as JSON text
+		data, err := json.Marshal(v)
+		if err == nil {
+			return string(data)
+		}
+	}
+	return value
+}
+
+// foreignKeyOrder sorts tables so that the tables they reference come
+// first. References to tables outside the list and to the table itself are
+// ignored.
+func foreignKeyOrder(db *gosybase.Database, tables []string) ([]string, error) {
+	query := "SELECT object_name(tableid) AS child, object_name(reftabid) AS parent FROM sysreferences"
+	if db.Dialect() != builder.DialectASE {
+		query = "SELECT c.table_name AS child, p.table_name AS parent FROM SYS.SYSFOREIGNKEY f" +
+			" JOIN SYS.SYSTABLE c ON c.table_id = f.foreign_table_id" +
+			" JOIN SYS.SYSTABLE p ON p.table_id = f.primary_table_id"
+	}
+	type reference struct {
+		Child  string `db:"child"`
+		Parent string `db:"parent"`
+	}
+	references, err := gosybase.Pluck[reference](db, query)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := map[string]map[string]bool{}
+	for _, table := range tables {
+		pending[table] = map[string]bool{}
+	}
+	for _, ref := range references {
+		if pending[ref.Child] != nil && pending[ref.Parent] != nil && ref.Child != ref.Parent {
+			pending[ref.Child][ref.Parent] = true
+		}
+	}
+
+	// Kahn's algorithm, by name among the ready tables for a stable order
+	var order []string
+	for len(pending) > 0 {
+		var ready []string
+		for table, parents := range pending {
+			if len(parents) == 0 {
+				ready = append(ready, table)
+			}
+		}
+		if len(ready) == 0 {
+			var cycle []string
+			for table := range pending {
+				cycle = append(cycle, table)
+			}
+			sort.Strings(cycle)
+			return nil, fmt.Errorf("foreign key cycle between %s", strings.Join(cycle, ", "))
+		}
+		sort.Strings(ready)
+		for _, table := range ready {
+			delete(pending, table)
+			for _, parents := range pending {
+				delete(parents, table)
+			}
+		}
+		order = append(order, ready...)
+	}
+	return order, nil
+}
@@ -0,0 +1,303 @@
+package gosybasetest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	gosybase "github.com/CatHood0/Go-Sybase"
+	builder "github.com/CatHood0/Go-Sybase/builders"
+	sybase "github.com/CatHood0/Go-Sybase/internal"
+)
+
+type expectationKind int
+
+const (
+	expectQuery expectationKind = iota
+	expectExec
+	expectBegin
+	expectCommit
+	expectRollback
+)
+
+func (k expectationKind) String() string {
+	switch k {
+	case expectQuery:
+		return "query"
+	case expectExec:
+		return "exec"
+	case expectBegin:
+		return "begin"
+	case expectCommit:
+		return "commit"
+	case expectRollback:
+		return "rollback"
+	}
+	return "unknown"
+}
+
+// Expectation is a call the code under test is expected to make.
+type Expectation struct {
+	kind     expectationKind
+	pattern  *regexp.Regexp
+	response Response
+	met      bool
+}
+
+// WillReturnRows sets the rows returned by the query.
+func (e *Expectation) WillReturnRows(rows *Rows) *Expectation {
+	e.response.Rows = rows.rows
+	e.response.Columns = rows.columns
+	return e
+}
+
+// WillReturnResultSets adds result sets after the rows of the query.
+func (e *Expectation) WillReturnResultSets(resultSets ...*Rows) *Expectation {
+	for _, rows := range resultSets {
+		e.response.ResultSets = append(e.response.ResultSets, rows.rows)
+	}
+	return e
+}
+
+// WillReturnError makes the call fail with err.
+func (e *Expectation) WillReturnError(err error) *Expectation {
+	e.response.Err = err
+	return e
+}
+
+// WillDelayFor delays the response, e.g. to test timeouts.
+func (e *Expectation) WillDelayFor(delay time.Duration) *Expectation {
+	e.response.Delay = delay
+	return e
+}
+
+func (e *Expectation) String() string {
+	if e.pattern == nil {
+		return e.kind.String()
+	}
+	return fmt.Sprintf("%s matching %q", e.kind, e.pattern)
+}
+
+// Rows are the rows returned by an expected query.
+type Rows struct {
+	columns []string
+	rows    []map[string]any
+}
+
+// NewRows creates the rows of an expected query with the given columns.
+func NewRows(columns ...string) *Rows {
+	return &Rows{columns: columns, rows: []map[string]any{}}
+}
+
+// AddRow adds a row with one value per column. Values are converted as
+// they would arrive from the bridge: numbers become float64, times
+// strings, and so on.
+func (r *Rows) AddRow(values ...any) *Rows {
+	if len(values) != len(r.columns) {
+		panic(fmt.Sprintf("gosybasetest: AddRow got %d values for %d columns", len(values), len(r.columns)))
+	}
+	row := make(map[string]any, len(values))
+	for i, column := range r.columns {
+		row[column] = bridgeValue(values[i])
+	}
+	r.rows = append(r.rows, row)
+	return r
+}
+
+// bridgeValue converts value the way the JSON bridge protocol does.
+func bridgeValue(value any) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return value
+	}
+	return decoded
+}
+
+// Mock is a gosybase.Backend asserting which SQL the code under test sends,
+// in the style of sqlmock. Each expectation matches one call; by default
+// the calls must happen in the order they were expected.
+//
+//	mock := gosybasetest.NewMock()
+//	db := mock.Open(builder.DialectASE)
+//	mock.ExpectQuery(`SELECT \* FROM users WHERE id = 1`).
+//		WillReturnRows(gosybasetest.NewRows("id", "name").AddRow(1, "Ana"))
+//	mock.ExpectExec(`^DELETE FROM users`).WillReturnError(errors.New("permission denied"))
+//	...
+//	if err := mock.ExpectationsWereMet(); err != nil {
+//		t.Error(err)
+//	}
+type Mock struct {
+	mu           sync.Mutex
+	expectations []*Expectation
+	unexpected   []string
+	ordered      bool
+}
+
+var _ gosybase.Backend = (*Mock)(nil)
+
+// NewMock creates a mock with no expectations.
+func NewMock() *Mock {
+	return &Mock{ordered: true}
+}
+
+// Open returns a Database running its queries on the mock.
+func (m *Mock) Open(dialect builder.Dialect) *gosybase.Database {
+	return gosybase.NewDatabase(m, dialect)
+}
+
+// MatchExpectationsInOrder sets whether the calls must happen in the order
+// they were expected (default) or in any order.
+func (m *Mock) MatchExpectationsInOrder(ordered bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ordered = ordered
+}
+
+// ExpectQuery expects a query matching the regular expression pattern.
+func (m *Mock) ExpectQuery(pattern string) *Expectation {
+	return m.expect(expectQuery, regexp.MustCompile(pattern))
+}
+
+// ExpectExec expects a statement matching the regular expression pattern.
+// Queries and statements are sent the same way to the bridge, so both
+// kinds of expectation match any call with matching SQL.
+func (m *Mock) ExpectExec(pattern string) *Expectation {
+	return m.expect(expectExec, regexp.MustCompile(pattern))
+}
+
+// ExpectBegin expects a transaction to be started.
+func (m *Mock) ExpectBegin() *Expectation {
+	return m.expect(expectBegin, nil)
+}
+
+// ExpectCommit expects a transaction to be committed.
+func (m *Mock) ExpectCommit() *Expectation {
+	return m.expect(expectCommit, nil)
+}
+
+// ExpectRollback expects a transaction to be rolled back.
+func (m *Mock) ExpectRollback() *Expectation {
+	return m.expect(expectRollback, nil)
+}
+
+func (m *Mock) expect(kind expectationKind, pattern *regexp.Regexp) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expectation := &Expectation{kind: kind, pattern: pattern}
+	m.expectations = append(m.expectations, expectation)
+	return expectation
+}
+
+// ExpectationsWereMet returns an error describing the expectations that
+// were not met and the calls that matched no expectation.
+func (m *Mock) ExpectationsWereMet() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var problems []string
+	for _, call := range m.unexpected {
+		problems = append(problems, "unexpected "+call)
+	}
+	for _, expectation := range m.expectations {
+		if !expectation.met {
+			problems = append(problems, "expected "+expectation.String()+" was not called")
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New("gosybasetest: " + strings.Join(problems, "; "))
+}
+
+// match consumes the expectation matching the call.
+func (m *Mock) match(kind expectationKind, sql string) (Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, expectation := range m.expectations {
+		if expectation.met {
+			continue
+		}
+		if matches(expectation, kind, sql) {
+			expectation.met = true
+			return expectation.response, nil
+		}
+		if m.ordered {
+			break
+		}
+	}
+
+	call := kind.String()
+	if sql != "" {
+		call = fmt.Sprintf("%s %q", kind, sql)
+	}
+	m.unexpected = append(m.unexpected, call)
+	return Response{}, fmt.Errorf("gosybasetest: %s matches no expectation", call)
+}
+
+func matches(expectation *Expectation, kind expectationKind, sql string) bool {
+	switch kind {
+	case expectQuery, expectExec:
+		return (expectation.kind == expectQuery || expectation.kind == expectExec) && expectation.pattern.MatchString(sql)
+	}
+	return expectation.kind == kind
+}
+
+// Raw answers sql with the response of the matching expectation.
+func (m *Mock) Raw(sql string) (*sybase.RawResponse, error) {
+	response, err := m.match(expectQuery, sql)
+	if err != nil {
+		return nil, err
+	}
+	return response.raw()
+}
+
+// Begin matches an ExpectBegin expectation.
+func (m *Mock) Begin() (gosybase.BackendTx, error) {
+	response, err := m.match(expectBegin, "")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := response.raw(); err != nil {
+		return nil, err
+	}
+	return &mockTransaction{mock: m}, nil
+}
+
+// Disconnect does nothing.
+func (m *Mock) Disconnect() error {
+	return nil
+}
+
+type mockTransaction struct {
+	mock *Mock
+}
+
+func (t *mockTransaction) Raw(sql string) (*sybase.RawResponse, error) {
+	return t.mock.Raw(sql)
+}
+
+func (t *mockTransaction) Commit() error {
+	return t.finish(expectCommit)
+}
+
+func (t *mockTransaction) Rollback() error {
+	return t.finish(expectRollback)
+}
+
+func (t *mockTransaction) finish(kind expectationKind) error {
+	response, err := t.mock.match(kind, "")
+	if err != nil {
+		return err
+	}
+	_, err = response.raw()
+	return err
+}
@@ -0,0 +1,31 @@
+package gosybasetest
+
+import (
+	"testing"
+
+	gosybase "github.com/CatHood0/Go-Sybase"
+)
+
+// WithRollback runs fn in a transaction that is always rolled back, even
+// when fn fails the test or panics, so tests sharing a server leave no
+// data behind. The transaction runs on one connection of the bridge's
+// transaction pool, so temporary tables and @@identity are reliable.
+//
+//	gosybasetest.WithRollback(t, db, func(tx *gosybase.Tx) {
+//		tx.Exec("INSERT INTO users (name) VALUES ('Ana')")
+//		...
+//	})
+func WithRollback(t testing.TB, db *gosybase.Database, fn func(tx *gosybase.Tx)) {
+	t.Helper()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("gosybasetest: unable to begin the transaction: %s", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			t.Errorf("gosybasetest: unable to roll back the transaction: %s", err)
+		}
+	}()
+	fn(tx)
+}
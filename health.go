@@ -0,0 +1,31 @@
+package gosybase
+
+import (
+	"errors"
+
+	sybase "github.com/CatHood0/Go-Sybase/internal"
+)
+
+// BridgeHealth is the state of the bridge process: JVM memory, threads,
+// garbage collection and pool usage.
+type BridgeHealth = sybase.BridgeHealth
+
+// ErrHealthNotSupported is returned when the backend of a database does not
+// report its health.
+var ErrHealthNotSupported = errors.New("backend does not report its health")
+
+// BridgeHealth asks the bridge for its health, for dashboards and to
+// troubleshoot OutOfMemoryError crashes. A heap close to HeapMax calls for a
+// larger heap or smaller result sets.
+//
+//	health, err := db.BridgeHealth()
+//	if err == nil && health.HeapUsed > health.HeapMax*9/10 {
+//		log.Printf("bridge heap at %d of %d bytes", health.HeapUsed, health.HeapMax)
+//	}
+func (ds *Database) BridgeHealth() (*BridgeHealth, error) {
+	reporter, ok := ds.db.(interface{ BridgeHealth() (*BridgeHealth, error) })
+	if !ok {
+		return nil, ErrHealthNotSupported
+	}
+	return reporter.BridgeHealth()
+}
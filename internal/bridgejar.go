@@ -0,0 +1,55 @@
+package sybase
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+)
+
+// bridgeJar son las funciones de la línea de comandos que admite el jar del
+// puente. Main de los jars anteriores a ellas termina si recibe argumentos
+// de más, así que solo se envían a los que las admiten.
+type bridgeJar struct {
+	options   bool // Opciones key=value tras los argumentos (jdbc.*, tls.*, warmup...)
+	secretEnv bool // Contraseñas en variables de entorno (ver secretEnv)
+}
+
+// mainClass es la clase de entrada del puente en el jar.
+const mainClass = "Main.class"
+
+// Constantes de Main.java que delatan cada función; javac las deja en el
+// pool de constantes de Main.class.
+var (
+	driverPropertyMarker = []byte("jdbc.")
+	passwordEnvMarker    = []byte(passwordEnv)
+)
+
+// inspectBridgeJar lee Main.class del jar para saber qué funciones admite.
+// Si no puede leerlo, lo trata como un jar anterior a todas ellas.
+func inspectBridgeJar(path string) bridgeJar {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return bridgeJar{}
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		if file.Name != mainClass {
+			continue
+		}
+		class, err := file.Open()
+		if err != nil {
+			return bridgeJar{}
+		}
+		defer class.Close()
+		content, err := io.ReadAll(class)
+		if err != nil {
+			return bridgeJar{}
+		}
+		return bridgeJar{
+			options:   bytes.Contains(content, driverPropertyMarker),
+			secretEnv: bytes.Contains(content, passwordEnvMarker),
+		}
+	}
+	return bridgeJar{}
+}
@@ -0,0 +1,94 @@
+package sybase
+
+import (
+	"archive/zip"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeJar genera un jar cuyo Main.class contiene las constantes dadas,
+// como las deja javac en el pool de constantes.
+func writeJar(t *testing.T, constants ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "TDSLink.jar")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	writer := zip.NewWriter(file)
+	class, err := writer.Create(mainClass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := class.Write([]byte("\xca\xfe\xba\xbe" + strings.Join(constants, "\x01"))); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func testBridge(jar string) *Sybase {
+	return &Sybase{
+		host:       "db.example.com",
+		port:       "5000",
+		database:   "master",
+		username:   "sa",
+		password:   "s3cret-pw",
+		tdsJarPath: jar,
+	}
+}
+
+func TestCommandPassesPasswordInEnvironment(t *testing.T) {
+	s := testBridge(writeJar(t, "jdbc.", passwordEnv))
+
+	cmd, err := s.command()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, arg := range cmd.Args {
+		if strings.Contains(arg, s.password) {
+			t.Fatalf("argv contains the password: %q", cmd.Args)
+		}
+	}
+	if !slices.Contains(cmd.Env, passwordEnv+"="+s.password) {
+		t.Fatalf("env lacks %s", passwordEnv)
+	}
+	if !slices.ContainsFunc(cmd.Args, func(arg string) bool { return strings.HasPrefix(arg, "jdbc.APPLICATIONNAME=") }) {
+		t.Fatalf("argv lacks the application name: %q", cmd.Args)
+	}
+}
+
+func TestCommandKeepsLegacyArguments(t *testing.T) {
+	s := testBridge(writeJar(t))
+
+	cmd, err := s.command()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// java -jar <jar> y los 13 argumentos posicionales, sin opciones
+	if len(cmd.Args) != 3+13 {
+		t.Fatalf("argv = %q", cmd.Args)
+	}
+	if cmd.Args[3+4] != s.password {
+		t.Fatalf("password argument = %q", cmd.Args[3+4])
+	}
+	if slices.ContainsFunc(cmd.Env, func(env string) bool { return strings.HasPrefix(env, passwordEnv+"=") }) {
+		t.Fatalf("env carries %s for a jar that ignores it", passwordEnv)
+	}
+}
+
+func TestCommandRejectsOptionsForLegacyJar(t *testing.T) {
+	s := testBridge(writeJar(t))
+	s.config.TLS.Enabled = true
+
+	if _, err := s.command(); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("err = %v, want ErrUnsupported", err)
+	}
+}
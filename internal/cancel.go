@@ -0,0 +1,31 @@
+package sybase
+
+import "errors"
+
+// Cancel cancela la sentencia de la petición msgID, que termina con error.
+// El puente atiende la cancelación fuera del pool, aunque todas sus
+// conexiones estén ocupadas.
+func (s *Sybase) Cancel(msgID int) error {
+	if msgID <= 0 {
+		return errors.New("invalid message id")
+	}
+	_, err := s.send(QueryRequest{TransID: -1, FinishTrans: true, CancelMsgID: msgID})
+	return err
+}
+
+// KillSpid termina el proceso spid del servidor con kill, usando una
+// conexión nueva fuera de los pools del puente.
+func (s *Sybase) KillSpid(spid int) error {
+	if spid <= 0 {
+		return errors.New("invalid spid")
+	}
+	_, err := s.send(QueryRequest{TransID: -1, FinishTrans: true, KillSpid: spid})
+	return err
+}
+
+// Cancel cancela la sentencia que la transacción está ejecutando. No toma
+// el mutex de la transacción, que la sentencia en curso mantiene bloqueado.
+func (t *Transaction) Cancel() error {
+	_, err := t.Db.send(QueryRequest{TransID: -1, FinishTrans: true, CancelTransID: t.TxID})
+	return err
+}
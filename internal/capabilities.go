@@ -0,0 +1,204 @@
+package sybase
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrUnsupported indica que el puente no admite la función que usa la
+// petición, por ser de una versión anterior al cliente.
+var ErrUnsupported = errors.New("feature not supported by the bridge")
+
+// Capability es una función opcional del puente, que el cliente y el puente
+// negocian para trabajar juntos aunque sean de versiones distintas.
+type Capability string
+
+const (
+	CapabilityCancel         Capability = "cancel"         // Cancel y Transaction.Cancel
+	CapabilityKill           Capability = "kill"           // KillSpid
+	CapabilityReauthenticate Capability = "reauthenticate" // Cambio de credenciales de los pools
+	CapabilityHealth         Capability = "health"         // BridgeHealth
+	CapabilitySessions       Capability = "sessions"       // Sesiones con conexión propia
+	CapabilityMaxRows        Capability = "maxRows"        // Límite de filas del lado del puente
+	CapabilityTraceID        Capability = "traceId"        // TraceID en los logs del puente
+	CapabilityPaging         Capability = "paging"         // Cursores paginados (OpenCursor)
+	CapabilityColumnar       Capability = "columnar"       // Result sets como columnas y arrays de valores
+)
+
+// clientCapabilities son las capacidades que ofrece este cliente.
+var clientCapabilities = []Capability{
+	CapabilityCancel, CapabilityKill, CapabilityReauthenticate, CapabilityHealth,
+	CapabilitySessions, CapabilityMaxRows, CapabilityTraceID, CapabilityPaging,
+	CapabilityColumnar,
+}
+
+// legacyRequestError es el error con el que responde a la negociación un
+// puente anterior a ella, que la toma por una consulta vacía.
+const legacyRequestError = "Received empty SQL request"
+
+// negotiation es el resultado de negociar las capacidades con un proceso
+// del puente.
+type negotiation struct {
+	generation   int
+	done         chan struct{}
+	capabilities map[Capability]bool
+	err          error
+}
+
+// required devuelve las capacidades que la petición requiere del puente,
+// además de las funciones básicas.
+func (r QueryRequest) required() []Capability {
+	var capabilities []Capability
+	switch {
+	case r.CancelMsgID != 0 || r.CancelTransID != 0:
+		capabilities = append(capabilities, CapabilityCancel)
+	case r.KillSpid != 0:
+		capabilities = append(capabilities, CapabilityKill)
+	case r.Reauthenticate:
+		capabilities = append(capabilities, CapabilityReauthenticate)
+	case r.Health:
+		capabilities = append(capabilities, CapabilityHealth)
+	case r.SessionID != 0 || r.CloseSessionID != 0:
+		capabilities = append(capabilities, CapabilitySessions)
+	case r.PageSize != 0 || r.FetchCursor != 0 || r.CloseCursor != 0:
+		capabilities = append(capabilities, CapabilityPaging)
+	}
+	if r.MaxRows > 0 {
+		capabilities = append(capabilities, CapabilityMaxRows)
+	}
+	if r.TraceID != "" {
+		capabilities = append(capabilities, CapabilityTraceID)
+	}
+	if r.Columnar {
+		capabilities = append(capabilities, CapabilityColumnar)
+	}
+	return capabilities
+}
+
+// negotiate comprueba que el puente admite las funciones que usa la
+// petición. Sin ellas, el TraceID se descarta, los result sets llegan como
+// arrays de objetos, el límite de filas se aplica al recibir la respuesta
+// (ver RawResponse.truncate) y el resto falla con ErrUnsupported.
+func (s *Sybase) negotiate(req *QueryRequest) error {
+	for _, capability := range req.required() {
+		supported, err := s.supports(capability, req.background)
+		if err != nil {
+			return err
+		}
+		if supported {
+			continue
+		}
+
+		switch capability {
+		case CapabilityMaxRows:
+			req.clientLimit = req.MaxRows
+			req.MaxRows = 0
+		case CapabilityTraceID:
+			req.TraceID = ""
+		case CapabilityColumnar:
+			req.Columnar = false
+		default:
+			return fmt.Errorf("%w: %s", ErrUnsupported, capability)
+		}
+	}
+	return nil
+}
+
+// supports indica si el puente admite la capacidad, negociándolas con él la
+// primera vez tras lanzarlo.
+func (s *Sybase) supports(capability Capability, background bool) (bool, error) {
+	s.mu.Lock()
+	n := s.negotiation
+	owner := n == nil || n.generation != s.generation
+	if owner {
+		n = &negotiation{generation: s.generation, done: make(chan struct{})}
+		s.negotiation = n
+	}
+	s.mu.Unlock()
+
+	if owner {
+		n.capabilities, n.err = s.handshake(background)
+		if n.err != nil {
+			// se reintenta con la siguiente petición
+			s.mu.Lock()
+			if s.negotiation == n {
+				s.negotiation = nil
+			}
+			s.mu.Unlock()
+		}
+		close(n.done)
+	}
+	<-n.done
+	if n.err != nil {
+		return false, n.err
+	}
+	return n.capabilities[capability], nil
+}
+
+// Capabilities devuelve las capacidades comunes al cliente y al puente,
+// negociándolas si aún no se hizo.
+func (s *Sybase) Capabilities() ([]Capability, error) {
+	var capabilities []Capability
+	for _, capability := range clientCapabilities {
+		supported, err := s.supports(capability, false)
+		if err != nil {
+			return nil, err
+		}
+		if supported {
+			capabilities = append(capabilities, capability)
+		}
+	}
+	return capabilities, nil
+}
+
+// handshake envía al puente las capacidades del cliente y devuelve las que
+// ambos admiten. Un puente anterior a la negociación solo admite las
+// funciones básicas.
+func (s *Sybase) handshake(background bool) (map[Capability]bool, error) {
+	resp, err := s.send(QueryRequest{
+		TransID:      -1,
+		FinishTrans:  true,
+		Capabilities: clientCapabilities,
+		background:   background,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), legacyRequestError) {
+			return map[Capability]bool{}, nil
+		}
+		return nil, fmt.Errorf("unable to negotiate the bridge capabilities: %w", err)
+	}
+
+	capabilities := map[Capability]bool{}
+	if len(resp.Results) == 0 {
+		return capabilities, nil
+	}
+	offered, _ := resp.Results[0]["capabilities"].(string)
+	for _, name := range strings.Split(offered, ",") {
+		// el puente puede ofrecer capacidades que este cliente no conoce
+		if capability := Capability(strings.TrimSpace(name)); slices.Contains(clientCapabilities, capability) {
+			capabilities[capability] = true
+		}
+	}
+	return capabilities, nil
+}
+
+// truncate corta los result sets a maxRows filas, para los puentes que no
+// admiten CapabilityMaxRows.
+func (r *RawResponse) truncate(maxRows int) {
+	if maxRows <= 0 {
+		return
+	}
+	var results []map[string]any
+	for i, resultSet := range r.ResultSets {
+		if len(resultSet) > maxRows {
+			r.ResultSets[i] = resultSet[:maxRows]
+			r.Truncated = true
+		}
+		results = append(results, r.ResultSets[i]...)
+	}
+	if r.Truncated {
+		r.Results = results
+	}
+}
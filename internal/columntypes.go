@@ -0,0 +1,116 @@
+package sybase
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Códigos de java.sql.Types de las columnas que se decodifican según su tipo.
+const (
+	jdbcNumeric       = 2
+	jdbcDecimal       = 3
+	jdbcDate          = 91
+	jdbcTime          = 92
+	jdbcTimestamp     = 93
+	jdbcBinary        = -2
+	jdbcVarbinary     = -3
+	jdbcLongVarbinary = -4
+	jdbcBlob          = 2004
+	jdbcTinyint       = -6
+	jdbcSmallint      = 5
+	jdbcInteger       = 4
+	jdbcBigint        = -5
+)
+
+// Formatos de las fechas y horas que envía el puente.
+const (
+	bridgeDateLayout = "2006-01-02T15:04:05.000Z07:00"
+	bridgeTimeLayout = "15:04:05.999999999"
+)
+
+// ColumnType describe el tipo de una columna de un result set, tal como lo
+// informa el driver JDBC del puente.
+type ColumnType struct {
+	Name         string // Etiqueta de la columna
+	JDBCType     int    // Código de java.sql.Types (p. ej. 93 para TIMESTAMP)
+	DatabaseType string // Nombre del tipo en el servidor (p. ej. "datetime")
+}
+
+// IsTemporal indica si la columna es DATE, TIME o TIMESTAMP.
+func (c ColumnType) IsTemporal() bool {
+	return c.JDBCType == jdbcDate || c.JDBCType == jdbcTime || c.JDBCType == jdbcTimestamp
+}
+
+// IsDecimal indica si la columna es DECIMAL o NUMERIC.
+func (c ColumnType) IsDecimal() bool {
+	return c.JDBCType == jdbcDecimal || c.JDBCType == jdbcNumeric
+}
+
+// IsInteger indica si la columna es TINYINT, SMALLINT, INTEGER o BIGINT.
+func (c ColumnType) IsInteger() bool {
+	switch c.JDBCType {
+	case jdbcTinyint, jdbcSmallint, jdbcInteger, jdbcBigint:
+		return true
+	}
+	return false
+}
+
+// IsBinary indica si la columna es binaria (binary, varbinary, image).
+func (c ColumnType) IsBinary() bool {
+	switch c.JDBCType {
+	case jdbcBinary, jdbcVarbinary, jdbcLongVarbinary, jdbcBlob:
+		return true
+	}
+	return false
+}
+
+// columnTypes combina las columnas de un result set columnar con sus tipos.
+// Devuelve nil si el puente no envió los tipos.
+func (c columnarSet) columnTypes() ([]ColumnType, error) {
+	if c.Types == nil {
+		return nil, nil
+	}
+	if len(c.Types) != len(c.Columns) || c.TypeNames != nil && len(c.TypeNames) != len(c.Columns) {
+		return nil, fmt.Errorf("%d column types for %d columns", len(c.Types), len(c.Columns))
+	}
+	types := make([]ColumnType, len(c.Columns))
+	for i, column := range c.Columns {
+		types[i] = ColumnType{Name: column, JDBCType: c.Types[i]}
+		if c.TypeNames != nil {
+			types[i].DatabaseType = c.TypeNames[i]
+		}
+	}
+	return types, nil
+}
+
+// typedValue convierte un valor decodificado con json.Decoder.UseNumber
+// según el tipo de su columna: time.Time para fechas y horas, []byte para
+// las columnas binarias, json.Number, con todos sus dígitos, para DECIMAL y
+// NUMERIC, e int64 para las columnas enteras, para no perder precisión en
+// los BIGINT. El resto de números se convierten a float64, como sin
+// Config.TypedValues.
+func typedValue(value any, column ColumnType) (any, error) {
+	switch v := value.(type) {
+	case json.Number:
+		switch {
+		case column.IsDecimal():
+			return v, nil
+		case column.IsInteger():
+			return v.Int64()
+		}
+		return v.Float64()
+	case string:
+		switch {
+		case column.JDBCType == jdbcTime:
+			return time.Parse(bridgeTimeLayout, v)
+		case column.IsTemporal():
+			return time.Parse(bridgeDateLayout, v)
+		case column.IsBinary():
+			return hex.DecodeString(strings.TrimPrefix(v, "0x"))
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,29 @@
+package sybase
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestTypedValueNumbers(t *testing.T) {
+	tests := []struct {
+		value  json.Number
+		column ColumnType
+		want   any
+	}{
+		{"9007199254740993", ColumnType{JDBCType: jdbcBigint}, int64(9007199254740993)},
+		{"-7", ColumnType{JDBCType: jdbcSmallint}, int64(-7)},
+		{"12.50", ColumnType{JDBCType: jdbcDecimal}, json.Number("12.50")},
+		{"1.5", ColumnType{JDBCType: 8}, 1.5},
+	}
+	for _, test := range tests {
+		got, err := typedValue(test.value, test.column)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Fatalf("typedValue(%s, %d) = %#v, want %#v", test.value, test.column.JDBCType, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package sybase
+
+import (
+	"slices"
+	"time"
+)
+
+// Pools del puente
+const (
+	RegularPool     = "regular"     // Pool de las consultas fuera de transacción
+	TransactionPool = "transaction" // Pool de las transacciones
+)
+
+// ConnectionUse describe una conexión del puente en uso.
+type ConnectionUse struct {
+	ConnectionID int       // Id de la conexión física en el puente
+	Pool         string    // RegularPool o TransactionPool
+	MsgID        int       // Petición que la usa (0 en las transacciones)
+	TransID      int       // Transacción a la que está asignada (0 fuera de transacción)
+	SQL          string    // Sentencia en curso en la conexión, si la hay
+	Since        time.Time // Momento en que se tomó del pool
+}
+
+// PoolStats es el estado de los pools del puente visto desde Go, reconstruido
+// con los eventos de checkout y checkin que envía el puente.
+type PoolStats struct {
+	InUse       []ConnectionUse // Conexiones en uso, de la más antigua a la más reciente
+	Connections int             // Conexiones físicas distintas usadas desde el arranque del puente
+	Checkouts   int64           // Conexiones tomadas de los pools
+	Checkins    int64           // Conexiones devueltas a los pools
+}
+
+// trackConnection aplica un evento del pool del puente.
+func (s *Sybase) trackConnection(event QueryResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if event.ConnectionID > s.poolStats.Connections {
+		s.poolStats.Connections = event.ConnectionID
+	}
+
+	switch event.Event {
+	case "checkout":
+		s.poolStats.Checkouts++
+		s.connections[event.ConnectionID] = ConnectionUse{
+			ConnectionID: event.ConnectionID,
+			Pool:         event.Pool,
+			MsgID:        event.MsgID,
+			TransID:      max(event.TransactionID, 0),
+			Since:        time.Now(),
+		}
+		if size := s.poolSize(event.Pool); size > 0 && s.inUse(event.Pool) == size {
+			// las siguientes consultas esperarán una conexión
+			s.emit(Event{Type: EventPoolExhausted, Pool: event.Pool, Size: size})
+		}
+	case "checkin":
+		s.poolStats.Checkins++
+		delete(s.connections, event.ConnectionID)
+	}
+}
+
+// Stats devuelve el estado de los pools del puente. Cada conexión en uso
+// incluye la sentencia que ejecuta, si se conoce.
+func (s *Sybase) Stats() PoolStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.poolStats
+	stats.InUse = make([]ConnectionUse, 0, len(s.connections))
+	for _, use := range s.connections {
+		use.SQL = s.connectionSQL(use)
+		stats.InUse = append(stats.InUse, use)
+	}
+	slices.SortFunc(stats.InUse, func(a, b ConnectionUse) int { return a.Since.Compare(b.Since) })
+	return stats
+}
+
+// connectionSQL devuelve la sentencia en curso en la conexión. Requiere s.mu.
+func (s *Sybase) connectionSQL(use ConnectionUse) string {
+	if use.MsgID != 0 {
+		return s.activeQueries[use.MsgID].SQL
+	}
+	for _, query := range s.activeQueries {
+		if use.TransID != 0 && query.TransID == use.TransID {
+			return query.SQL
+		}
+	}
+	return ""
+}
+
+// poolSize devuelve el número de conexiones del pool.
+func (s *Sybase) poolSize(pool string) int {
+	if pool == TransactionPool {
+		return s.transactionConnections
+	}
+	return s.maxConnections
+}
+
+// PoolSize devuelve el número de conexiones del pool de las consultas fuera
+// de transacción (Config.MaxConnections), o 0 si no se configuró.
+func (s *Sybase) PoolSize() int {
+	return s.poolSize(RegularPool)
+}
+
+// inUse cuenta las conexiones en uso del pool. Requiere s.mu.
+func (s *Sybase) inUse(pool string) int {
+	count := 0
+	for _, use := range s.connections {
+		if use.Pool == pool {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,47 @@
+package sybase
+
+import (
+	"errors"
+	"fmt"
+)
+
+// refreshCredentials obtiene las credenciales del CredentialProvider.
+// Requiere s.mu.
+func (s *Sybase) refreshCredentials() error {
+	username, password, err := s.config.Credentials()
+	if err != nil {
+		return fmt.Errorf("unable to get the credentials: %w", err)
+	}
+	s.username, s.password = username, password
+	return nil
+}
+
+// Reauthenticate obtiene credenciales nuevas del CredentialProvider y las
+// aplica a los pools del puente sin reiniciarlo: el puente las comprueba
+// con una conexión de prueba y reemplaza las conexiones a medida que se
+// liberan. Si el servidor las rechaza se siguen usando las anteriores.
+func (s *Sybase) Reauthenticate() error {
+	if s.config.Credentials == nil {
+		return errors.New("no credential provider configured")
+	}
+	username, password, err := s.config.Credentials()
+	if err != nil {
+		return fmt.Errorf("unable to get the credentials: %w", err)
+	}
+
+	if _, err := s.send(QueryRequest{
+		TransID:        -1,
+		FinishTrans:    true,
+		Reauthenticate: true,
+		Username:       username,
+		Password:       password,
+	}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.username, s.password = username, password
+	s.redactor.set(password, s.config.TLS.TrustStorePassword)
+	s.mu.Unlock()
+	return nil
+}
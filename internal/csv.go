@@ -0,0 +1,107 @@
+package sybase
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// CSVOptions configura la exportación a CSV de RawResponse.WriteCSV.
+type CSVOptions struct {
+	Delimiter rune     // Separador de campos (default: ',')
+	Null      string   // Representación de NULL (default: cadena vacía)
+	Columns   []string // Columnas y su orden (default: las del primer result set, ver WriteCSV)
+	NoHeader  bool     // Omite la fila de encabezados
+	UseCRLF   bool     // Termina las líneas con \r\n
+}
+
+// WriteCSV escribe las filas en w como CSV, entrecomillando los campos
+// que lo necesiten. Se usan las columnas de opts.Columns o, si no se
+// indican, las del primer result set en el orden de la consulta. Con un
+// puente que no envía las columnas, las filas no conservan su orden y se
+// usan las de la primera fila ordenadas alfabéticamente.
+func (r *RawResponse) WriteCSV(w io.Writer, opts CSVOptions) error {
+	columns := opts.Columns
+	if len(columns) == 0 && len(r.Columns) > 0 {
+		columns = r.Columns[0]
+	}
+	if len(columns) == 0 {
+		columns = sortedColumns(r.Results)
+	}
+
+	writer := csv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		writer.Comma = opts.Delimiter
+	}
+	writer.UseCRLF = opts.UseCRLF
+
+	if !opts.NoHeader && len(columns) > 0 {
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, len(columns))
+	for _, row := range r.Results {
+		for i, column := range columns {
+			field, err := formatField(row[column], opts.Null)
+			if err != nil {
+				return fmt.Errorf("column %q: %w", column, err)
+			}
+			record[i] = field
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// sortedColumns devuelve las columnas de la primera fila en orden alfabético.
+func sortedColumns(rows []map[string]any) []string {
+	if len(rows) == 0 {
+		return nil
+	}
+	columns := make([]string, 0, len(rows[0]))
+	for column := range rows[0] {
+		columns = append(columns, column)
+	}
+	slices.Sort(columns)
+	return columns
+}
+
+// formatField convierte un valor recibido del puente en texto.
+func formatField(value any, null string) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return null, nil
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	// valores de Config.TypedValues, con el formato del puente
+	case json.Number:
+		return v.String(), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case time.Time:
+		return v.Format(bridgeDateLayout), nil
+	case []byte:
+		return "0x" + hex.EncodeToString(v), nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,136 @@
+package sybase
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Cursor lee el resultado de una consulta por páginas: el puente mantiene
+// abierto el ResultSet y envía cada página al pedirla, de modo que ni el
+// puente ni el cliente guardan más de una página en memoria. Solo se pagina
+// el primer result set de la consulta.
+type Cursor struct {
+	Db       *Sybase
+	ID       int // msgId de la consulta que lo abrió
+	PageSize int
+	Columns  []string     // Columnas del resultado, en orden (nil si el puente no las envía)
+	Types    []ColumnType // Tipos de las columnas (nil si el puente no los envía)
+
+	// Con Borrowed, las filas de cada página solo son válidas hasta la
+	// siguiente llamada a Next o Close, que reutilizan su memoria.
+	Borrowed bool
+
+	generation int              // Proceso del puente que tiene el ResultSet
+	page       []map[string]any // Primera página, recibida al abrirlo
+	last       *RawResponse     // Respuesta de la página entregada
+	done       bool             // Se leyó la última página o se cerró
+
+	mu sync.Mutex // Las páginas se piden de una en una
+}
+
+// OpenCursor ejecuta sql y devuelve un cursor sobre su resultado, en
+// páginas de pageSize filas. Requiere CapabilityPaging. El cursor retiene
+// una conexión del pool hasta leer la última página o cerrarlo.
+func (s *Sybase) OpenCursor(sql string, pageSize int) (*Cursor, error) {
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("invalid page size %d", pageSize)
+	}
+	// las páginas sustituyen al límite de filas
+	response, err := s.send(QueryRequest{TransID: -1, FinishTrans: true, SQL: sql, PageSize: pageSize, MaxRows: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := &Cursor{Db: s, ID: response.CursorID, PageSize: pageSize, Borrowed: s.config.LowAllocation,
+		page: response.Results, last: response, done: !response.HasMore}
+	if len(response.Columns) > 0 {
+		cursor.Columns = response.Columns[0]
+	}
+	if len(response.ColumnTypes) > 0 {
+		cursor.Types = response.ColumnTypes[0]
+	}
+	if !cursor.done {
+		s.mu.Lock()
+		// un cursor abierto, como una transacción, impide detener el puente
+		s.openTransactions++
+		cursor.generation = s.generation
+		s.mu.Unlock()
+	}
+	return cursor, nil
+}
+
+// Next devuelve la siguiente página de filas, o io.EOF tras la última.
+func (c *Cursor) Next() ([]map[string]any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if page := c.page; page != nil {
+		// la primera página aún no se entregó
+		c.page = nil
+		if len(page) > 0 {
+			return page, nil
+		}
+	}
+	c.release()
+	if c.done {
+		return nil, io.EOF
+	}
+	if !c.Db.running(c.generation) {
+		c.close()
+		return nil, errors.New("cursor lost: the bridge was restarted")
+	}
+
+	response, err := c.Db.send(QueryRequest{TransID: -1, FinishTrans: true, FetchCursor: c.ID, PageSize: c.PageSize})
+	if err != nil {
+		// el puente cierra el cursor que falla
+		c.close()
+		return nil, err
+	}
+	if !response.HasMore {
+		c.close()
+	}
+	c.last = response
+	if len(response.Results) == 0 {
+		return nil, io.EOF
+	}
+	return response.Results, nil
+}
+
+// Close cierra el cursor antes de leer todas sus páginas, devolviendo su
+// conexión al pool.
+func (c *Cursor) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.page = nil
+	c.release()
+	if c.done {
+		return nil
+	}
+	c.close()
+	if !c.Db.running(c.generation) {
+		// el ResultSet murió con el puente que lo tenía
+		return nil
+	}
+	_, err := c.Db.send(QueryRequest{TransID: -1, FinishTrans: true, CloseCursor: c.ID})
+	return err
+}
+
+// release devuelve al pool las filas de la página entregada, con Borrowed.
+// Requiere c.mu.
+func (c *Cursor) release() {
+	if c.Borrowed && c.last != nil {
+		c.last.Release()
+	}
+	c.last = nil
+}
+
+// close marca el cursor como terminado. Requiere c.mu.
+func (c *Cursor) close() {
+	c.done = true
+	c.Db.mu.Lock()
+	c.Db.openTransactions--
+	c.Db.mu.Unlock()
+}
@@ -0,0 +1,99 @@
+package sybase
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// benchRows son las filas de las respuestas de los benchmarks.
+const benchRows = 1000
+
+// legacyResponse genera una respuesta con los result sets como arrays de
+// objetos, como la envían los puentes sin CapabilityColumnar.
+func legacyResponse() []byte {
+	var sb strings.Builder
+	sb.WriteString(`{"messageId":1,"result":[[`)
+	for i := range benchRows {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprintf(&sb, `{"id":%d,"name":"user %d","balance":%d.25,"active":true,"created":"2024-01-02T03:04:05.000Z","avatar":"0x0aff%04x"}`, i, i, i, i)
+	}
+	sb.WriteString(`]]}`)
+	return []byte(sb.String())
+}
+
+// columnarResponse genera la misma respuesta en el formato columnar.
+func columnarResponse() []byte {
+	var sb strings.Builder
+	sb.WriteString(`{"messageId":1,"result":[{"columns":["id","name","balance","active","created","avatar"],` +
+		`"types":[4,12,3,-7,93,-3],"typeNames":["int","varchar","numeric","bit","datetime","varbinary"],"rows":[`)
+	for i := range benchRows {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprintf(&sb, `[%d,"user %d",%d.25,true,"2024-01-02T03:04:05.000Z","0x0aff%04x"]`, i, i, i, i)
+	}
+	sb.WriteString(`]}]}`)
+	return []byte(sb.String())
+}
+
+// benchmarkDecode decodifica data como lo hace handleResponses, devolviendo
+// las filas al pool tras cada respuesta si release.
+func benchmarkDecode(b *testing.B, data []byte, typed, release bool) {
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for b.Loop() {
+		resp := QueryResponse{Result: resultSets{typed: typed}}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			b.Fatal(err)
+		}
+		response, err := resp.Result.response()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(response.Results) != benchRows {
+			b.Fatalf("got %d rows", len(response.Results))
+		}
+		if release {
+			response.Release()
+		}
+	}
+}
+
+func BenchmarkDecodeLegacy(b *testing.B) {
+	benchmarkDecode(b, legacyResponse(), false, false)
+}
+
+func BenchmarkDecodeColumnar(b *testing.B) {
+	benchmarkDecode(b, columnarResponse(), false, false)
+}
+
+func BenchmarkDecodeColumnarTyped(b *testing.B) {
+	benchmarkDecode(b, columnarResponse(), true, false)
+}
+
+func BenchmarkDecodeColumnarPooled(b *testing.B) {
+	benchmarkDecode(b, columnarResponse(), false, true)
+}
+
+func BenchmarkDecodeStream(b *testing.B) {
+	data := columnarResponse()
+	data = append(data, '\n')
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for b.Loop() {
+		stream := newResponseStream(strings.NewReader(string(data)))
+		var resp QueryResponse
+		if _, err := stream.next(&resp); err != nil {
+			b.Fatal(err)
+		}
+		response, err := resp.Result.response()
+		if err != nil {
+			b.Fatal(err)
+		}
+		response.Release()
+	}
+}
@@ -0,0 +1,69 @@
+package sybase
+
+import (
+	"time"
+)
+
+// eventBuffer es la capacidad del canal de Events; los eventos que no caben
+// se descartan.
+const eventBuffer = 64
+
+// EventType es el tipo de un Event.
+type EventType int
+
+const (
+	EventConnected        EventType = iota + 1 // Connect lanzó el puente
+	EventDisconnected                          // Se perdió el puente o se llamó a Disconnect
+	EventBridgeRestarted                       // Reconnect o el keepalive relanzaron el puente
+	EventSlowQuery                             // Una consulta superó Config.SlowQueryThreshold
+	EventPoolExhausted                         // Un pool del puente tiene todas sus conexiones en uso
+	EventLongRunningQuery                      // Una consulta en curso superó Config.WatchdogThreshold
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventConnected:
+		return "Connected"
+	case EventDisconnected:
+		return "Disconnected"
+	case EventBridgeRestarted:
+		return "BridgeRestarted"
+	case EventSlowQuery:
+		return "SlowQuery"
+	case EventPoolExhausted:
+		return "PoolExhausted"
+	case EventLongRunningQuery:
+		return "LongRunningQuery"
+	}
+	return "Unknown"
+}
+
+// Event es un evento del ciclo de vida de la conexión.
+type Event struct {
+	Type EventType
+	Time time.Time
+	Err  error // Disconnected: causa de la caída (nil con Disconnect)
+
+	// SlowQuery y LongRunningQuery
+	Query    ActiveQuery
+	Duration time.Duration
+
+	// PoolExhausted
+	Pool string // RegularPool o TransactionPool
+	Size int    // Conexiones del pool, todas en uso
+}
+
+// Events devuelve el canal de eventos de la conexión. Es único y tiene un
+// buffer de 64 eventos: los que no caben porque nadie lo lee se descartan.
+func (s *Sybase) Events() <-chan Event {
+	return s.events
+}
+
+// emit publica un evento sin bloquear.
+func (s *Sybase) emit(event Event) {
+	event.Time = time.Now()
+	select {
+	case s.events <- event:
+	default:
+	}
+}
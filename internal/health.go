@@ -0,0 +1,68 @@
+package sybase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BridgeHealth es el estado del proceso del puente: memoria de la JVM,
+// hilos, recolección de basura y ocupación de los pools.
+type BridgeHealth struct {
+	Uptime time.Duration `json:"-"` // Tiempo desde el arranque de la JVM
+
+	// Memoria de la JVM, en bytes
+	HeapUsed      int64 `json:"heapUsed"`
+	HeapCommitted int64 `json:"heapCommitted"`
+	HeapMax       int64 `json:"heapMax"` // -Xmx (-1 si no está definido)
+	NonHeapUsed   int64 `json:"nonHeapUsed"`
+
+	Threads int           `json:"threads"`
+	GCCount int64         `json:"gcCount"` // Recolecciones desde el arranque
+	GCTime  time.Duration `json:"-"`       // Tiempo total en recolecciones
+
+	// Pool de consultas (Hikari)
+	PoolActive  int `json:"poolActive"`
+	PoolIdle    int `json:"poolIdle"`
+	PoolTotal   int `json:"poolTotal"`
+	PoolWaiting int `json:"poolWaiting"` // Consultas esperando una conexión
+
+	// Pool de transacciones
+	TransactionActive    int `json:"transactionActive"`
+	TransactionAvailable int `json:"transactionAvailable"`
+}
+
+// BridgeHealth consulta el estado del puente. Se atiende fuera de los pools,
+// por lo que responde aunque todas las conexiones estén ocupadas, y no
+// relanza un puente detenido por inactividad.
+func (s *Sybase) BridgeHealth() (*BridgeHealth, error) {
+	resp, err := s.send(QueryRequest{
+		TransID:     -1,
+		FinishTrans: true,
+		Health:      true,
+		background:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) == 0 {
+		return nil, errors.New("the bridge does not report its health")
+	}
+
+	data, err := json.Marshal(resp.Results[0])
+	if err != nil {
+		return nil, err
+	}
+	var health struct {
+		BridgeHealth
+		UptimeMs int64 `json:"uptimeMs"`
+		GCTimeMs int64 `json:"gcTimeMs"`
+	}
+	if err := json.Unmarshal(data, &health); err != nil {
+		return nil, fmt.Errorf("invalid health response: %w", err)
+	}
+	health.Uptime = time.Duration(health.UptimeMs) * time.Millisecond
+	health.GCTime = time.Duration(health.GCTimeMs) * time.Millisecond
+	return &health.BridgeHealth, nil
+}
@@ -0,0 +1,82 @@
+package sybase
+
+import (
+	"fmt"
+	"time"
+)
+
+// heartbeatMisses es el número de intervalos sin respuesta tras los que se
+// da el puente por caído si Config.HeartbeatTimeout es 0.
+const heartbeatMisses = 3
+
+// startHeartbeat lanza el heartbeat si Config.HeartbeatInterval está
+// definido. Requiere s.mu.
+func (s *Sybase) startHeartbeat() {
+	if s.config.HeartbeatInterval <= 0 || s.heartbeatStop != nil {
+		return
+	}
+	timeout := s.config.HeartbeatTimeout
+	if timeout <= 0 {
+		timeout = heartbeatMisses * s.config.HeartbeatInterval
+	}
+	s.heartbeatStop = make(chan struct{})
+	go s.heartbeat(s.config.HeartbeatInterval, timeout, s.heartbeatStop)
+}
+
+// stopHeartbeat detiene el heartbeat. Requiere s.mu.
+func (s *Sybase) stopHeartbeat() {
+	if s.heartbeatStop != nil {
+		close(s.heartbeatStop)
+		s.heartbeatStop = nil
+	}
+}
+
+// heartbeat envía cada interval un latido al puente, que lo responde sin
+// usar la base de datos. Si no responde en timeout, el proceso está
+// bloqueado o muerto sin haber cerrado sus pipes, y se da por caído como
+// tras un error fatal (ver fail).
+func (s *Sybase) heartbeat(interval, timeout time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		s.mu.Lock()
+		running := s.connected && !s.idle
+		generation := s.generation
+		s.mu.Unlock()
+		if !running {
+			continue
+		}
+
+		if !s.beat(timeout, stop) {
+			err := fmt.Errorf("the bridge missed its heartbeats for %s", timeout)
+			s.logger().Error("bridge heartbeat lost", "timeout", timeout)
+			s.fail(generation, err)
+		}
+	}
+}
+
+// beat envía un latido e indica si el puente respondió en timeout. Cualquier
+// respuesta vale, también el error de un puente que no conoce los latidos.
+func (s *Sybase) beat(timeout time.Duration, stop <-chan struct{}) bool {
+	done := make(chan struct{})
+	go func() {
+		s.send(QueryRequest{TransID: -1, FinishTrans: true, Heartbeat: true, background: true})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-stop:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
@@ -2,79 +2,137 @@ package sybase
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
-	"strings"
+	"slices"
 )
 
 const (
-	javaLogPrefix          = "JAVALOG:"
-	javaLogErrorPrefix     = "JAVAEXCEPTION:"
-	javaLogExceptionPrefix = "JAVAERROR:"
+	javaLogPrefix       = "JAVALOG:"
+	javaErrorPrefix     = "JAVAERROR:"
+	javaExceptionPrefix = "JAVAEXCEPTION:"
 )
 
+// IsConnected indica si la conexión está abierta, aunque el puente esté
+// detenido por inactividad (ver Config.IdleShutdown).
 func (s *Sybase) IsConnected() bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	return s.connected
+	return s.connected || s.idle
 }
 
-func (s *Sybase) handleErrors() {
-	scanner := bufio.NewScanner(s.stderr)
+// running indica si el puente de la generación dada sigue conectado.
+func (s *Sybase) running(generation int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connected && s.generation == generation
+}
+
+func (s *Sybase) handleErrors(stderr io.Reader, generation int) {
+	scanner := bufio.NewScanner(stderr)
 	for scanner.Scan() {
-		if !s.IsConnected() {
+		// un puente ya reemplazado por Reconnect no debe desconectar al nuevo
+		if !s.running(generation) {
 			break
 		}
 
 		// since output or errors comes in bytes format
 		// we prefer converting them into string
-		errMsg := string(scanner.Bytes())
+		event, _ := parseBridgeLog(string(s.decode(scanner.Bytes())))
+		s.bridgeLog(event)
+		if event.level >= slog.LevelError {
+			s.mu.Lock()
+			s.lastError = s.redactor.redact(event.message)
+			s.mu.Unlock()
+		}
+
 		switch {
-		case strings.HasPrefix(errMsg, javaLogErrorPrefix):
-		case strings.HasPrefix(errMsg, javaLogExceptionPrefix):
-			fmt.Printf("%s\n", errMsg)
-			continue
-		default:
-			fmt.Printf("Database error: %s\n", errMsg)
-			break
+		case event.fatal:
+			s.fail(generation, errors.New(event.message))
+		case event.msgID != 0:
+			// the request will not get any other response
+			s.mu.Lock()
+			if ch, exists := s.currentQueries[event.msgID]; exists {
+				select {
+				case ch <- QueryResponse{MsgID: event.msgID, Error: event.message}:
+				default:
+				}
+			}
+			s.mu.Unlock()
 		}
-		s.Disconnect()
 	}
 }
 
-func (s *Sybase) handleResponses() {
-	scanner := bufio.NewScanner(s.stdout)
-	for scanner.Scan() {
-		if !s.IsConnected() {
+func (s *Sybase) handleResponses(stdout io.Reader, generation int) {
+	stream := newResponseStream(s.charset.reader(stdout))
+	var err error
+	for {
+		resp := QueryResponse{Result: resultSets{typed: s.config.TypedValues}}
+		var line []byte
+		if line, err = stream.next(&resp); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) {
+				// EOF or a read error of the pipe
+				break
+			}
+			s.logger().Error("unable to parse a bridge response", "error", err, "source", "tdslink")
+			continue
+		}
+		if !s.running(generation) {
 			break
 		}
 
-		if s.logs {
-			// normally, these are response logs from the Tds bridge
-			// we prefer ignoring them just printing as a common log
-			cmdLog := string(scanner.Bytes())
-			if strings.HasPrefix(cmdLog, javaLogPrefix) {
-				fmt.Printf("%s\n", cmdLog)
-				continue
+		if line != nil {
+			// the bridge logs (Config.Logs) share stdout with the responses
+			if event, ok := parseBridgeLog(string(line)); ok {
+				s.bridgeLog(event)
+			} else if len(line) > 0 {
+				s.logger().Error("unable to parse a bridge response", "line", s.redactor.redact(string(line)), "source", "tdslink")
 			}
+			continue
 		}
 
-		var resp QueryResponse
-
-		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
-			fmt.Printf("error parsing response: %v\n", err)
+		if resp.Event != "" {
+			s.trackConnection(resp)
 			continue
 		}
 
 		s.mu.Lock()
 		if ch, exists := s.currentQueries[resp.MsgID]; exists {
-			ch <- resp
+			// the request may have failed already with an error from stderr
+			select {
+			case ch <- resp:
+			default:
+			}
 		}
 		s.mu.Unlock()
 	}
+
+	// the bridge died without being stopped
+	if s.running(generation) {
+		err = fmt.Errorf("the bridge stopped responding: %w", err)
+		s.bridgeLog(bridgeEvent{
+			category: LogBridgeStderr,
+			level:    slog.LevelError,
+			message:  err.Error(),
+			fatal:    true,
+		})
+		s.fail(generation, err)
+	}
+}
+
+// decode convierte una línea del puente a UTF-8 si usa otro charset.
+func (s *Sybase) decode(line []byte) []byte {
+	if s.charset == nil {
+		return line
+	}
+	return s.charset.decode(line)
 }
 
 func getExecutableDir() string {
@@ -126,22 +184,159 @@ func getTdsJarPath(config *Config) (*string, error) {
 	return &tdsPath, nil
 }
 
-func convertToRawResponse(data []any) (*RawResponse, error) {
-	var response RawResponse = RawResponse{Results: []map[string]any{}}
+// resultSets son los result sets de una respuesta del puente, decodificados
+// directamente en las filas finales, reutilizadas del pool.
+type resultSets struct {
+	sets    [][]map[string]any
+	columns [][]string     // Columnas de cada result set (nil en el formato anterior)
+	types   [][]ColumnType // Tipos de las columnas de cada result set (nil si el puente no los envía)
+	typed   bool           // Decodifica los valores según su tipo (Config.TypedValues)
+	err     error          // El resultado no tiene la forma esperada
+}
 
-	for _, jsonItem := range data {
-		jsonBytes, err := json.Marshal(jsonItem)
+// UnmarshalJSON decodifica cada result set en filas del pool. Un resultado
+// inválido no falla la decodificación, para que la consulta reciba el error
+// en lugar de quedarse sin respuesta.
+func (rs *resultSets) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		if token != nil {
+			rs.err = fmt.Errorf("invalid result: expected an array of result sets")
+		}
+		return nil
+	}
+	for decoder.More() {
+		var set json.RawMessage
+		if err := decoder.Decode(&set); err != nil {
+			rs.err = fmt.Errorf("invalid result set: %w", err)
+			return nil
+		}
+		rows, columns, types, err := decodeResultSet(set, rs.typed)
 		if err != nil {
-			return nil, fmt.Errorf("error al serializar el dato: %v", err)
+			rs.err = fmt.Errorf("invalid result set: %w", err)
+			return nil
+		}
+		rs.sets = append(rs.sets, rows)
+		rs.columns = append(rs.columns, columns)
+		rs.types = append(rs.types, types)
+	}
+	return nil
+}
+
+// columnarSet es un result set en el formato columnar: las columnas una vez,
+// en el orden de la consulta, con sus tipos, y cada fila como un array de
+// valores.
+type columnarSet struct {
+	Columns   []string        `json:"columns"`
+	Types     []int           `json:"types"`     // Códigos de java.sql.Types
+	TypeNames []string        `json:"typeNames"` // Tipos del servidor
+	Rows      json.RawMessage `json:"rows"`      // Se decodifican fila a fila al conocer las columnas
+}
+
+// decodeResultSet decodifica un result set en el formato columnar
+// ({"columns": [...], "rows": [[...]]}) o, si el puente no lo admite, como un
+// array de objetos, del que no se conoce el orden ni el tipo de las
+// columnas. Con typed, los valores del formato columnar se convierten según
+// el tipo de su columna (ver typedValue).
+func decodeResultSet(data []byte, typed bool) ([]map[string]any, []string, []ColumnType, error) {
+	rows := borrowRows()
+	if len(data) == 0 || data[0] != '{' {
+		err := json.Unmarshal(data, &rows)
+		return rows, nil, nil, err
+	}
+
+	var set columnarSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return rows, nil, nil, err
+	}
+	types, err := set.columnTypes()
+	if err != nil {
+		return rows, nil, nil, err
+	}
+	typed = typed && types != nil
+
+	decoder := json.NewDecoder(bytes.NewReader(set.Rows))
+	if typed {
+		// los DECIMAL conservan todos sus dígitos
+		decoder.UseNumber()
+	}
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return rows, nil, nil, errors.New("expected an array of rows")
+	}
+
+	// los mapas de un slice del pool se reutilizan, como hace json.Unmarshal,
+	// y cada fila se decodifica en el mismo slice de valores
+	pooled := rows[:cap(rows)]
+	values := make([]any, 0, len(set.Columns))
+	for i := 0; decoder.More(); i++ {
+		if err := decoder.Decode(&values); err != nil {
+			return rows, nil, nil, err
 		}
+		if len(values) != len(set.Columns) {
+			return rows, nil, nil, fmt.Errorf("row %d has %d values for %d columns", i, len(values), len(set.Columns))
+		}
+		var row map[string]any
+		if i < len(pooled) && pooled[i] != nil {
+			row = pooled[i]
+		} else {
+			row = make(map[string]any, len(set.Columns))
+		}
+		for j, column := range set.Columns {
+			value := values[j]
+			if typed {
+				if value, err = typedValue(value, types[j]); err != nil {
+					return rows, nil, nil, fmt.Errorf("column %q: %w", column, err)
+				}
+			}
+			row[column] = value
+		}
+		rows = append(rows, row)
+	}
+	return rows, set.Columns, types, nil
+}
+
+// response crea la respuesta con los result sets, sin copiarlos.
+func (rs resultSets) response() (*RawResponse, error) {
+	if rs.err != nil {
+		return nil, rs.err
+	}
+
+	response := &RawResponse{ResultSets: rs.sets}
+	if slices.ContainsFunc(rs.columns, func(columns []string) bool { return columns != nil }) {
+		response.Columns = rs.columns
+	}
+	if slices.ContainsFunc(rs.types, func(types []ColumnType) bool { return types != nil }) {
+		response.ColumnTypes = rs.types
+	}
+	switch len(rs.sets) {
+	case 0:
+		response.Results = []map[string]any{}
+	case 1:
+		response.Results = rs.sets[0]
+	default:
+		rows := 0
+		for _, set := range rs.sets {
+			rows += len(set)
+		}
+		response.Results = make([]map[string]any, 0, rows)
+		for _, set := range rs.sets {
+			response.Results = append(response.Results, set...)
+		}
+	}
+	return response, nil
+}
 
-		var jsonMap []map[string]any
-		if err := json.Unmarshal(jsonBytes, &jsonMap); err != nil {
-			return nil, fmt.Errorf("error al parsear el dato: %v", err)
+// sameColumns indica si la fila tiene exactamente las columnas indicadas.
+func sameColumns(columns map[string]bool, row map[string]any) bool {
+	if len(columns) != len(row) {
+		return false
+	}
+	for column := range row {
+		if !columns[column] {
+			return false
 		}
-		response.Results = append(response.Results, jsonMap...)
 	}
-	return &response, nil
+	return true
 }
 
 func checkFileExistence(path string) bool {
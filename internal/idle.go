@@ -0,0 +1,71 @@
+package sybase
+
+import (
+	"errors"
+	"time"
+)
+
+// touch registra actividad y programa la comprobación de inactividad.
+// Requiere s.mu.
+func (s *Sybase) touch() {
+	s.lastUsed = time.Now()
+	if s.config.IdleShutdown <= 0 {
+		return
+	}
+	if s.idleTimer == nil {
+		s.idleTimer = time.AfterFunc(s.config.IdleShutdown, s.checkIdle)
+		return
+	}
+	s.idleTimer.Reset(s.config.IdleShutdown)
+}
+
+// checkIdle detiene el puente si lleva Config.IdleShutdown sin consultas,
+// salvo que tenga consultas o transacciones en curso.
+func (s *Sybase) checkIdle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.connected || s.idleTimer == nil {
+		return
+	}
+	if remaining := s.config.IdleShutdown - time.Since(s.lastUsed); remaining > 0 {
+		s.idleTimer.Reset(remaining)
+		return
+	}
+	if len(s.currentQueries) > 0 || s.openTransactions > 0 {
+		s.idleTimer.Reset(s.config.IdleShutdown)
+		return
+	}
+
+	s.stop()
+	s.idle = true
+}
+
+// resume relanza el puente si se detuvo por inactividad. Requiere s.mu.
+func (s *Sybase) resume() error {
+	if s.idle {
+		if err := s.connect(); err != nil {
+			return err
+		}
+		s.idle = false
+	}
+	if !s.connected {
+		return errors.New("database isn't connected")
+	}
+	return nil
+}
+
+// isIdle indica si el puente está detenido por inactividad.
+func (s *Sybase) isIdle() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.idle
+}
+
+// stopIdleTimer cancela la comprobación de inactividad. Requiere s.mu.
+func (s *Sybase) stopIdleTimer() {
+	if s.idleTimer != nil {
+		s.idleTimer.Stop()
+		s.idleTimer = nil
+	}
+}
@@ -0,0 +1,46 @@
+package sybase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// lookupGC devuelve la opción de la JVM que selecciona el recolector de
+// basura indicado (g1, parallel, serial, zgc, shenandoah). "" deja el de la
+// JVM.
+func lookupGC(name string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "":
+		return "", nil
+	case "g1", "g1gc":
+		return "-XX:+UseG1GC", nil
+	case "parallel", "parallelgc":
+		return "-XX:+UseParallelGC", nil
+	case "serial", "serialgc":
+		return "-XX:+UseSerialGC", nil
+	case "zgc", "z":
+		return "-XX:+UseZGC", nil
+	case "shenandoah", "shenandoahgc":
+		return "-XX:+UseShenandoahGC", nil
+	default:
+		return "", fmt.Errorf("unsupported garbage collector %q", name)
+	}
+}
+
+// memoryOptions devuelve las opciones de memoria y recolección de la JVM
+// del puente.
+func (s *Sybase) memoryOptions() []string {
+	var options []string
+	if s.config.BridgeHeapMB > 0 {
+		options = append(options, "-Xmx"+strconv.Itoa(s.config.BridgeHeapMB)+"m")
+	}
+	if s.config.BridgeInitialHeapMB > 0 {
+		options = append(options, "-Xms"+strconv.Itoa(s.config.BridgeInitialHeapMB)+"m")
+	}
+	// validado en NewConnectionInstance
+	if gc, _ := lookupGC(s.config.BridgeGC); gc != "" {
+		options = append(options, gc)
+	}
+	return append(options, s.config.BridgeJVMOptions...)
+}
@@ -0,0 +1,110 @@
+package sybase
+
+import (
+	"errors"
+	"time"
+)
+
+// defaultKeepalive es el intervalo de KeepalivePing si KeepaliveTime es 0.
+const defaultKeepalive = 30 * time.Second
+
+// startKeepalive lanza el ping periódico si Config.KeepalivePing está
+// activo. Requiere s.mu.
+func (s *Sybase) startKeepalive() {
+	if !s.config.KeepalivePing || s.keepaliveStop != nil {
+		return
+	}
+	interval := time.Duration(s.keepaliveTime) * time.Millisecond
+	if interval <= 0 {
+		interval = defaultKeepalive
+	}
+	s.keepaliveStop = make(chan struct{})
+	go s.keepalive(interval, s.keepaliveStop)
+}
+
+// stopKeepalive detiene el ping periódico. Requiere s.mu.
+func (s *Sybase) stopKeepalive() {
+	if s.keepaliveStop != nil {
+		close(s.keepaliveStop)
+		s.keepaliveStop = nil
+	}
+}
+
+// keepalive hace ping al servidor a través del puente cada interval y lo
+// reinicia en cuanto un ping falla, en lugar de esperar a que falle una
+// consulta del usuario.
+func (s *Sybase) keepalive(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		if s.isIdle() {
+			// el puente está detenido a propósito
+			continue
+		}
+
+		err := s.ping(interval)
+		if err == nil {
+			continue
+		}
+		s.mu.Lock()
+		select {
+		case <-stop:
+			// Disconnect durante el ping
+			s.mu.Unlock()
+			return
+		default:
+		}
+		if s.idle {
+			s.mu.Unlock()
+			continue
+		}
+		lost := s.linkLost()
+		s.mu.Unlock()
+
+		s.logger().Warn("keepalive ping failed, restarting the bridge", "error", err)
+		if lost {
+			s.onDisconnect(err)
+		}
+
+		s.mu.Lock()
+		select {
+		case <-stop:
+			s.mu.Unlock()
+			return
+		default:
+		}
+		err = s.restart()
+		restored := err == nil && s.linkRestored()
+		s.mu.Unlock()
+		if err != nil {
+			s.logger().Error("keepalive reconnection failed", "error", err)
+		} else {
+			s.emit(Event{Type: EventBridgeRestarted})
+		}
+		if restored {
+			s.onReconnect()
+		}
+	}
+}
+
+// ping ejecuta una consulta trivial, fallando si no responde en timeout.
+func (s *Sybase) ping(timeout time.Duration) error {
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.send(QueryRequest{TransID: -1, FinishTrans: true, SQL: "SELECT 1", background: true})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return errors.New("ping timed out")
+	}
+}
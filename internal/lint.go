@@ -0,0 +1,50 @@
+package sybase
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSuspiciousSQL indica que LintSQL encontró en una sentencia un patrón
+// típico de la inyección de SQL por concatenación.
+var ErrSuspiciousSQL = errors.New("suspicious SQL")
+
+// stackedKeywords son las palabras que, fuera de paréntesis y tras el inicio
+// de la sentencia, suelen indicar una segunda sentencia inyectada sin punto
+// y coma (p. ej. "... WHERE id = 1 DROP TABLE t").
+var stackedKeywords = map[string]bool{
+	"DROP": true, "EXEC": true, "EXECUTE": true, "SHUTDOWN": true, "TRUNCATE": true,
+	"ALTER": true, "CREATE": true, "GRANT": true, "REVOKE": true, "KILL": true,
+}
+
+// LintSQL busca en la sentencia patrones de concatenación sospechosos:
+// comillas sin cerrar, sentencias apiladas y comentarios. Es una
+// heurística: no detecta toda inyección y rechaza algunas sentencias
+// legítimas, que deberían construirse con los builders o con sentencias
+// preparadas.
+func LintSQL(sql string) error {
+	tokens := sqlTokens(sql)
+	for i, token := range tokens {
+		switch {
+		case token.text == "'" || token.text == `"` || token.text == "[":
+			return fmt.Errorf("%w: unbalanced %s", ErrSuspiciousSQL, token.text)
+		case token.text == "--" || token.text == "/*":
+			return fmt.Errorf("%w: comment sequence %s", ErrSuspiciousSQL, token.text)
+		case token.text == ";" && hasStatement(tokens[i+1:]):
+			return fmt.Errorf("%w: stacked statements", ErrSuspiciousSQL)
+		case i > 0 && token.depth == 0 && stackedKeywords[token.text]:
+			return fmt.Errorf("%w: stacked statement %s", ErrSuspiciousSQL, token.text)
+		}
+	}
+	return nil
+}
+
+// hasStatement indica si quedan palabras tras un punto y coma.
+func hasStatement(tokens []sqlToken) bool {
+	for _, token := range tokens {
+		if token.text != ";" {
+			return true
+		}
+	}
+	return false
+}
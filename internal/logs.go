@@ -0,0 +1,128 @@
+package sybase
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// LogCategory es el tipo de una línea de log del puente.
+type LogCategory string
+
+const (
+	LogBridgeInfo      LogCategory = "info"      // JAVALOG: trazas del puente (Config.Logs)
+	LogBridgeError     LogCategory = "error"     // JAVAERROR: errores del puente
+	LogBridgeException LogCategory = "exception" // JAVAEXCEPTION: excepciones de Java
+	LogBridgeStderr    LogCategory = "stderr"    // Otras líneas de stderr, p. ej. avisos de la JVM
+)
+
+// fatalMarkers identifican las líneas de stderr tras las que el puente no
+// puede seguir funcionando.
+var fatalMarkers = []string{
+	"java.lang.OutOfMemoryError",
+	"java.lang.StackOverflowError",
+	`Exception in thread "main"`,
+	"A fatal error has been detected by the Java Runtime Environment",
+	"Error occurred during initialization of VM",
+	"Could not reserve enough space",
+	"Unable to access jarfile",
+	"Could not find or load main class",
+}
+
+// requestTag es la etiqueta con la que el puente atribuye un error a una
+// petición que no recibirá respuesta.
+var requestTag = regexp.MustCompile(`^\[msgId=(\d+)\]\s*`)
+
+// traceTag es la etiqueta con la que el puente marca los logs de una
+// petición con el TraceID de QueryRequest.
+var traceTag = regexp.MustCompile(`^\[traceId=([^\]]+)\]\s*`)
+
+// bridgeEvent es una línea de log del puente clasificada.
+type bridgeEvent struct {
+	category LogCategory
+	level    slog.Level
+	message  string
+	msgID    int    // Petición a la que se atribuye el error (0 si no se conoce)
+	traceID  string // TraceID de la petición que originó el log
+	fatal    bool   // El puente no puede seguir funcionando
+}
+
+// parseBridgeLog reconoce y clasifica una línea de log del puente. ok es
+// false si la línea no tiene ninguno de los prefijos del puente.
+func parseBridgeLog(line string) (event bridgeEvent, ok bool) {
+	ok = true
+	switch {
+	case strings.HasPrefix(line, javaLogPrefix):
+		event = bridgeEvent{category: LogBridgeInfo, level: slog.LevelInfo, message: line[len(javaLogPrefix):]}
+	case strings.HasPrefix(line, javaErrorPrefix):
+		event = bridgeEvent{category: LogBridgeError, level: slog.LevelError, message: line[len(javaErrorPrefix):]}
+	case strings.HasPrefix(line, javaExceptionPrefix):
+		event = bridgeEvent{category: LogBridgeException, level: slog.LevelError, message: line[len(javaExceptionPrefix):]}
+	default:
+		ok = false
+		event = bridgeEvent{category: LogBridgeStderr, level: slog.LevelWarn, message: line}
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "at ") ||
+			strings.HasPrefix(trimmed, "Caused by:") || strings.HasPrefix(trimmed, "...") {
+			// continuación de una traza, ya registrada con su excepción
+			event.level = slog.LevelDebug
+		}
+	}
+	event.message = strings.TrimSpace(event.message)
+
+	if match := traceTag.FindStringSubmatch(event.message); match != nil {
+		event.traceID = match[1]
+		event.message = event.message[len(match[0]):]
+	}
+	if match := requestTag.FindStringSubmatch(event.message); match != nil {
+		event.msgID, _ = strconv.Atoi(match[1])
+		event.message = event.message[len(match[0]):]
+	}
+	for _, marker := range fatalMarkers {
+		if strings.Contains(event.message, marker) {
+			event.fatal = true
+			event.level = slog.LevelError
+			break
+		}
+	}
+	return event, ok
+}
+
+// logger devuelve el logger de SetLogger, el de Config.Logger o el de slog
+// por defecto.
+func (s *Sybase) logger() *slog.Logger {
+	if logger := s.loggerOverride.Load(); logger != nil {
+		return logger
+	}
+	if s.config.Logger != nil {
+		return s.config.Logger
+	}
+	return slog.Default()
+}
+
+// SetLogger reemplaza el logger de Config.Logger; nil lo restaura.
+func (s *Sybase) SetLogger(logger *slog.Logger) {
+	s.loggerOverride.Store(logger)
+}
+
+// bridgeLog envía una línea de log del puente al logger con su nivel, salvo
+// que la categoría esté en Config.SuppressLogs. Los errores fatales se
+// registran siempre.
+func (s *Sybase) bridgeLog(event bridgeEvent) {
+	if !event.fatal && slices.Contains(s.config.SuppressLogs, event.category) {
+		return
+	}
+	attrs := []slog.Attr{slog.String("source", "tdslink"), slog.String("category", string(event.category))}
+	if event.msgID != 0 {
+		attrs = append(attrs, slog.Int("msgId", event.msgID))
+	}
+	if event.traceID != "" {
+		attrs = append(attrs, slog.String("traceId", event.traceID))
+	}
+	if event.fatal {
+		attrs = append(attrs, slog.Bool("fatal", true))
+	}
+	s.logger().LogAttrs(context.Background(), event.level, s.redactor.redact(event.message), attrs...)
+}
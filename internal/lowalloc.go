@@ -0,0 +1,45 @@
+package sybase
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// maxPooledBuffer acota los buffers de peticiones que vuelven al pool, para
+// no retener la memoria de una sentencia excepcionalmente grande.
+const maxPooledBuffer = 64 * 1024
+
+// requestBuffers guarda los buffers en que se codifican las peticiones con
+// Config.LowAllocation.
+var requestBuffers = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// writePooled es writeRequest para Config.LowAllocation: codifica la
+// petición en un buffer del pool y la escribe con una sola llamada, sin
+// copias intermedias.
+func (s *Sybase) writePooled(req QueryRequest) error {
+	buffer := requestBuffers.Get().(*bytes.Buffer)
+	defer func() {
+		if buffer.Cap() <= maxPooledBuffer {
+			requestBuffers.Put(buffer)
+		}
+	}()
+	buffer.Reset()
+
+	// Encode termina la petición con el salto de línea que espera el puente
+	if err := json.NewEncoder(buffer).Encode(req); err != nil {
+		return fmt.Errorf("error marshaling query: %w", err)
+	}
+	data := buffer.Bytes()
+	if s.charset != nil {
+		data = s.charset.encode(data)
+	}
+
+	if _, err := s.stdin.Write(data); err != nil {
+		return fmt.Errorf("failed to send query: %w", err)
+	}
+	return nil
+}
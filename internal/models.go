@@ -2,9 +2,13 @@ package sybase
 
 import (
 	"io"
+	"log/slog"
 	"os/exec"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
 )
 
 // Sybase representa una conexión a una base de datos Sybase mediante un puente Java.
@@ -22,8 +26,8 @@ type Sybase struct {
 	maxConnections         int // Máximo de conexiones en el pool (default: 10)
 	connectionTimeout      int // Tiempo máximo (segundos) para conectar (default: 30)
 	idleTimeout            int // Tiempo máximo (segundos) de inactividad antes de cerrar conexión (default: 300)
-	keepaliveTime          int // Intervalo (segundos) para verificar conexiones activas (default: 30)
-	maxLifetime            int // Vida máxima (segundos) de una conexión (default: 3600)
+	keepaliveTime          int // Intervalo (milisegundos) para verificar conexiones activas
+	maxLifetime            int // Vida máxima (milisegundos) de una conexión
 	transactionConnections int // Conexiones reservadas para transacciones (default: 2)
 
 	// Logging
@@ -31,6 +35,8 @@ type Sybase struct {
 
 	tdsJarPath string // Ruta absoluta al archivo .jar del puente Java
 
+	charset *charset // Charset de los pipes del puente (nil si es UTF-8)
+
 	// Gestión del proceso Java
 	cmd    *exec.Cmd      // Proceso del puente Java
 	stdin  io.WriteCloser // Pipe para enviar comandos al proceso Java
@@ -38,12 +44,30 @@ type Sybase struct {
 	stderr io.ReadCloser  // Pipe para leer errores del proceso
 
 	// Estado interno
-	connected        bool                       // Indica si la conexión está activa
-	queryCount       int                        // Contador incremental de consultas
-	currentQueries   map[int]chan QueryResponse // Canales activos por queryID
-	transactionCount int                        // Contador de transacciones activas
-	mu               sync.Mutex                 // Mutex para operaciones concurrentes
-	config           Config                     // Configuración extendida
+	connected        bool                        // Indica si la conexión está activa
+	queryCount       int                         // Contador incremental de consultas
+	currentQueries   map[int]chan QueryResponse  // Canales activos por queryID
+	activeQueries    map[int]ActiveQuery         // Datos de las consultas en curso por queryID
+	connections      map[int]ConnectionUse       // Conexiones del puente en uso, por id de conexión
+	poolStats        PoolStats                   // Contadores de los eventos del pool del puente
+	transactionCount int                         // Contador de transacciones activas
+	sessionCount     int                         // Contador de sesiones abiertas con NewSession
+	generation       int                         // Número de procesos del puente lanzados, para detectar reinicios
+	keepaliveStop    chan struct{}               // Detiene el keepalive del lado de Go
+	watchdogStop     chan struct{}               // Detiene el watchdog de consultas largas
+	heartbeatStop    chan struct{}               // Detiene el heartbeat con el puente
+	negotiation      *negotiation                // Capacidades negociadas con el proceso actual del puente
+	openTransactions int                         // Transacciones sin terminar, que impiden detener el puente
+	lastUsed         time.Time                   // Última consulta enviada
+	idle             bool                        // El puente se detuvo por inactividad y se relanzará con la próxima consulta
+	idleTimer        *time.Timer                 // Comprueba la inactividad tras Config.IdleShutdown
+	linkDown         bool                        // Se perdió el puente y aún no se ha reconectado
+	events           chan Event                  // Eventos de Events
+	lastError        string                      // Último error del puente, para explicar por qué terminó
+	redactor         redactor                    // Oculta la contraseña en logs y errores
+	loggerOverride   atomic.Pointer[slog.Logger] // Logger de SetLogger, que reemplaza a Config.Logger
+	mu               sync.Mutex                  // Mutex para operaciones concurrentes
+	config           Config                      // Configuración extendida
 }
 
 type Config struct {
@@ -56,28 +80,259 @@ type Config struct {
 	MaxConnections         int
 	ConnectionTimeout      int
 	IdleTimeout            int
-	KeepaliveTime          int
-	MaxLifetime            int
+	KeepaliveTime          int           // Intervalo (milisegundos) de keepalive de las conexiones del pool
+	KeepalivePing          bool          // Hace además ping desde Go cada KeepaliveTime (default: 30s) y reinicia el puente si falla
+	IdleShutdown           time.Duration // Detiene el puente tras este tiempo sin consultas y lo relanza con la siguiente (default: nunca)
+	MaxLifetime            int           // Vida máxima (milisegundos) de las conexiones del puente, que se retiran y recrean al superarla (0: sin límite; mínimo de Hikari: 30000)
 	TransactionConnections int
 	Logs                   bool
 	TdsLink                string
 	TdsProperties          string
 	Timeout                time.Duration
+	Dialect                builder.Dialect // Variante de Sybase del servidor (default: ASE)
+	Charset                string          // Charset del servidor: iso_1, cp850, roman8, cp1252, cp437 (default: utf8)
+	AppName                string          // Nombre de la aplicación en sp_who y sysprocesses (default: nombre del ejecutable)
+	TLS                    TLSConfig       // Conexiones cifradas con SSL/TLS
+	Auth                   AuthConfig      // Método de autenticación (default: contraseña)
+	Credentials            CredentialProvider
+	Logger                 *slog.Logger  // Destino de los logs, incluidos los del puente (default: slog.Default())
+	SuppressLogs           []LogCategory // Categorías de logs del puente descartadas
+
+	// Supervisión: se llaman sin bloquear la conexión, por lo que pueden
+	// usarla. OnDisconnect recibe la causa de la caída del puente (error
+	// fatal o ping fallido); OnReconnect, tras relanzarlo Reconnect o el
+	// keepalive. No se llaman con Disconnect ni con IdleShutdown.
+	OnDisconnect func(err error)
+	OnReconnect  func()
+
+	SlowQueryThreshold time.Duration // Duración a partir de la cual una consulta emite EventSlowQuery (default: nunca)
+
+	// Watchdog: las consultas que siguen en curso tras WatchdogThreshold se
+	// registran en el log con su SQL, msgId y la pila de llamadas que las
+	// envió, y emiten EventLongRunningQuery; con WatchdogCancel, además se
+	// cancelan. A diferencia de SlowQueryThreshold, no espera a que
+	// terminen, por lo que encuentra las sentencias bloqueadas.
+	// Si la consulta cancelada sigue en curso tras WatchdogGrace (puente
+	// bloqueado), el watchdog reinicia el puente y las consultas en curso
+	// fallan con ErrBridgeRestarted (default: nunca).
+	WatchdogThreshold time.Duration
+	WatchdogCancel    bool
+	WatchdogGrace     time.Duration
+
+	// Heartbeat: cada HeartbeatInterval se envía un latido al puente, que lo
+	// responde sin usar la base de datos. Si no responde en HeartbeatTimeout
+	// (default: tres intervalos), el puente se da por caído: sus consultas
+	// fallan, se llama a OnDisconnect y el keepalive lo relanza. Detecta un
+	// puente bloqueado sin esperar a que una consulta no responda.
+	HeartbeatInterval time.Duration
+	HeartbeatTimeout  time.Duration
+
+	// TypedValues decodifica los valores según el tipo de su columna, que
+	// informa el puente, en lugar de deducirlo del JSON: time.Time para
+	// fechas y horas, []byte para las columnas binarias y json.Number, sin
+	// perder dígitos, para DECIMAL y NUMERIC. Sin él, llegan como strings y
+	// float64. Los puentes sin CapabilityColumnar no informan los tipos.
+	TypedValues bool
+
+	// LowAllocation activa la ruta de pocas asignaciones, para servicios
+	// sensibles al throughput. Al formato columnar, las filas del pool y el
+	// decodificador en streaming, que se usan siempre, añade: las peticiones
+	// se codifican en buffers del pool, las filas que recibe el callback de
+	// Query vuelven al pool al terminar y los cursores usan Borrowed. Ni las
+	// filas ni sus mapas deben retenerse tras el callback o tras pedir la
+	// siguiente página; cópielas si hace falta.
+	LowAllocation bool
+
+	// ReadOnly rechaza con ErrReadOnly, sin enviarlas al puente, las
+	// sentencias de escritura: INSERT, UPDATE, DELETE, SELECT INTO, EXEC
+	// (también las llamadas implícitas a procedimientos) y DDL, incluso sobre
+	// tablas temporales. Para servicios de informes que nunca deben
+	// modificar la base.
+	ReadOnly bool
+
+	// Policies se aplican a cada sentencia antes de enviarla al puente; la
+	// primera que devuelve un error la bloquea con ErrPolicyViolation. Hay
+	// reglas predefinidas (DenyDeleteWithoutWhere, DenyTruncate,
+	// DenyCrossDatabase...) y Allow y Deny para listas de patrones.
+	Policies []Policy
+
+	// MaxRows limita las filas que el puente devuelve por result set
+	// (Statement.setMaxRows), para que una consulta sin filtro no agote la
+	// memoria; RawResponse.Truncated indica que se descartaron filas. El
+	// driver aplica el límite con SET ROWCOUNT, que también limita UPDATE,
+	// DELETE y SELECT INTO, por lo que no se aplica a las sentencias de
+	// escritura. RawMaxRows lo reemplaza en una consulta. (default: sin límite)
+	MaxRows int
+
+	// WarmUp hace que Connect no vuelva hasta que el puente haya abierto
+	// MinConnections conexiones y ejecutado WarmUpProbe en cada una (y en
+	// las del pool de transacciones), para que las primeras consultas no
+	// paguen la latencia de conexión ni descubran tarde credenciales rotas.
+	WarmUp      bool
+	WarmUpProbe string // Sentencia de prueba (default: SELECT 1)
+
+	// JVM del puente. Con el heap por defecto (1/4 de la RAM) un result set
+	// grande puede terminar la JVM con OutOfMemoryError.
+	BridgeHeapMB        int      // Heap máximo en MB (-Xmx)
+	BridgeInitialHeapMB int      // Heap inicial en MB (-Xms)
+	BridgeGC            string   // Recolector de basura: g1, parallel, serial, zgc, shenandoah (default: el de la JVM)
+	BridgeJVMOptions    []string // Opciones adicionales de la JVM, p. ej. -XX:+HeapDumpOnOutOfMemoryError
+}
+
+// CredentialProvider devuelve las credenciales vigentes, p. ej. leídas de un
+// gestor de secretos. Si se define, reemplaza a Username y Password: se
+// consulta en cada Connect (y de nuevo si el login falla) y en Reauthenticate.
+type CredentialProvider func() (username, password string, err error)
+
+// AuthMethod es el método con el que el puente se autentica en el servidor.
+type AuthMethod int
+
+const (
+	AuthPassword AuthMethod = iota // Usuario y contraseña de Sybase (default)
+	AuthKerberos                   // Kerberos (GSSAPI) con el ticket del usuario o un keytab
+	AuthLDAP                       // Usuario y contraseña validados por el servidor contra LDAP
+)
+
+// AuthConfig configura la autenticación del puente.
+type AuthConfig struct {
+	Method AuthMethod
+
+	// Kerberos
+	ServicePrincipal string // SPN del servidor (default: el nombre del servidor)
+	Krb5Config       string // Ruta del krb5.conf (default: el de la JVM)
+	JAASConfig       string // Ruta de la configuración JAAS, p. ej. para usar un keytab (default: caché de tickets)
+
+	// EncryptPassword cifra la contraseña en el login (ENCRYPT_PASSWORD).
+	// Siempre activo con AuthLDAP, que envía la contraseña LDAP al servidor.
+	EncryptPassword bool
+}
+
+// TLSConfig configura las conexiones SSL/TLS del driver del puente.
+type TLSConfig struct {
+	Enabled            bool   // Cifra las conexiones (ENABLE_SSL)
+	CAFile             string // Bundle PEM con los certificados de CA de confianza
+	TrustStore         string // Trust store JKS o PKCS12, alternativa a CAFile
+	TrustStorePassword string // Contraseña del trust store
+	TrustStoreType     string // Tipo del trust store: JKS o PKCS12 (default: el de la JVM)
+	VerifyHostname     bool   // Comprueba que el certificado sea de ServerName (SSL_HOSTNAME_IN_CERT)
+	ServerName         string // Nombre esperado en el certificado (default: Host)
+	InsecureSkipVerify bool   // Acepta cualquier certificado (SSL_TRUST_ALL_CERTS); solo para pruebas
 }
 
 type RawResponse struct {
-	Results []map[string]any
+	Results     []map[string]any
+	ResultSets  [][]map[string]any // Filas agrupadas por cada result set devuelto
+	Columns     [][]string         // Columnas de cada result set, en el orden de la consulta (nil si el puente no las envía)
+	ColumnTypes [][]ColumnType     // Tipos de las columnas de cada result set (nil si el puente no los envía)
+	Messages    []string           // Mensajes informativos del servidor (p. ej. SHOWPLAN)
+
+	ConnectionID int  // Conexión física del puente que ejecutó la consulta (0 si no se conoce)
+	Truncated    bool // Algún result set se cortó en MaxRows filas
+
+	CursorID int  // Cursor paginado que sigue abierto (ver OpenCursor)
+	HasMore  bool // El cursor tiene más páginas
+}
+
+// SplitCompute separa las filas de detalle de las filas generadas por COMPUTE.
+// El primer result set define las columnas de detalle; los result sets con
+// columnas distintas se consideran filas de COMPUTE.
+func (r *RawResponse) SplitCompute() (detail []map[string]any, compute []map[string]any) {
+	if len(r.ResultSets) == 0 {
+		return r.Results, nil
+	}
+
+	var detailColumns map[string]bool
+	for _, resultSet := range r.ResultSets {
+		if len(resultSet) == 0 {
+			continue
+		}
+		if detailColumns == nil {
+			detailColumns = make(map[string]bool, len(resultSet[0]))
+			for column := range resultSet[0] {
+				detailColumns[column] = true
+			}
+		}
+
+		if sameColumns(detailColumns, resultSet[0]) {
+			detail = append(detail, resultSet...)
+			continue
+		}
+		compute = append(compute, resultSet...)
+	}
+	return detail, compute
 }
 
 type QueryRequest struct {
-	MsgID       int    `json:"msgId"`
-	TransID     int    `json:"transId,omitempty"`
-	FinishTrans bool   `json:"finishTrans,omitempty"`
-	SQL         string `json:"sql"`
+	MsgID         int    `json:"msgId"`
+	TransID       int    `json:"transId,omitempty"`
+	FinishTrans   bool   `json:"finishTrans"` // El puente asume true si se omite
+	SQL           string `json:"sql"`
+	CancelMsgID   int    `json:"cancelMsgId,omitempty"`   // Petición cuya sentencia se cancela
+	CancelTransID int    `json:"cancelTransId,omitempty"` // Transacción cuya sentencia en curso se cancela
+	KillSpid      int    `json:"killSpid,omitempty"`      // Proceso del servidor a terminar con kill
+
+	// Cambio de credenciales de los pools del puente
+	Reauthenticate bool   `json:"reauthenticate,omitempty"`
+	Username       string `json:"username,omitempty"`
+	Password       string `json:"password,omitempty"`
+
+	Health    bool `json:"health,omitempty"`    // Pide el estado de la JVM y los pools del puente
+	Heartbeat bool `json:"heartbeat,omitempty"` // Latido que el puente responde sin usar la base de datos
+
+	SessionID      int `json:"sessionId,omitempty"`      // Sesión cuya conexión ejecuta la consulta
+	CloseSessionID int `json:"closeSessionId,omitempty"` // Sesión a cerrar, liberando su conexión
+
+	MaxRows int    `json:"maxRows,omitempty"` // Filas devueltas por result set (0: Config.MaxRows; negativo: sin límite)
+	TraceID string `json:"traceId,omitempty"` // Identificador de correlación que el puente repite en sus logs
+
+	Capabilities []Capability `json:"capabilities,omitempty"` // Negociación: capacidades que ofrece el cliente
+
+	// Cursores paginados (ver OpenCursor)
+	PageSize    int `json:"pageSize,omitempty"`    // Filas de cada página
+	FetchCursor int `json:"fetchCursor,omitempty"` // Cursor cuya siguiente página se pide
+	CloseCursor int `json:"closeCursor,omitempty"` // Cursor a cerrar antes de leer todas sus páginas
+
+	Columnar bool `json:"columnar,omitempty"` // Pide los result sets como columnas y arrays de valores
+
+	background  bool // Petición interna (ping) que no cuenta como actividad
+	clientLimit int  // Límite de filas que aplica el cliente si el puente no admite MaxRows
+}
+
+// control indica si la petición actúa sobre el puente en lugar de ejecutar SQL.
+func (r QueryRequest) control() bool {
+	return r.CancelMsgID != 0 || r.CancelTransID != 0 || r.KillSpid != 0 || r.Reauthenticate || r.Health || r.Heartbeat || r.CloseSessionID != 0 ||
+		len(r.Capabilities) > 0 || r.FetchCursor != 0 || r.CloseCursor != 0
+}
+
+// ActiveQuery describe una consulta enviada al puente que aún no ha respondido.
+type ActiveQuery struct {
+	MsgID     int       // Id de la petición, usado por Cancel
+	SQL       string    // Sentencia enviada
+	StartedAt time.Time // Momento del envío
+	TransID   int       // Transacción de la consulta (0 fuera de transacción)
+	TraceID   string    // Identificador de correlación de la petición de la aplicación
+	Stack     string    // Pila de llamadas que envió la consulta (solo con Config.WatchdogThreshold)
+
+	flagged bool // El watchdog ya la señaló
 }
 
 type QueryResponse struct {
-	MsgID  int    `json:"msgId,omitempty"`
-	Result []any  `json:"result"`
-	Error  string `json:"error,omitempty"`
+	MsgID    int        `json:"messageId,omitempty"`
+	Result   resultSets `json:"result"`
+	Messages []string   `json:"messages,omitempty"`
+	Error    string     `json:"error,omitempty"`
+	// El puente descartó las filas que superaban maxRows
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Eventos del pool (checkout/checkin), que no responden a ninguna petición
+	Event         string `json:"event,omitempty"`
+	Pool          string `json:"pool,omitempty"`
+	ConnectionID  int    `json:"connectionId,omitempty"`
+	TransactionID int    `json:"transactionId,omitempty"`
+
+	// Cursores paginados: el ResultSet sigue abierto con más filas
+	CursorID int  `json:"cursorId,omitempty"`
+	HasMore  bool `json:"hasMore,omitempty"`
+
+	err error // Causa del fallo cuando la respuesta no viene del puente
 }
@@ -0,0 +1,102 @@
+package sybase
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// bridgeOptions devuelve las opciones key=value que siguen a los argumentos
+// del puente. Las claves jdbc.* se pasan al driver como propiedades de
+// conexión de jConnect. Un jar que no admite opciones no identifica la
+// conexión, y falla con ErrUnsupported si la configuración requiere alguna
+// otra, ya que conectar sin ella cambiaría la conexión (TLS, Kerberos...).
+func (s *Sybase) bridgeOptions(jar bridgeJar) ([]string, error) {
+	properties := map[string]string{}
+	options := []string{}
+
+	if tls := s.config.TLS; tls.Enabled {
+		properties["ENABLE_SSL"] = "true"
+		if tls.InsecureSkipVerify {
+			properties["SSL_TRUST_ALL_CERTS"] = "true"
+		}
+		if tls.VerifyHostname {
+			properties["SSL_HOSTNAME_IN_CERT"] = cmp.Or(tls.ServerName, s.host)
+		}
+		// los certificados de confianza los configura el puente
+		options = appendOption(options, "tls.caFile", tls.CAFile)
+		options = appendOption(options, "tls.trustStore", tls.TrustStore)
+		options = appendOption(options, "tls.trustStoreType", tls.TrustStoreType)
+	}
+
+	switch s.config.Auth.Method {
+	case AuthKerberos:
+		properties["REQUEST_KERBEROS_SESSION"] = "true"
+		if s.config.Auth.ServicePrincipal != "" {
+			properties["SERVICE_PRINCIPAL_NAME"] = s.config.Auth.ServicePrincipal
+		}
+	case AuthLDAP:
+		properties["ENCRYPT_PASSWORD"] = "true"
+	}
+	if s.config.Auth.EncryptPassword {
+		properties["ENCRYPT_PASSWORD"] = "true"
+	}
+
+	for name, value := range properties {
+		options = append(options, "jdbc."+name+"="+value)
+	}
+	if s.config.WarmUp {
+		options = append(options, "warmup="+cmp.Or(s.config.WarmUpProbe, defaultWarmUpProbe))
+	}
+	if s.config.Credentials != nil {
+		// reemplaza también al del fichero de propiedades, como la
+		// contraseña (ver secretEnv)
+		options = append(options, "username="+s.username)
+	}
+	if !jar.options {
+		if len(options) > 0 {
+			slices.Sort(options)
+			return nil, fmt.Errorf("%w: %s needs a newer bridge jar (rebuild libs/TDSLink)", ErrUnsupported, options[0])
+		}
+		return nil, nil
+	}
+
+	// identifican la conexión en sp_who y sysprocesses
+	appName := s.config.AppName
+	if appName == "" {
+		appName = filepath.Base(os.Args[0])
+	}
+	options = append(options, "jdbc.APPLICATIONNAME="+appName)
+	if hostname, err := os.Hostname(); err == nil {
+		options = append(options, "jdbc.HOSTNAME="+hostname)
+	}
+	slices.Sort(options)
+	return options, nil
+}
+
+// appendOption añade la opción key=value si value no está vacío.
+func appendOption(options []string, key, value string) []string {
+	if value == "" {
+		return options
+	}
+	return append(options, key+"="+value)
+}
+
+// javaOptions devuelve las opciones de la JVM del puente: la memoria, el
+// charset de los pipes y la configuración de Kerberos.
+func (s *Sybase) javaOptions() []string {
+	options := append(s.memoryOptions(), s.charset.javaOptions()...)
+	if auth := s.config.Auth; auth.Method == AuthKerberos {
+		// sin configuración JAAS, GSSAPI usa la caché de tickets del usuario
+		options = append(options, "-Djavax.security.auth.useSubjectCredsOnly=false")
+		if auth.Krb5Config != "" {
+			options = append(options, "-Djava.security.krb5.conf="+auth.Krb5Config)
+		}
+		if auth.JAASConfig != "" {
+			options = append(options, "-Djava.security.auth.login.config="+auth.JAASConfig)
+		}
+	}
+	return options
+}
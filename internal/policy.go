@@ -0,0 +1,170 @@
+package sybase
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrPolicyViolation indica que una política de Config.Policies bloqueó una
+// sentencia antes de enviarla al puente.
+var ErrPolicyViolation = errors.New("statement blocked by the query policy")
+
+// Policy decide si una sentencia puede enviarse al servidor. Devuelve nil
+// para permitirla, o un error que explica la regla incumplida.
+type Policy func(sql string) error
+
+// checkPolicies aplica las políticas de Config.Policies, en orden.
+func (s *Sybase) checkPolicies(sql string) error {
+	for _, policy := range s.config.Policies {
+		if err := policy(sql); err != nil {
+			if errors.Is(err, ErrPolicyViolation) {
+				return err
+			}
+			return fmt.Errorf("%w: %w", ErrPolicyViolation, err)
+		}
+	}
+	return nil
+}
+
+// violation crea el error de una regla incumplida.
+func violation(rule string) error {
+	return fmt.Errorf("%w: %s", ErrPolicyViolation, rule)
+}
+
+// Deny bloquea las sentencias en las que se encuentra pattern.
+func Deny(rule string, pattern *regexp.Regexp) Policy {
+	return func(sql string) error {
+		if pattern.MatchString(sql) {
+			return violation(rule)
+		}
+		return nil
+	}
+}
+
+// Allow solo permite las sentencias en las que se encuentra alguno de los
+// patrones.
+func Allow(patterns ...*regexp.Regexp) Policy {
+	return func(sql string) error {
+		for _, pattern := range patterns {
+			if pattern.MatchString(sql) {
+				return nil
+			}
+		}
+		return violation("statement not in the allowlist")
+	}
+}
+
+// DenyTruncate bloquea TRUNCATE TABLE.
+func DenyTruncate(sql string) error {
+	for _, word := range sqlWords(sqlTokens(sql)) {
+		if word == "TRUNCATE" {
+			return violation("TRUNCATE is not allowed")
+		}
+	}
+	return nil
+}
+
+// DenyDeleteWithoutWhere bloquea los DELETE sin WHERE, que borran la tabla
+// completa.
+func DenyDeleteWithoutWhere(sql string) error {
+	if missingWhere(sqlTokens(sql), "DELETE") {
+		return violation("DELETE without WHERE is not allowed")
+	}
+	return nil
+}
+
+// DenyUpdateWithoutWhere bloquea los UPDATE sin WHERE, que modifican la
+// tabla completa.
+func DenyUpdateWithoutWhere(sql string) error {
+	if missingWhere(sqlTokens(sql), "UPDATE") {
+		return violation("UPDATE without WHERE is not allowed")
+	}
+	return nil
+}
+
+// statementStarts son las palabras que empiezan otra sentencia del lote.
+// SET no se incluye porque forma parte de UPDATE.
+var statementStarts = map[string]bool{
+	"SELECT": true, "INSERT": true, "UPDATE": true, "DELETE": true, "IF": true,
+	"ELSE": true, "BEGIN": true, "END": true, "WHILE": true, "RETURN": true,
+	"EXEC": true, "EXECUTE": true, "DECLARE": true, "PRINT": true, "GO": true,
+}
+
+// missingWhere indica si alguna sentencia del verbo (DELETE o UPDATE) no
+// tiene WHERE en su mismo nivel de paréntesis.
+func missingWhere(tokens []sqlToken, verb string) bool {
+	for i, token := range tokens {
+		if token.text != verb || !dmlVerb(tokens, i) {
+			continue
+		}
+		where := false
+		for _, next := range tokens[i+1:] {
+			if next.depth < token.depth || next.depth == token.depth && (next.text == ";" || statementStarts[next.text]) {
+				break
+			}
+			if next.depth == token.depth && next.text == "WHERE" {
+				where = true
+				break
+			}
+		}
+		if !where {
+			return true
+		}
+	}
+	return false
+}
+
+// dmlVerb indica si el UPDATE o DELETE en la posición i es una sentencia, y
+// no parte de FOR UPDATE, UPDATE STATISTICS o ON DELETE.
+func dmlVerb(tokens []sqlToken, i int) bool {
+	if i > 0 && (tokens[i-1].text == "FOR" || tokens[i-1].text == "ON") {
+		return false
+	}
+	for _, next := range tokens[i+1 : min(i+3, len(tokens))] {
+		if next.text == "STATISTICS" {
+			return false
+		}
+	}
+	return true
+}
+
+// DenyCrossDatabase bloquea el acceso a bases distintas de database: los
+// nombres calificados con otra base (otra..tabla, otra.dbo.tabla) y USE.
+// Todo nombre de tres partes se toma como base.propietario.objeto, por lo
+// que también bloquea propietario.tabla.columna.
+func DenyCrossDatabase(database string) Policy {
+	database = strings.ToUpper(database)
+	return func(sql string) error {
+		tokens := sqlTokens(sql)
+		for i, token := range tokens {
+			if !token.word() || token.text[0] == '@' || token.text[0] >= '0' && token.text[0] <= '9' {
+				continue
+			}
+			if token.text == "USE" && i+1 < len(tokens) && tokens[i+1].text != database {
+				return violation("access to another database is not allowed")
+			}
+			// base.propietario.objeto o base..objeto
+			if i > 0 && tokens[i-1].text == "." {
+				continue
+			}
+			if qualifiesDatabase(tokens[i+1:]) && token.text != database {
+				return violation("access to database " + strings.ToLower(token.text) + " is not allowed")
+			}
+		}
+		return nil
+	}
+}
+
+// qualifiesDatabase indica si los tokens que siguen a un nombre lo
+// convierten en la base de un nombre de tres partes.
+func qualifiesDatabase(rest []sqlToken) bool {
+	if len(rest) < 3 || rest[0].text != "." {
+		return false
+	}
+	if rest[1].text == "." {
+		return rest[2].word()
+	}
+	return len(rest) >= 4 && rest[1].word() && rest[2].text == "." && rest[3].word()
+}
@@ -4,59 +4,176 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 )
 
 func (s *Sybase) Raw(sql string) (*RawResponse, error) {
-	if !s.IsConnected() {
-		return nil, errors.New("database isn't connected")
+	return s.send(QueryRequest{
+		TransID:     -1,
+		FinishTrans: true,
+		SQL:         sql,
+	})
+}
+
+// RawMaxRows es como Raw, limitando el result set a maxRows filas en lugar
+// de a Config.MaxRows (negativo: sin límite).
+func (s *Sybase) RawMaxRows(sql string, maxRows int) (*RawResponse, error) {
+	return s.RawWith(sql, RawOptions{MaxRows: maxRows})
+}
+
+// RawOptions son las opciones de una consulta de RawWith.
+type RawOptions struct {
+	MaxRows int    // Filas por result set (0: Config.MaxRows; negativo: sin límite)
+	TraceID string // Identificador de correlación que repiten los logs del puente
+}
+
+// RawWith es como Raw, con las opciones de opts.
+func (s *Sybase) RawWith(sql string, opts RawOptions) (*RawResponse, error) {
+	return s.send(QueryRequest{
+		TransID:     -1,
+		FinishTrans: true,
+		SQL:         sql,
+		MaxRows:     opts.MaxRows,
+		TraceID:     opts.TraceID,
+	})
+}
+
+// writeRequest escribe la petición en el stdin del puente, como una línea
+// JSON.
+func (s *Sybase) writeRequest(req QueryRequest) error {
+	if s.config.LowAllocation {
+		return s.writePooled(req)
+	}
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("error marshaling query: %w", err)
+	}
+
+	if s.charset != nil {
+		reqBytes = s.charset.encode(reqBytes)
+	}
+
+	// aplica la query directamente
+	if _, err := fmt.Fprintf(s.stdin, "%s\n", reqBytes); err != nil {
+		return fmt.Errorf("failed to send query: %w", err)
+	}
+	return nil
+}
+
+// send envía la petición al puente asignándole un msgId y espera su respuesta.
+func (s *Sybase) send(req QueryRequest) (*RawResponse, error) {
+	if !req.control() {
+		if err := s.checkReadOnly(req.SQL); err != nil {
+			return nil, err
+		}
+		if err := s.checkPolicies(req.SQL); err != nil {
+			return nil, err
+		}
+		req.MaxRows = s.rowLimit(req)
+	}
+	// las filas de consultas y páginas, si el puente lo admite
+	req.Columnar = !req.control() || req.FetchCursor != 0
+	if err := s.negotiate(&req); err != nil {
+		return nil, err
+	}
+
+	var stack string
+	if !req.control() && !req.background {
+		stack = s.callerStack()
 	}
 
 	s.mu.Lock()
+	if req.background && s.idle {
+		// un ping no debe relanzar un puente detenido por inactividad
+		s.mu.Unlock()
+		return nil, errors.New("the bridge is stopped for inactivity")
+	}
+	if err := s.resume(); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	if !req.background {
+		s.touch()
+	}
 	s.queryCount++
 	msgID := s.queryCount
 
 	respChan := make(chan QueryResponse, 1)
 	s.currentQueries[msgID] = respChan
+	query := ActiveQuery{
+		MsgID:     msgID,
+		SQL:       req.SQL,
+		StartedAt: time.Now(),
+		TransID:   max(req.TransID, 0),
+		TraceID:   req.TraceID,
+	}
+	query.Stack = stack
+	// las peticiones de control (cancelar, kill...) no son consultas
+	if !req.control() {
+		s.activeQueries[msgID] = query
+	}
 	s.mu.Unlock()
 
 	defer func() {
 		s.mu.Lock()
 		delete(s.currentQueries, msgID)
+		delete(s.activeQueries, msgID)
 		s.mu.Unlock()
 	}()
 
-	req := QueryRequest{
-		MsgID:       msgID,
-		TransID:     -1,
-		FinishTrans: true,
-		SQL:         sql,
+	req.MsgID = msgID
+	if err := s.writeRequest(req); err != nil {
+		return nil, err
 	}
 
-	reqBytes, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling query: %w", err)
+	if !req.control() {
+		s.logger().Debug("request sent to the bridge", "msgId", msgID, "transId", max(req.TransID, 0), "sql", req.SQL)
 	}
 
-	// aplica la query directamente
-	if _, err := fmt.Fprintf(s.stdin, "%s\n", reqBytes); err != nil {
-		return nil, fmt.Errorf("failed to send query: %w", err)
+	resp, ok := <-respChan
+	if !ok {
+		// Disconnect o Reconnect terminaron el puente
+		return nil, errors.New("the bridge was stopped before responding")
 	}
-
-	if s.logs {
-		fmt.Println("Full JSON being sent: ")
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	if threshold := s.config.SlowQueryThreshold; threshold > 0 && !req.control() && !req.background {
+		if elapsed := time.Since(query.StartedAt); elapsed >= threshold {
+			s.emit(Event{Type: EventSlowQuery, Query: query, Duration: elapsed})
+		}
 	}
 
-	resp := <-respChan
-
-	if len(resp.Result) == 0 && resp.Error != "" {
+	if len(resp.Result.sets) == 0 && resp.Error != "" {
 		return nil, errors.New(resp.Error)
 	}
 
-	response, err := convertToRawResponse(resp.Result)
+	response, err := resp.Result.response()
 
 	if err != nil {
 		return nil, err
 	}
+	response.Messages = resp.Messages
+	response.ConnectionID = resp.ConnectionID
+	response.Truncated = resp.Truncated
+	response.CursorID = resp.CursorID
+	response.HasMore = resp.HasMore
+	response.truncate(req.clientLimit)
 
 	return response, nil
 }
+
+// ActiveQueries devuelve las consultas enviadas al puente que aún no han
+// respondido, ordenadas por MsgID.
+func (s *Sybase) ActiveQueries() []ActiveQuery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queries := make([]ActiveQuery, 0, len(s.activeQueries))
+	for _, query := range s.activeQueries {
+		queries = append(queries, query)
+	}
+	slices.SortFunc(queries, func(a, b ActiveQuery) int { return a.MsgID - b.MsgID })
+	return queries
+}
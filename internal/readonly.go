@@ -0,0 +1,82 @@
+package sybase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrReadOnly indica que Config.ReadOnly rechazó una sentencia de escritura
+// antes de enviarla al puente.
+var ErrReadOnly = errors.New("write statement rejected in read-only mode")
+
+// writeKeywords son las palabras clave que modifican datos, esquema o el
+// servidor. INTO cubre SELECT ... INTO, salvo FETCH ... INTO @variable.
+var writeKeywords = map[string]bool{
+	"INSERT": true, "UPDATE": true, "DELETE": true, "MERGE": true, "TRUNCATE": true,
+	"EXEC": true, "EXECUTE": true, "CREATE": true, "ALTER": true, "DROP": true,
+	"GRANT": true, "REVOKE": true, "WRITETEXT": true, "DUMP": true, "LOAD": true,
+	"DBCC": true, "KILL": true, "RECONFIGURE": true, "SETUSER": true, "SHUTDOWN": true,
+	"INTO": true,
+}
+
+// readStarts son las palabras con las que puede empezar un lote de solo
+// lectura. Un lote que empieza con otra palabra es una llamada implícita a
+// un procedimiento (p. ej. "sp_who"), que puede escribir.
+var readStarts = map[string]bool{
+	"SELECT": true, "WITH": true, "SET": true, "DECLARE": true, "PRINT": true,
+	"IF": true, "ELSE": true, "BEGIN": true, "END": true, "WHILE": true,
+	"RETURN": true, "USE": true, "READTEXT": true, "WAITFOR": true, "BREAK": true,
+	"CONTINUE": true, "RAISERROR": true, "OPEN": true, "FETCH": true, "CLOSE": true,
+	"DEALLOCATE": true, "COMMIT": true, "ROLLBACK": true, "SAVE": true,
+}
+
+// writeKeyword devuelve la palabra por la que la sentencia se considera de
+// escritura (INSERT, UPDATE, DELETE, EXEC, DDL...), o cadena vacía si solo
+// lee.
+func writeKeyword(sql string) string {
+	words := sqlWords(sqlTokens(sql))
+	if len(words) == 0 {
+		return ""
+	}
+	if !readStarts[words[0]] && !writeKeywords[words[0]] {
+		return "EXEC"
+	}
+	for i, word := range words {
+		if !writeKeywords[word] {
+			continue
+		}
+		// FETCH cursor INTO @variable solo lee
+		if word == "INTO" && i+1 < len(words) && strings.HasPrefix(words[i+1], "@") {
+			continue
+		}
+		return word
+	}
+	return ""
+}
+
+// rowLimit devuelve el límite de filas que se envía al puente con la
+// petición: el suyo o Config.MaxRows, salvo en las sentencias de escritura,
+// que SET ROWCOUNT también limitaría.
+func (s *Sybase) rowLimit(req QueryRequest) int {
+	limit := req.MaxRows
+	if limit == 0 {
+		limit = s.config.MaxRows
+	}
+	if limit <= 0 || writeKeyword(req.SQL) != "" {
+		return 0
+	}
+	return limit
+}
+
+// checkReadOnly rechaza las sentencias de escritura si Config.ReadOnly está
+// activo.
+func (s *Sybase) checkReadOnly(sql string) error {
+	if !s.config.ReadOnly {
+		return nil
+	}
+	if keyword := writeKeyword(sql); keyword != "" {
+		return fmt.Errorf("%w: %s", ErrReadOnly, keyword)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package sybase
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Variables de entorno con las que el puente recibe los secretos, en lugar
+// de argumentos visibles en la lista de procesos (ps).
+const (
+	passwordEnv           = "TDSLINK_PASSWORD"
+	trustStorePasswordEnv = "TDSLINK_TRUSTSTORE_PASSWORD"
+)
+
+const redacted = "******"
+
+// redactor oculta los secretos en los logs y errores.
+type redactor struct {
+	mu      sync.RWMutex
+	secrets []string
+}
+
+// set reemplaza los secretos a ocultar; se ignoran los vacíos.
+func (r *redactor) set(secrets ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secrets = r.secrets[:0]
+	for _, secret := range secrets {
+		if secret != "" {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+}
+
+// redact reemplaza los secretos de text.
+func (r *redactor) redact(text string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, secret := range r.secrets {
+		text = strings.ReplaceAll(text, secret, redacted)
+	}
+	return text
+}
+
+// secretEnv devuelve las variables de entorno con los secretos del puente.
+// Con TdsProperties la contraseña es la del fichero, salvo que la dé el
+// CredentialProvider. Un jar que no las lee recibe la contraseña como
+// argumento. Requiere s.mu.
+func (s *Sybase) secretEnv(jar bridgeJar) []string {
+	if !jar.secretEnv {
+		return nil
+	}
+	var env []string
+	if s.config.TdsProperties == "" || s.config.Credentials != nil {
+		env = append(env, passwordEnv+"="+s.password)
+	}
+	if tls := s.config.TLS; tls.Enabled && tls.TrustStorePassword != "" {
+		env = append(env, trustStorePasswordEnv+"="+tls.TrustStorePassword)
+	}
+	return env
+}
+
+// String formatea la configuración ocultando las contraseñas, para que no
+// aparezcan al imprimirla en logs.
+func (c Config) String() string {
+	type plain Config
+	p := plain(c)
+	if p.Password != "" {
+		p.Password = redacted
+	}
+	if p.TLS.TrustStorePassword != "" {
+		p.TLS.TrustStorePassword = redacted
+	}
+	return fmt.Sprintf("%+v", p)
+}
@@ -0,0 +1,49 @@
+package sybase
+
+import "sync"
+
+// maxPooledRows acota las filas de los result sets que vuelven al pool, para
+// no retener la memoria de un resultado excepcionalmente grande.
+const maxPooledRows = 4096
+
+// rowsPool guarda result sets vacíos para reutilizarlos. json.Unmarshal
+// reutiliza los mapas que quedan tras la longitud del slice, por lo que
+// también se ahorran los mapas de las filas.
+var rowsPool = sync.Pool{
+	New: func() any { return new([]map[string]any) },
+}
+
+// borrowRows devuelve un result set vacío del pool.
+func borrowRows() []map[string]any {
+	rows := rowsPool.Get().(*[]map[string]any)
+	return (*rows)[:0]
+}
+
+// releaseRows vacía las filas y devuelve el result set al pool.
+func releaseRows(rows []map[string]any) {
+	if cap(rows) == 0 || cap(rows) > maxPooledRows {
+		return
+	}
+	rows = rows[:cap(rows)]
+	for i, row := range rows {
+		if row == nil {
+			rows = rows[:i]
+			break
+		}
+		clear(row)
+	}
+	rows = rows[:0]
+	rowsPool.Put(&rows)
+}
+
+// Release devuelve la memoria de las filas al pool, para reutilizarla en
+// las siguientes consultas y reducir la presión sobre el GC en servicios
+// con muchas consultas por segundo. Tras llamarlo, ni la respuesta ni sus
+// filas deben usarse, aunque se hayan guardado en otro sitio.
+func (r *RawResponse) Release() {
+	for _, resultSet := range r.ResultSets {
+		releaseRows(resultSet)
+	}
+	r.Results = nil
+	r.ResultSets = nil
+}
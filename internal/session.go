@@ -0,0 +1,84 @@
+package sybase
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Session envía sus consultas a una conexión del pool del puente reservada
+// para ella, en auto-commit, de modo que conservan el estado de la sesión
+// (USE, SET, tablas temporales). La conexión se descarta al cerrarla.
+type Session struct {
+	Db     *Sybase
+	ID     int
+	Closed bool
+
+	generation   int // Proceso del puente que tiene la conexión de la sesión
+	connectionID int // Conexión física reservada por el puente
+
+	mu sync.Mutex // Las consultas de una sesión se envían de una en una
+}
+
+// NewSession abre una sesión. El puente reserva la conexión con la primera
+// consulta enviada.
+func (s *Sybase) NewSession() (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.resume(); err != nil {
+		return nil, err
+	}
+	s.touch()
+	// una sesión abierta, como una transacción, impide detener el puente
+	s.openTransactions++
+	s.sessionCount++
+	return &Session{Db: s, ID: s.sessionCount, generation: s.generation}, nil
+}
+
+// Raw ejecuta sql en la conexión de la sesión.
+func (ss *Session) Raw(sql string) (*RawResponse, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if ss.Closed {
+		return nil, errors.New("session is closed")
+	}
+	if !ss.Db.running(ss.generation) {
+		ss.close()
+		return nil, errors.New("session lost: the bridge was restarted")
+	}
+	response, err := ss.Db.send(QueryRequest{TransID: -1, FinishTrans: true, SessionID: ss.ID, SQL: sql})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPinned(&ss.connectionID, response.ConnectionID); err != nil {
+		return nil, fmt.Errorf("session lost: %w", err)
+	}
+	return response, nil
+}
+
+// Close cierra la sesión y descarta su conexión, con su estado.
+func (ss *Session) Close() error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if ss.Closed {
+		return nil
+	}
+	ss.close()
+	if !ss.Db.running(ss.generation) {
+		// la conexión murió con el puente que la tenía
+		return nil
+	}
+	_, err := ss.Db.send(QueryRequest{TransID: -1, FinishTrans: true, CloseSessionID: ss.ID})
+	return err
+}
+
+// close marca la sesión como cerrada. Requiere ss.mu.
+func (ss *Session) close() {
+	ss.Closed = true
+	ss.Db.mu.Lock()
+	ss.Db.openTransactions--
+	ss.Db.mu.Unlock()
+}
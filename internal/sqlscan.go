@@ -0,0 +1,119 @@
+package sybase
+
+import (
+	"strings"
+	"unicode"
+)
+
+// sqlToken es una palabra o un signo (punto, punto y coma, paréntesis) de
+// una sentencia, fuera de literales, identificadores entre comillas y
+// comentarios. Los comentarios dan el token "--" o "/*".
+type sqlToken struct {
+	text  string // Palabra en mayúsculas, o el signo
+	depth int    // Nivel de paréntesis en el que aparece
+}
+
+// word indica si el token es una palabra, y no un signo.
+func (t sqlToken) word() bool {
+	return isSQLWordChar(rune(t.text[0]))
+}
+
+// sqlTokens divide la sentencia en tokens. Las variables (@x) y tablas
+// temporales (#t) se devuelven con su prefijo; los literales y los
+// identificadores entre comillas o corchetes, como el token "?", para
+// conservar la posición de los nombres. Un literal sin cerrar da el token
+// de su comilla de apertura.
+func sqlTokens(sql string) []sqlToken {
+	var tokens []sqlToken
+	depth := 0
+	for i := 0; i < len(sql); {
+		switch c := sql[i]; {
+		case c == '\'' || c == '"':
+			end, closed := skipLiteral(sql, i, c)
+			tokens = append(tokens, literalToken(c, closed, depth))
+			i = end
+		case c == '[':
+			end := strings.IndexByte(sql[i:], ']')
+			tokens = append(tokens, literalToken(c, end >= 0, depth))
+			if end >= 0 {
+				i += end + 1
+			} else {
+				i = len(sql)
+			}
+		case strings.HasPrefix(sql[i:], "--"):
+			tokens = append(tokens, sqlToken{"--", depth})
+			if end := strings.IndexByte(sql[i:], '\n'); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(sql)
+			}
+		case strings.HasPrefix(sql[i:], "/*"):
+			tokens = append(tokens, sqlToken{"/*", depth})
+			if end := strings.Index(sql[i+2:], "*/"); end >= 0 {
+				i += end + 4
+			} else {
+				i = len(sql)
+			}
+		case isSQLWordChar(rune(c)):
+			start := i
+			for i < len(sql) && isSQLWordChar(rune(sql[i])) {
+				i++
+			}
+			tokens = append(tokens, sqlToken{strings.ToUpper(sql[start:i]), depth})
+		case c == '(':
+			tokens = append(tokens, sqlToken{"(", depth})
+			depth++
+			i++
+		case c == ')':
+			depth = max(depth-1, 0)
+			tokens = append(tokens, sqlToken{")", depth})
+			i++
+		case c == '.' || c == ';':
+			tokens = append(tokens, sqlToken{string(c), depth})
+			i++
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
+// sqlWords devuelve el texto de los tokens que son palabras.
+func sqlWords(tokens []sqlToken) []string {
+	var words []string
+	for _, token := range tokens {
+		if token.word() {
+			words = append(words, token.text)
+		}
+	}
+	return words
+}
+
+// literalToken devuelve el token de un literal o identificador entre
+// comillas: "?", o la comilla de apertura si no se cerró.
+func literalToken(quote byte, closed bool, depth int) sqlToken {
+	if !closed {
+		return sqlToken{string(quote), depth}
+	}
+	return sqlToken{"?", depth}
+}
+
+// skipLiteral devuelve la posición siguiente al literal que empieza en i,
+// delimitado por quote, que se escapa duplicándolo, e indica si se cerró.
+func skipLiteral(sql string, i int, quote byte) (int, bool) {
+	for i++; i < len(sql); i++ {
+		if sql[i] != quote {
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1, true
+	}
+	return len(sql), false
+}
+
+func isSQLWordChar(r rune) bool {
+	return r == '_' || r == '@' || r == '#' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,69 @@
+package sybase
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// responseStream lee del stdout del puente las respuestas JSON, sin límite
+// de tamaño y aunque ocupen varias líneas, intercaladas con las líneas de
+// log del puente.
+type responseStream struct {
+	source  *bufio.Reader
+	pending []byte // Bytes que un decoder anterior leyó sin consumirlos
+	decoder *json.Decoder
+}
+
+func newResponseStream(r io.Reader) *responseStream {
+	stream := &responseStream{source: bufio.NewReader(r)}
+	stream.decoder = json.NewDecoder(stream)
+	return stream
+}
+
+// Read entrega primero los bytes pendientes del decoder anterior.
+func (st *responseStream) Read(p []byte) (int, error) {
+	if len(st.pending) > 0 {
+		n := copy(p, st.pending)
+		st.pending = st.pending[n:]
+		return n, nil
+	}
+	return st.source.Read(p)
+}
+
+// next decodifica la siguiente respuesta en resp. Si lo siguiente no es
+// JSON (un log del puente), devuelve esa línea sin decodificar.
+func (st *responseStream) next(resp *QueryResponse) (line []byte, err error) {
+	err = st.decoder.Decode(resp)
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return nil, err
+	}
+
+	// el decoder no avanza tras un error de sintaxis, y no se recupera de
+	// él: la línea se lee desde sus bytes pendientes y se sigue con otro
+	buffered, _ := io.ReadAll(st.decoder.Buffered())
+	st.pending = bytes.TrimLeft(append(buffered, st.pending...), " \t\r\n")
+	line, err = st.readLine()
+	st.decoder = json.NewDecoder(st)
+	return line, err
+}
+
+// readLine lee hasta el siguiente salto de línea, sin incluirlo.
+func (st *responseStream) readLine() ([]byte, error) {
+	if i := bytes.IndexByte(st.pending, '\n'); i >= 0 {
+		line := st.pending[:i]
+		st.pending = st.pending[i+1:]
+		return bytes.TrimRight(line, "\r"), nil
+	}
+	line := st.pending
+	st.pending = nil
+	rest, err := st.source.ReadBytes('\n')
+	line = append(line, rest...)
+	if err != nil && len(line) == 0 {
+		return nil, err
+	}
+	return bytes.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,52 @@
+package sybase
+
+// fail termina el puente de la generación dada tras un error fatal, haciendo
+// fallar sus consultas en curso. El keepalive (Config.KeepalivePing) lo
+// relanza.
+func (s *Sybase) fail(generation int, err error) {
+	s.mu.Lock()
+	if !s.connected || s.generation != generation {
+		s.mu.Unlock()
+		return
+	}
+	s.stop()
+	lost := s.linkLost()
+	s.mu.Unlock()
+
+	if lost {
+		s.onDisconnect(err)
+	}
+}
+
+// linkLost marca el puente como perdido e indica si no lo estaba ya, para
+// avisar una sola vez por caída. Requiere s.mu.
+func (s *Sybase) linkLost() bool {
+	if s.linkDown {
+		return false
+	}
+	s.linkDown = true
+	return true
+}
+
+// linkRestored marca el puente como recuperado e indica si estaba perdido.
+// Requiere s.mu.
+func (s *Sybase) linkRestored() bool {
+	restored := s.linkDown
+	s.linkDown = false
+	return restored
+}
+
+// onDisconnect llama a Config.OnDisconnect. No debe tenerse s.mu.
+func (s *Sybase) onDisconnect(err error) {
+	s.emit(Event{Type: EventDisconnected, Err: err})
+	if s.config.OnDisconnect != nil {
+		s.config.OnDisconnect(err)
+	}
+}
+
+// onReconnect llama a Config.OnReconnect. No debe tenerse s.mu.
+func (s *Sybase) onReconnect() {
+	if s.config.OnReconnect != nil {
+		s.config.OnReconnect()
+	}
+}
@@ -4,12 +4,24 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"strconv"
 	"strings"
 )
 
 func NewConnectionInstance(config Config) (*Sybase, error) {
+	charset, err := lookupCharset(config.Charset)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := lookupGC(config.BridgeGC); err != nil {
+		return nil, err
+	}
+	if config.BridgeInitialHeapMB > 0 && config.BridgeHeapMB > 0 && config.BridgeInitialHeapMB > config.BridgeHeapMB {
+		return nil, fmt.Errorf("BridgeInitialHeapMB (%d) exceeds BridgeHeapMB (%d)", config.BridgeInitialHeapMB, config.BridgeHeapMB)
+	}
+
 	var tdsJarPath *string = &config.TdsLink
 
 	if config.TdsLink == "" {
@@ -32,31 +44,103 @@ func NewConnectionInstance(config Config) (*Sybase, error) {
 		connectionTimeout:      config.ConnectionTimeout,
 		idleTimeout:            config.IdleTimeout,
 		keepaliveTime:          config.KeepaliveTime,
-		maxLifetime:            config.KeepaliveTime,
+		maxLifetime:            config.MaxLifetime,
 		transactionConnections: config.TransactionConnections,
 		logs:                   config.Logs,
 		tdsJarPath:             *tdsJarPath,
+		charset:                charset,
 		config:                 config,
 		currentQueries:         make(map[int]chan QueryResponse),
+		activeQueries:          make(map[int]ActiveQuery),
+		connections:            make(map[int]ConnectionUse),
+		events:                 make(chan Event, eventBuffer),
 	}, nil
 }
 
 func (s *Sybase) Connect() error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if s.connected {
+		s.mu.Unlock()
 		return errors.New("already connected")
 	}
 
-	var cmd *exec.Cmd
+	if err := s.connect(); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	s.linkDown = false
+	s.startKeepalive()
+	s.startWatchdog()
+	s.startHeartbeat()
+	s.mu.Unlock()
+
+	if s.config.WarmUp {
+		if err := s.awaitWarmUp(); err != nil {
+			s.Disconnect()
+			return err
+		}
+	}
+	s.emit(Event{Type: EventConnected})
+	return nil
+}
+
+// connect lanza el puente, pidiendo antes las credenciales al
+// CredentialProvider si lo hay. Requiere s.mu.
+func (s *Sybase) connect() error {
+	if s.config.Credentials == nil {
+		return s.start()
+	}
+
+	if err := s.refreshCredentials(); err != nil {
+		return err
+	}
+	if err := s.start(); err == nil {
+		return nil
+	}
+	// las credenciales pueden haber rotado desde que se leyeron
+	if err := s.refreshCredentials(); err != nil {
+		return err
+	}
+	return s.start()
+}
+
+// command prepara el proceso del puente. Si el jar lee la contraseña de
+// TDSLINK_PASSWORD, no aparece en sus argumentos ni en la lista de procesos.
+func (s *Sybase) command() (*exec.Cmd, error) {
+	jar := inspectBridgeJar(s.tdsJarPath)
+	options, err := s.bridgeOptions(jar)
+	if err != nil {
+		return nil, err
+	}
+
+	// the bridge reads and writes its pipes with the
+	// charset of the server, and may need Kerberos settings
+	args := append(s.javaOptions(), "-jar", s.tdsJarPath)
 	if s.config.TdsProperties != "" && checkFileExistence(s.config.TdsProperties) {
 		// TdsProperties already have all the necessary configurations
-		cmd = exec.Command("java", "-jar", s.tdsJarPath, s.config.TdsProperties)
+		args = append(args, s.config.TdsProperties)
 	} else {
-		cmd = exec.Command("java", "-jar", s.tdsJarPath,
-			s.host, s.port, s.database, s.username, s.password, strconv.FormatBool(s.logs), strconv.Itoa(s.minConnections), strconv.Itoa(s.maxConnections), strconv.Itoa(s.connectionTimeout), strconv.Itoa(s.idleTimeout), strconv.Itoa(s.keepaliveTime), strconv.Itoa(s.maxLifetime), strconv.Itoa(s.transactionConnections))
+		password := s.password
+		if jar.secretEnv {
+			// the bridge reads it from the environment (see secretEnv)
+			password = ""
+		}
+		args = append(args,
+			s.host, s.port, s.database, s.username, password, strconv.FormatBool(s.logs), strconv.Itoa(s.minConnections), strconv.Itoa(s.maxConnections), strconv.Itoa(s.connectionTimeout), strconv.Itoa(s.idleTimeout), strconv.Itoa(s.keepaliveTime), strconv.Itoa(s.maxLifetime), strconv.Itoa(s.transactionConnections))
+	}
+
+	cmd := exec.Command("java", append(args, options...)...)
+	cmd.Env = append(os.Environ(), s.secretEnv(jar)...)
+	return cmd, nil
+}
+
+// start lanza el puente y espera a que conecte. Requiere s.mu.
+func (s *Sybase) start() error {
+	cmd, err := s.command()
+	if err != nil {
+		return err
 	}
+	s.redactor.set(s.password, s.config.TLS.TrustStorePassword)
 
 	// listen any input text that will come from the commandline
 	// Like StdInputReader class of TDSLink
@@ -89,11 +173,14 @@ func (s *Sybase) Connect() error {
 	if !s.logs {
 		scanner := bufio.NewScanner(stdout)
 		if !scanner.Scan() {
+			cmd.Process.Kill()
 			return errors.New("failed to read connection status")
 		}
 
 		if text := scanner.Text(); !strings.HasPrefix(text, "JAVALOG: Connection created") {
-			return fmt.Errorf("connection failed: %s", text)
+			// no bridge is left running after a failed attempt
+			cmd.Process.Kill()
+			return fmt.Errorf("connection failed: %s", s.redactor.redact(text))
 		}
 	}
 
@@ -102,54 +189,111 @@ func (s *Sybase) Connect() error {
 	s.stdout = stdout
 	s.stderr = stderr
 	s.connected = true
+	s.generation++
+	s.lastError = ""
 
-	go s.handleResponses()
-	go s.handleErrors()
+	go s.handleResponses(stdout, s.generation)
+	go s.handleErrors(stderr, s.generation)
 
 	return nil
 }
 
 func (s *Sybase) Disconnect() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the keepalive may be retrying to reconnect
+	s.stopKeepalive()
+	s.stopWatchdog()
+	s.stopHeartbeat()
+	s.stopIdleTimer()
+	if s.idle {
+		// the bridge was already stopped for inactivity
+		s.idle = false
+		return nil
+	}
 	if !s.connected {
 		return errors.New("Database isn't connected")
 	}
+	s.emit(Event{Type: EventDisconnected})
+	return s.stop()
+}
 
-	locked := s.mu.TryLock()
+// Reconnect reinicia el puente: termina el proceso actual, cuyas consultas
+// y transacciones en curso fallan, y lanza uno nuevo.
+func (s *Sybase) Reconnect() error {
+	s.mu.Lock()
+	err := s.restart()
+	restored := err == nil && s.linkRestored()
+	s.mu.Unlock()
 
-	if locked {
-		defer s.mu.Unlock()
+	if err == nil {
+		s.emit(Event{Type: EventBridgeRestarted})
 	}
+	if restored {
+		s.onReconnect()
+	}
+	return err
+}
+
+// ErrBridgeRestarted indica que la consulta falló porque el puente se
+// reinició (Reconnect, el keepalive o el watchdog) antes de responderla.
+var ErrBridgeRestarted = errors.New("the bridge was restarted before responding")
+
+// restart reemplaza el proceso del puente. Las consultas en curso fallan con
+// ErrBridgeRestarted. Requiere s.mu.
+func (s *Sybase) restart() error {
+	if s.connected {
+		// el proceso anterior puede haber muerto ya
+		s.stopWith(ErrBridgeRestarted)
+	}
+	if err := s.connect(); err != nil {
+		return err
+	}
+	s.idle = false
+	return nil
+}
 
+// stop termina el proceso del puente y hace fallar las consultas en curso.
+// Requiere s.mu.
+func (s *Sybase) stop() error {
+	return s.stopWith(nil)
+}
+
+// stopWith es stop, haciendo fallar las consultas en curso con cause si no
+// es nil. Requiere s.mu.
+func (s *Sybase) stopWith(cause error) error {
 	s.connected = false
 
+	var errs []error
 	if s.stdin != nil {
-		err := s.stdin.Close()
-		if err != nil {
-			return err
-		}
+		errs = append(errs, s.stdin.Close())
 	}
 	if s.stdout != nil {
-		err := s.stdout.Close()
-		if err != nil {
-			return err
-		}
+		errs = append(errs, s.stdout.Close())
 	}
 	if s.stderr != nil {
-		err := s.stderr.Close()
-		if err != nil {
-			return err
-		}
+		errs = append(errs, s.stderr.Close())
 	}
 	if s.cmd != nil && s.cmd.Process != nil {
-		err := s.cmd.Process.Kill()
-		if err != nil {
-			return err
+		if err := s.cmd.Process.Kill(); !errors.Is(err, os.ErrProcessDone) {
+			errs = append(errs, err)
 		}
 	}
 
 	for _, ch := range s.currentQueries {
+		if cause != nil {
+			// si el puente ya respondió, la consulta recibe su respuesta
+			select {
+			case ch <- QueryResponse{err: cause}:
+			default:
+			}
+		}
 		close(ch)
 	}
 	s.currentQueries = make(map[int]chan QueryResponse)
-	return nil
+	s.activeQueries = make(map[int]ActiveQuery)
+	// las conexiones del puente terminado ya no existen
+	s.connections = make(map[int]ConnectionUse)
+	return errors.Join(errs...)
 }
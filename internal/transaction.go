@@ -1,9 +1,121 @@
 package sybase
 
-import ()
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
 
+// ErrConnectionChanged indica que el puente ejecutó una consulta de una
+// transacción o sesión en una conexión distinta de la suya.
+var ErrConnectionChanged = errors.New("the bridge connection changed")
+
+// Transaction agrupa consultas que el puente ejecuta en una misma conexión
+// del pool de transacciones, sin auto-commit. La conexión se libera (y se
+// confirman los cambios pendientes) al enviar una consulta con finishTrans.
 type Transaction struct {
 	Db        *Sybase
 	TxID      int
 	Finalized bool
+
+	generation   int // Proceso del puente que tiene la conexión de la transacción
+	connectionID int // Conexión física que ejecutó la primera consulta
+
+	mu sync.Mutex // Las consultas de una transacción se envían de una en una
+}
+
+// Begin inicia una transacción. El puente reserva la conexión con la
+// primera consulta enviada.
+func (s *Sybase) Begin() (*Transaction, error) {
+	s.mu.Lock()
+	// la transacción pertenece al proceso actual del puente, que debe existir
+	if err := s.resume(); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.touch()
+	s.openTransactions++
+	// los ids empiezan en 1: el puente interpreta -1 (o la ausencia
+	// de transId) como una consulta fuera de transacción
+	s.transactionCount++
+	txID := s.transactionCount
+	generation := s.generation
+	s.mu.Unlock()
+
+	return &Transaction{Db: s, TxID: txID, generation: generation}, nil
+}
+
+// Raw ejecuta sql dentro de la transacción.
+func (t *Transaction) Raw(sql string) (*RawResponse, error) {
+	return t.send(sql, false)
+}
+
+// Commit confirma la transacción y libera su conexión.
+func (t *Transaction) Commit() error {
+	_, err := t.send("COMMIT TRANSACTION", true)
+	return err
+}
+
+// Rollback deshace la transacción y libera su conexión.
+func (t *Transaction) Rollback() error {
+	_, err := t.send("ROLLBACK TRANSACTION", true)
+	return err
+}
+
+func (t *Transaction) send(sql string, finish bool) (*RawResponse, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.Finalized {
+		return nil, errors.New("transaction has already been committed or rolled back")
+	}
+	if !t.Db.running(t.generation) {
+		// su conexión murió con el puente que la tenía
+		t.finalize()
+		return nil, errors.New("transaction lost: the bridge was restarted")
+	}
+	if finish {
+		t.finalize()
+	}
+
+	response, err := t.Db.send(QueryRequest{
+		TransID:     t.TxID,
+		FinishTrans: finish,
+		SQL:         sql,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPinned(&t.connectionID, response.ConnectionID); err != nil {
+		if !t.Finalized {
+			t.finalize()
+		}
+		return nil, fmt.Errorf("transaction lost: %w", err)
+	}
+	return response, nil
+}
+
+// checkPinned comprueba que una consulta de una transacción o sesión se
+// ejecutó en la misma conexión física que las anteriores, de la que dependen
+// las tablas temporales y @@identity. pinned guarda la primera conexión.
+func checkPinned(pinned *int, connectionID int) error {
+	switch {
+	case connectionID == 0:
+		// puente sin soporte de connectionId
+		return nil
+	case *pinned == 0:
+		*pinned = connectionID
+		return nil
+	case *pinned != connectionID:
+		return fmt.Errorf("%w: statements ran on connections %d and %d", ErrConnectionChanged, *pinned, connectionID)
+	}
+	return nil
+}
+
+// finalize marca la transacción como terminada. Requiere t.mu.
+func (t *Transaction) finalize() {
+	t.Finalized = true
+	t.Db.mu.Lock()
+	t.Db.openTransactions--
+	t.Db.mu.Unlock()
 }
@@ -0,0 +1,24 @@
+package sybase
+
+import "fmt"
+
+// defaultWarmUpProbe es la sentencia de Config.WarmUpProbe por defecto.
+const defaultWarmUpProbe = "SELECT 1"
+
+// awaitWarmUp espera a que el puente termine el calentamiento de los pools
+// (Config.WarmUp): el puente no lee peticiones hasta terminarlo y termina
+// si falla, por lo que basta con esperar la respuesta a un ping.
+func (s *Sybase) awaitWarmUp() error {
+	_, err := s.send(QueryRequest{TransID: -1, FinishTrans: true, SQL: defaultWarmUpProbe})
+	if err == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	cause := s.lastError
+	s.mu.Unlock()
+	if cause != "" {
+		return fmt.Errorf("pool warm-up failed: %s", cause)
+	}
+	return fmt.Errorf("pool warm-up failed: %w", err)
+}
@@ -0,0 +1,125 @@
+package sybase
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// minWatchdogInterval acota la frecuencia con la que el watchdog revisa
+// las consultas en curso.
+const minWatchdogInterval = 10 * time.Millisecond
+
+// startWatchdog lanza el watchdog si Config.WatchdogThreshold está
+// definido. Requiere s.mu.
+func (s *Sybase) startWatchdog() {
+	if s.config.WatchdogThreshold <= 0 || s.watchdogStop != nil {
+		return
+	}
+	s.watchdogStop = make(chan struct{})
+	go s.watchdog(max(s.config.WatchdogThreshold/4, minWatchdogInterval), s.watchdogStop)
+}
+
+// stopWatchdog detiene el watchdog. Requiere s.mu.
+func (s *Sybase) stopWatchdog() {
+	if s.watchdogStop != nil {
+		close(s.watchdogStop)
+		s.watchdogStop = nil
+	}
+}
+
+// watchdog revisa cada interval las consultas en curso y señala una vez
+// cada una que supera Config.WatchdogThreshold: la registra en el log,
+// emite EventLongRunningQuery y, con Config.WatchdogCancel, la cancela
+// (ver watchdogCancel).
+func (s *Sybase) watchdog(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		for _, query := range s.overdueQueries() {
+			elapsed := time.Since(query.StartedAt)
+			s.logger().Warn("query exceeded the watchdog threshold",
+				"msgId", query.MsgID, "transId", query.TransID, "traceId", query.TraceID, "sql", query.SQL,
+				"duration", elapsed, "stack", query.Stack)
+			s.emit(Event{Type: EventLongRunningQuery, Query: query, Duration: elapsed})
+
+			if s.config.WatchdogCancel {
+				go s.watchdogCancel(query)
+			}
+		}
+	}
+}
+
+// overdueQueries devuelve las consultas que superaron el umbral y aún no se
+// habían señalado, marcándolas.
+func (s *Sybase) overdueQueries() []ActiveQuery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var overdue []ActiveQuery
+	for msgID, query := range s.activeQueries {
+		if query.flagged || time.Since(query.StartedAt) < s.config.WatchdogThreshold {
+			continue
+		}
+		query.flagged = true
+		s.activeQueries[msgID] = query
+		overdue = append(overdue, query)
+	}
+	return overdue
+}
+
+// watchdogCancel cancela una consulta señalada por el watchdog y, si sigue
+// en curso tras Config.WatchdogGrace, reinicia el puente.
+func (s *Sybase) watchdogCancel(query ActiveQuery) {
+	// con el puente bloqueado, la cancelación no responde
+	go func() {
+		if err := s.Cancel(query.MsgID); err != nil {
+			s.logger().Error("watchdog failed to cancel the query", "msgId", query.MsgID, "error", err)
+		}
+	}()
+
+	if s.config.WatchdogGrace <= 0 {
+		return
+	}
+	time.Sleep(s.config.WatchdogGrace)
+	s.restartHung(query)
+}
+
+// restartHung reinicia el puente si la consulta sigue en curso. Las
+// consultas pendientes fallan con ErrBridgeRestarted.
+func (s *Sybase) restartHung(query ActiveQuery) {
+	s.mu.Lock()
+	// los msgId no se reutilizan, ni siquiera entre procesos del puente
+	if _, active := s.activeQueries[query.MsgID]; !active || !s.connected {
+		s.mu.Unlock()
+		return
+	}
+	err := s.restart()
+	lost := err != nil && s.linkLost()
+	s.mu.Unlock()
+
+	if err != nil {
+		s.logger().Error("watchdog failed to restart the hung bridge", "msgId", query.MsgID, "error", err)
+		if lost {
+			s.onDisconnect(err)
+		}
+		return
+	}
+	s.logger().Warn("watchdog restarted the bridge after a hung query", "msgId", query.MsgID, "sql", query.SQL)
+	s.emit(Event{Type: EventBridgeRestarted})
+}
+
+// callerStack devuelve la pila de llamadas que envía una consulta, para que
+// el watchdog indique su origen. Solo se captura si el watchdog está activo.
+func (s *Sybase) callerStack() string {
+	if s.config.WatchdogThreshold <= 0 {
+		return ""
+	}
+	return string(debug.Stack())
+}
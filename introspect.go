@@ -0,0 +1,117 @@
+package gosybase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+// maxIndexKeys is the number of key columns read from the ASE primary key
+// index (see primaryKey).
+const maxIndexKeys = 16
+
+// TableColumn describes a column of a table, as read from the catalog by
+// DescribeTable.
+type TableColumn struct {
+	Name       string `db:"name"`
+	Type       string `db:"type_name"` // Server type (e.g. "varchar", or the user-defined type)
+	Length     int    `db:"length"`    // Storage length in bytes
+	Precision  int    `db:"prec"`      // Precision of numeric and decimal columns
+	Scale      int    `db:"scale"`     // Scale of numeric and decimal columns
+	Nullable   bool   `db:"nullable"`
+	Identity   bool   `db:"is_identity"`
+	PrimaryKey bool   `db:"primary_key"`
+}
+
+// Tables returns the user tables of the current database, by name.
+func (ds *Database) Tables() ([]string, error) {
+	query := "SELECT name FROM sysobjects WHERE type = 'U' ORDER BY name"
+	if ds.dialect != builder.DialectASE {
+		query = "SELECT table_name AS name FROM SYS.SYSTABLE WHERE table_type = 'BASE'" +
+			" AND creator = user_id() ORDER BY table_name"
+	}
+	return Pluck[string](ds, query)
+}
+
+// DescribeTable returns the columns of table in definition order, or an
+// error if the table doesn't exist.
+//
+//	columns, err := db.DescribeTable("users")
+func (ds *Database) DescribeTable(table string) ([]TableColumn, error) {
+	if ds.dialect != builder.DialectASE {
+		return ds.describeAnywhereTable(table)
+	}
+
+	// syscolumns.status: 8 allows nulls, 128 is the identity column
+	columns, err := Pluck[TableColumn](ds, "SELECT c.name, t.name AS type_name, c.length,"+
+		" isnull(c.prec, 0) AS prec, isnull(c.scale, 0) AS scale,"+
+		" c.status & 8 AS nullable, c.status & 128 AS is_identity, 0 AS primary_key"+
+		" FROM syscolumns c JOIN systypes t ON t.usertype = c.usertype"+
+		" WHERE c.id = object_id(?) ORDER BY c.colid", table)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s not found", table)
+	}
+
+	keys, err := ds.primaryKey(table)
+	if err != nil {
+		return nil, err
+	}
+	for i := range columns {
+		columns[i].PrimaryKey = keys[columns[i].Name]
+	}
+	return columns, nil
+}
+
+// primaryKey returns the columns of the ASE primary key of table: the keys
+// of its index created by a PRIMARY KEY constraint (sysindexes.status
+// 2048). index_col returns NULL past the last key.
+func (ds *Database) primaryKey(table string) (map[string]bool, error) {
+	var columns []string
+	for i := 1; i <= maxIndexKeys; i++ {
+		columns = append(columns, "index_col(object_name(id), indid, "+strconv.Itoa(i)+") AS k"+strconv.Itoa(i))
+	}
+	query, err := ds.bind("SELECT "+strings.Join(columns, ", ")+
+		" FROM sysindexes WHERE id = object_id(?) AND status & 2048 = 2048", table)
+	if err != nil {
+		return nil, err
+	}
+	response, err := ds.RawQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]bool{}
+	for _, row := range response.Results {
+		for _, key := range row {
+			if name, ok := key.(string); ok && name != "" {
+				keys[name] = true
+			}
+		}
+	}
+	return keys, nil
+}
+
+// describeAnywhereTable reads the columns of a SQL Anywhere or IQ table
+// from SYS.SYSCOLUMN.
+func (ds *Database) describeAnywhereTable(table string) ([]TableColumn, error) {
+	columns, err := Pluck[TableColumn](ds, "SELECT c.column_name AS name, d.domain_name AS type_name,"+
+		" c.width AS length, c.width AS prec, c.scale,"+
+		" CASE c.nulls WHEN 'Y' THEN 1 ELSE 0 END AS nullable,"+
+		" CASE c.\"default\" WHEN 'autoincrement' THEN 1 ELSE 0 END AS is_identity,"+
+		" CASE c.pkey WHEN 'Y' THEN 1 ELSE 0 END AS primary_key"+
+		" FROM SYS.SYSCOLUMN c JOIN SYS.SYSTABLE t ON t.table_id = c.table_id"+
+		" JOIN SYS.SYSDOMAIN d ON d.domain_id = c.domain_id"+
+		" WHERE t.table_name = ? ORDER BY c.column_id", table)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s not found", table)
+	}
+	return columns, nil
+}
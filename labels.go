@@ -0,0 +1,35 @@
+package gosybase
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Labels identify a Database (service, env, shard...) in the logs, metrics,
+// traces and events of the package, so the output of several databases can
+// be told apart.
+type Labels map[string]string
+
+// String formats the labels as sorted key=value pairs.
+func (l Labels) String() string {
+	pairs := make([]string, 0, len(l))
+	for _, key := range slices.Sorted(maps.Keys(l)) {
+		pairs = append(pairs, key+"="+l[key])
+	}
+	return strings.Join(pairs, " ")
+}
+
+// SetLabels replaces the labels of the database, which are added to its log
+// records as a "labels" group.
+//
+//	db.SetLabels(gosybase.Labels{"service": "checkout", "env": "prod", "shard": "eu-1"})
+func (ds *Database) SetLabels(labels Labels) {
+	ds.labels = maps.Clone(labels)
+	ds.propagateLogger()
+}
+
+// Labels returns a copy of the labels of the database.
+func (ds *Database) Labels() Labels {
+	return maps.Clone(ds.labels)
+}
@@ -6,10 +6,12 @@ import java.util.Properties;
 import database_manager.SybaseDatabase;
 import input_reader.StdInputReader;
 import java.io.IOException;
+import java.security.GeneralSecurityException;
 
 import requests.SQLRequest;
 import requests.SQLRequestListener;
 import utils.EncodedLogger;
+import utils.TLSConfigurator;
 
 /**
  * Main entry point for the SQL Bridge application that:
@@ -25,6 +27,17 @@ import utils.EncodedLogger;
  */
 public class Main implements SQLRequestListener {
   private static final int REQUIRED_ARGS = 13;
+  /**
+   * Prefix of the properties forwarded to the driver as connection properties
+   * (e.g. jdbc.APPLICATIONNAME=billing)
+   */
+  private static final String DRIVER_PROPERTY_PREFIX = "jdbc.";
+  /**
+   * Environment variables overriding the secrets of the arguments, which any
+   * user can read in the process list
+   */
+  private static final String PASSWORD_ENV = "TDSLINK_PASSWORD";
+  private static final String TRUSTSTORE_PASSWORD_ENV = "TDSLINK_TRUSTSTORE_PASSWORD";
   private final SybaseDatabase db;
   private final StdInputReader input;
 
@@ -34,10 +47,17 @@ public class Main implements SQLRequestListener {
    * @param args optional can be passed in the commandline arguments in order:
    *             host, port, dbname, username, password, log,
    *             minConnections, maxConnections, connectionTimeout,
-   *             idleTimeout, keepaliveTime, maxLifetime, transactionConnections
+   *             idleTimeout, keepaliveTime, maxLifetime, transactionConnections,
+   *             or the path of a properties file. Either can be followed by
+   *             key=value options overriding the properties, such as the
+   *             jdbc.* driver connection properties. The password and
+   *             tls.trustStorePassword are better passed in the
+   *             TDSLINK_PASSWORD and TDSLINK_TRUSTSTORE_PASSWORD environment
+   *             variables, which override them
    */
   public static void main(String[] args) {
     final Properties props = buildProperties(args);
+    loadEnvironment(props);
     launchApplication(props);
   }
 
@@ -47,12 +67,14 @@ public class Main implements SQLRequestListener {
    */
   private static Properties buildProperties(String[] args) {
     final Properties props = new Properties();
-    if (args.length == 1) {
+    // the second positional argument is the port, never an option
+    if (args.length == 1 || (args.length > 1 && isOption(args[1]))) {
       final String tdslinkPath = args[0];
       final File configFile = new File(tdslinkPath);
       if (configFile.exists()) {
         try {
           props.load(new FileReader(configFile));
+          loadOptions(props, args, 1);
           return props;
         } catch (IOException e) {
           EncodedLogger.logError("Error reading tdslink.properties");
@@ -65,7 +87,7 @@ public class Main implements SQLRequestListener {
       }
     }
 
-    if (args.length != REQUIRED_ARGS) {
+    if (args.length < REQUIRED_ARGS) {
       EncodedLogger.logError("JAVAERROR: required args => <host>, <port>, <dbname>, <username>, <password>, <log>, " +
           "<minConnections>, <maxConnections>, <connectionTimeout>, <idleTimeout>, " +
           "<keepaliveTime>, <maxLifetime>, <transactionConnections>\n" +
@@ -87,10 +109,60 @@ public class Main implements SQLRequestListener {
     props.setProperty("keepaliveTime", args[10]);
     props.setProperty("maxLifetime", args[11]);
     props.setProperty("transactionConnections", args[12]);
+    loadOptions(props, args, REQUIRED_ARGS);
     EncodedLogger.log = false;
     return props;
   }
 
+  /**
+   * Indicates if the argument is a key=value option.
+   */
+  private static boolean isOption(String arg) {
+    return arg.indexOf('=') > 0;
+  }
+
+  /**
+   * Loads the key=value options starting at the given argument.
+   */
+  private static void loadOptions(Properties props, String[] args, int start) {
+    for (int i = start; i < args.length; i++) {
+      if (!isOption(args[i])) {
+        EncodedLogger.logError("Invalid option => \"" + args[i] + "\" (expected key=value)");
+        System.exit(1);
+      }
+      final int separator = args[i].indexOf('=');
+      props.setProperty(args[i].substring(0, separator), args[i].substring(separator + 1));
+    }
+  }
+
+  /**
+   * Loads the secrets passed in environment variables.
+   */
+  private static void loadEnvironment(Properties props) {
+    final String password = System.getenv(PASSWORD_ENV);
+    if (password != null) {
+      props.setProperty("password", password);
+    }
+    final String trustStorePassword = System.getenv(TRUSTSTORE_PASSWORD_ENV);
+    if (trustStorePassword != null) {
+      props.setProperty("tls.trustStorePassword", trustStorePassword);
+    }
+  }
+
+  /**
+   * Extracts the driver connection properties (jdbc.* keys, without the
+   * prefix).
+   */
+  private static Properties driverProperties(Properties properties) {
+    final Properties driverProperties = new Properties();
+    for (String key : properties.stringPropertyNames()) {
+      if (key.startsWith(DRIVER_PROPERTY_PREFIX)) {
+        driverProperties.setProperty(key.substring(DRIVER_PROPERTY_PREFIX.length()), properties.getProperty(key));
+      }
+    }
+    return driverProperties;
+  }
+
   /**
    * Initializes and launches the application.
    */
@@ -126,11 +198,15 @@ public class Main implements SQLRequestListener {
     final Boolean log = Boolean.valueOf(properties.getProperty("log"));
     EncodedLogger.log = log;
 
-    validateDatabaseCredentials(username, password);
+    // Kerberos logins use the principal of the ticket
+    final boolean kerberos = Boolean.parseBoolean(properties.getProperty("jdbc.REQUEST_KERBEROS_SESSION"));
+    validateDatabaseCredentials(username, password, kerberos);
+    configureTLS(properties);
 
     this.db = initializeDatabase(host, port, dbname, username, password,
         minConnections, maxConnections, connectionTimeout, idleTimeout,
-        keepaliveTime, maxLifetime, transactionConnections);
+        keepaliveTime, maxLifetime, transactionConnections, driverProperties(properties),
+        properties.getProperty("warmup"));
 
     this.input = initializeInputReader();
     DateConstants.init();
@@ -141,24 +217,42 @@ public class Main implements SQLRequestListener {
   /**
    * Validates basic database credentials.
    */
-  private void validateDatabaseCredentials(String username, String password) {
+  private void validateDatabaseCredentials(String username, String password, boolean kerberos) {
+    if (kerberos) {
+      return;
+    }
     if (username == null || username.isEmpty() || password == null) {
       EncodedLogger.logError("Invalid database credentials");
       System.exit(1);
     }
   }
 
+  /**
+   * Configures the certificates trusted by SSL connections.
+   */
+  private void configureTLS(Properties properties) {
+    try {
+      TLSConfigurator.configure(properties);
+    } catch (IOException | GeneralSecurityException ex) {
+      EncodedLogger.logError("Invalid TLS configuration");
+      EncodedLogger.logException(ex);
+      System.exit(1);
+    }
+  }
+
   /**
    * Initializes the database connection pool.
    */
   private SybaseDatabase initializeDatabase(String host, int port, String dbname, String username,
       String password, int minConnections,
       int maxConnections, int connectionTimeout, int idleTimeout,
-      int keepaliveTime, int maxLifetime, int transactionConnections) {
+      int keepaliveTime, int maxLifetime, int transactionConnections, Properties driverProperties,
+      String warmUpProbe) {
     final SybaseDatabase database = new SybaseDatabase(
         host, port, dbname, username, password,
         minConnections, maxConnections, connectionTimeout,
-        idleTimeout, keepaliveTime, maxLifetime, transactionConnections);
+        idleTimeout, keepaliveTime, maxLifetime, transactionConnections, driverProperties);
+    database.setWarmUp(warmUpProbe);
 
     if (!database.connect()) {
       EncodedLogger.logError("Database isn't connected");
@@ -198,10 +292,15 @@ public class Main implements SQLRequestListener {
    */
   @Override
   public void sqlRequest(SQLRequest request) {
-    if (request == null || request.sql == null || request.sql.trim().isEmpty()) {
+    // control requests (cancel, kill) carry no SQL
+    if (request == null) {
       EncodedLogger.logError("Received invalid SQL request (It will be ignored)");
       return;
     }
+    if (!request.isControl() && (request.sql == null || request.sql.trim().isEmpty())) {
+      EncodedLogger.logRequestError(request.msgId, "Received empty SQL request (It will be ignored)");
+      return;
+    }
 
     EncodedLogger.log("Processing request msgId = " + request.msgId);
 
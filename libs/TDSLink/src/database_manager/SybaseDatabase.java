@@ -1,6 +1,7 @@
 package database_manager;
 
 import java.time.LocalDate;
+import java.util.Properties;
 import java.util.concurrent.Callable;
 import java.util.concurrent.ExecutorService;
 import java.util.concurrent.Executors;
@@ -9,11 +10,14 @@ import java.util.logging.LogManager;
 import java.util.logging.Logger;
 import java.util.logging.Handler;
 
+import executors.ExecControlCallable;
 import executors.ExecSQLCallable;
+import executors.ExecSQLSessionCallable;
 import executors.ExecSQLTransactionCallable;
 
 import java.sql.SQLException;
 import pool.ConnectionPool;
+import pool.ConnectionPoolSession;
 import pool.ConnectionPoolTransaction;
 import requests.SQLRequest;
 import utils.EncodedLogger;
@@ -53,13 +57,19 @@ public class SybaseDatabase {
   private final int keepaliveTime;
   private final int maxLifetime;
   private final int transactionConnections;
+  private final Properties driverProperties;
+  // statement run on each pooled connection on connect (null: no warm-up)
+  private String warmUpProbe;
 
   // Connection pools
   private ConnectionPool pool;
   private ConnectionPoolTransaction transactionPool;
+  private ConnectionPoolSession sessionPool;
 
   // Thread pool for asynchronous execution
   private final ExecutorService executor;
+  // Threads for control requests, which must not wait behind the queries
+  private final ExecutorService controlExecutor;
 
   /**
    * Constructs a new SybaseDatabase instance with the specified configuration.
@@ -76,10 +86,11 @@ public class SybaseDatabase {
    * @param keepaliveTime          Keepalive interval in milliseconds
    * @param maxLifetime            Maximum connection lifetime in milliseconds
    * @param transactionConnections Number of dedicated transaction connections
+   * @param driverProperties       Additional jConnect connection properties
    */
   public SybaseDatabase(String host, int port, String dbName, String username, String password,
       int minConnections, int maxConnections, int connectionTimeout, int idleTimeout,
-      int keepaliveTime, int maxLifetime, int transactionConnections) {
+      int keepaliveTime, int maxLifetime, int transactionConnections, Properties driverProperties) {
     this.host = host;
     this.port = port;
     this.dbName = dbName;
@@ -92,7 +103,20 @@ public class SybaseDatabase {
     this.keepaliveTime = keepaliveTime;
     this.maxLifetime = maxLifetime;
     this.transactionConnections = transactionConnections;
+    this.driverProperties = driverProperties;
     this.executor = Executors.newFixedThreadPool(NUMBER_OF_THREADS);
+    this.controlExecutor = Executors.newCachedThreadPool();
+  }
+
+  /**
+   * Makes connect open minConnections connections and run the probe on each
+   * of them before returning, so broken credentials or servers fail on
+   * startup rather than on the first requests.
+   *
+   * @param probe The statement to run, such as "SELECT 1"
+   */
+  public void setWarmUp(String probe) {
+    this.warmUpProbe = probe;
   }
 
   /**
@@ -114,15 +138,23 @@ public class SybaseDatabase {
           this.host, this.port, this.dbName, this.username, this.password,
           this.minConnections, this.maxConnections,
           this.connectionTimeout, this.idleTimeout,
-          this.keepaliveTime, this.maxLifetime);
+          this.keepaliveTime, this.maxLifetime, this.driverProperties);
 
       // Initialize transactional connection pool
       this.transactionPool = ConnectionPoolTransaction.create(
           this.host, this.port, this.dbName,
-          this.username, this.password, this.transactionConnections);
+          this.username, this.password, this.transactionConnections,
+          this.maxLifetime, this.driverProperties);
+
+      // Connections of the regular pool reserved by sessions
+      this.sessionPool = new ConnectionPoolSession(this.pool);
 
       // Register shutdown hook for proper resource cleanup
       registerShutdownHook();
+
+      if (this.warmUpProbe != null) {
+        warmUp();
+      }
       return true;
 
     } catch (ClassNotFoundException | SQLException ex) {
@@ -132,12 +164,29 @@ public class SybaseDatabase {
     }
   }
 
+  /**
+   * Fills and validates both pools.
+   */
+  private void warmUp() throws SQLException {
+    try {
+      this.pool.warmUp(this.minConnections, this.warmUpProbe);
+      this.transactionPool.warmUp(this.warmUpProbe);
+    } catch (SQLException ex) {
+      EncodedLogger.logFatal("Pool warm-up failed: " + ex.getMessage());
+      throw ex;
+    }
+    EncodedLogger.log("Pool warm-up completed with " + this.minConnections + " connections");
+  }
+
   /**
    * Registers a shutdown hook to clean up resources when the JVM exits.
    */
   private void registerShutdownHook() {
     Runtime.getRuntime().addShutdownHook(new Thread(() -> {
       try {
+        if (sessionPool != null) {
+          sessionPool.shutdown();
+        }
         if (pool != null) {
           pool.shutdown();
         }
@@ -145,6 +194,7 @@ public class SybaseDatabase {
           transactionPool.shutdown();
         }
         executor.shutdown();
+        controlExecutor.shutdown();
       } catch (Exception ex) {
         EncodedLogger.logError("Error during shutdown");
         EncodedLogger.logException(ex);
@@ -160,12 +210,16 @@ public class SybaseDatabase {
    */
   public void execSQL(SQLRequest request) {
     if (pool == null || transactionPool == null) {
-      EncodedLogger.logException(new IllegalStateException("Database connection not established. Call connect() first."));
+      EncodedLogger.logRequestError(request.msgId, "Database connection not established. Call connect() first.");
       return;
     }
 
     EncodedLogger.log("Executing request at " + LocalDate.now() + ". " + request);
 
+    if (request.isControl()) {
+      controlExecutor.submit(new ExecControlCallable(pool, transactionPool, sessionPool, request));
+      return;
+    }
     executor.submit(createCallable(request));
   }
 
@@ -173,6 +227,9 @@ public class SybaseDatabase {
    * Creates the appropriate callable based on request type.
    */
   private Callable<String> createCallable(SQLRequest request) {
+    if (request.sessionId > 0) {
+      return new ExecSQLSessionCallable(pool, sessionPool, request);
+    }
     return request.transId != -1 ? new ExecSQLTransactionCallable(transactionPool, request)
         : new ExecSQLCallable(pool, request);
   }
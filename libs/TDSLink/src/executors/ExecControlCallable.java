@@ -0,0 +1,187 @@
+package executors;
+
+import java.lang.management.GarbageCollectorMXBean;
+import java.lang.management.ManagementFactory;
+import java.lang.management.MemoryUsage;
+import java.sql.Connection;
+import java.sql.SQLException;
+import java.sql.Statement;
+import java.util.concurrent.Callable;
+
+import net.minidev.json.JSONArray;
+import net.minidev.json.JSONObject;
+import pool.ConnectionPool;
+import pool.ConnectionPoolSession;
+import pool.ConnectionPoolTransaction;
+import requests.SQLRequest;
+import utils.Capabilities;
+import utils.EncodedLogger;
+import utils.StatementRegistry;
+
+/**
+ * A Callable implementation for control requests, which act on other
+ * requests instead of running SQL: cancelling a running statement, killing
+ * a server process, switching the pools to new credentials, closing sessions,
+ * reporting the health of the bridge, negotiating its capabilities,
+ * answering heartbeats and reading the pages of paged cursors.
+ *
+ * <p>
+ * Control requests run outside the pools, so they are served even when every
+ * pooled connection is busy with the statements they are meant to stop.
+ * </p>
+ *
+ * @contributor CatHood0
+ */
+public class ExecControlCallable implements Callable<String> {
+
+  private final ConnectionPool pool;
+  private final ConnectionPoolTransaction transactionPool;
+  private final ConnectionPoolSession sessionPool;
+  private final SQLRequest sqlRequest;
+
+  /**
+   * Constructs a new ExecControlCallable instance.
+   *
+   * @param pool            The regular connection pool
+   * @param transactionPool The transactional pool, also used to open the
+   *                        connection issuing kill
+   * @param sessionPool     The connections reserved by the sessions
+   * @param sqlRequest      The control request
+   */
+  public ExecControlCallable(ConnectionPool pool, ConnectionPoolTransaction transactionPool,
+      ConnectionPoolSession sessionPool, SQLRequest sqlRequest) {
+    this.pool = pool;
+    this.transactionPool = transactionPool;
+    this.sessionPool = sessionPool;
+    this.sqlRequest = sqlRequest;
+  }
+
+  /**
+   * Executes the control request and prints its JSON response.
+   *
+   * @return JSON string with an empty result or the error
+   */
+  @Override
+  public String call() throws Exception {
+    final JSONObject response = new JSONObject();
+    response.put("messageId", sqlRequest.msgId);
+    response.put("result", new JSONArray());
+
+    try {
+      if (sqlRequest.heartbeat) {
+        // answering is enough to prove the bridge is alive
+      } else if (sqlRequest.fetchCursor > 0) {
+        fetch(response, sqlRequest.fetchCursor);
+      } else if (sqlRequest.closeCursor > 0) {
+        if (!PagedCursor.close(sqlRequest.closeCursor)) {
+          response.put("error", "Cursor " + sqlRequest.closeCursor + " is not open");
+        }
+      } else if (sqlRequest.capabilities != null) {
+        final JSONObject row = new JSONObject();
+        row.put("capabilities", String.join(",", Capabilities.negotiate(sqlRequest.capabilities)));
+        final JSONArray rows = new JSONArray();
+        rows.add(row);
+        final JSONArray resultSets = new JSONArray();
+        resultSets.add(rows);
+        response.put("result", resultSets);
+      } else if (sqlRequest.health) {
+        final JSONArray rows = new JSONArray();
+        rows.add(health());
+        final JSONArray resultSets = new JSONArray();
+        resultSets.add(rows);
+        response.put("result", resultSets);
+      } else if (sqlRequest.closeSessionId > 0) {
+        // a session that never ran a request has no connection
+        sessionPool.release(sqlRequest.closeSessionId);
+      } else if (sqlRequest.reauthenticate) {
+        // the transactional pool checks the credentials first
+        transactionPool.updateCredentials(sqlRequest.username, sqlRequest.password);
+        pool.updateCredentials(sqlRequest.username, sqlRequest.password);
+      } else if (sqlRequest.killSpid > 0) {
+        kill(sqlRequest.killSpid);
+      } else if (sqlRequest.cancelTransId > 0) {
+        if (!StatementRegistry.cancelTransaction(sqlRequest.cancelTransId)) {
+          response.put("error", "Transaction " + sqlRequest.cancelTransId + " is not running any statement");
+        }
+      } else if (!StatementRegistry.cancelMessage(sqlRequest.cancelMsgId)) {
+        response.put("error", "Request " + sqlRequest.cancelMsgId + " is not running");
+      }
+    } catch (SQLException ex) {
+      response.put("error", ex.getMessage());
+      EncodedLogger.logException(ex);
+    }
+
+    final String result = response.toJSONString();
+    System.out.println(result);
+    return result;
+  }
+
+  /**
+   * Reads the next page of a paged cursor, closing it after the last one or
+   * if it fails.
+   */
+  private void fetch(JSONObject response, int id) throws SQLException {
+    final PagedCursor cursor = PagedCursor.get(id);
+    if (cursor == null) {
+      response.put("error", "Cursor " + id + " is not open");
+      return;
+    }
+    final JSONArray resultSets = new JSONArray();
+    try {
+      resultSets.add(cursor.readPage(sqlRequest.pageSize));
+    } catch (SQLException ex) {
+      PagedCursor.close(id);
+      throw ex;
+    }
+    response.put("result", resultSets);
+    if (cursor.isExhausted()) {
+      PagedCursor.close(id);
+    } else {
+      response.put("cursorId", id);
+      response.put("hasMore", true);
+    }
+  }
+
+  /**
+   * Collects the JVM memory, threads and uptime and the state of both pools.
+   */
+  private JSONObject health() {
+    final JSONObject health = new JSONObject();
+    health.put("uptimeMs", ManagementFactory.getRuntimeMXBean().getUptime());
+
+    final MemoryUsage heap = ManagementFactory.getMemoryMXBean().getHeapMemoryUsage();
+    health.put("heapUsed", heap.getUsed());
+    health.put("heapCommitted", heap.getCommitted());
+    health.put("heapMax", heap.getMax());
+    health.put("nonHeapUsed", ManagementFactory.getMemoryMXBean().getNonHeapMemoryUsage().getUsed());
+    health.put("threads", ManagementFactory.getThreadMXBean().getThreadCount());
+
+    long gcCount = 0;
+    long gcTime = 0;
+    for (GarbageCollectorMXBean gc : ManagementFactory.getGarbageCollectorMXBeans()) {
+      // -1 when the collector does not report it
+      gcCount += Math.max(gc.getCollectionCount(), 0);
+      gcTime += Math.max(gc.getCollectionTime(), 0);
+    }
+    health.put("gcCount", gcCount);
+    health.put("gcTimeMs", gcTime);
+
+    health.put("poolActive", pool.getActiveConnections());
+    health.put("poolIdle", pool.getIdleConnections());
+    health.put("poolTotal", pool.getTotalConnections());
+    health.put("poolWaiting", pool.getThreadsAwaitingConnection());
+    health.put("transactionActive", transactionPool.getActiveConnectionCount());
+    health.put("transactionAvailable", transactionPool.getAvailableConnectionCount());
+    return health;
+  }
+
+  /**
+   * Kills the server process using a dedicated connection.
+   */
+  private void kill(int spid) throws SQLException {
+    try (Connection connection = transactionPool.openConnection();
+        Statement statement = connection.createStatement()) {
+      statement.execute("kill " + spid);
+    }
+  }
+}
@@ -4,7 +4,9 @@ import java.sql.Connection;
 import java.sql.Date;
 import java.sql.ResultSet;
 import java.sql.ResultSetMetaData;
+import java.sql.SQLWarning;
 import java.sql.Statement;
+import java.sql.Types;
 import java.time.LocalTime;
 import java.time.format.DateTimeFormatter;
 import java.util.concurrent.Callable;
@@ -15,7 +17,10 @@ import net.minidev.json.JSONArray;
 import net.minidev.json.JSONObject;
 import pool.ConnectionPool;
 import requests.SQLRequest;
+import utils.ConnectionTracker;
 import utils.EncodedLogger;
+import utils.HexEncoder;
+import utils.StatementRegistry;
 
 /**
  * A Callable implementation that executes SQL queries against a Sybase database
@@ -34,7 +39,7 @@ import utils.EncodedLogger;
 public class ExecSQLCallable implements Callable<String> {
 
   private final ConnectionPool connectionPool;
-  private final SQLRequest sqlRequest;
+  protected final SQLRequest sqlRequest;
 
   /**
    * Constructs a new ExecSQLCallable instance.
@@ -56,6 +61,20 @@ public class ExecSQLCallable implements Callable<String> {
    */
   @Override
   public String call() throws Exception {
+    EncodedLogger.setTraceId(sqlRequest.traceId);
+    try {
+      return respond();
+    } finally {
+      EncodedLogger.clearTraceId();
+    }
+  }
+
+  /**
+   * Runs the request and prints its response.
+   *
+   * @return JSON string containing query results or error information
+   */
+  private String respond() {
     String jsonResult = executeSqlQuery();
     EncodedLogger.log("Query executed successfully. Result size: " + jsonResult.length());
     // the unique way to send the response back to the client
@@ -81,107 +100,242 @@ public class ExecSQLCallable implements Callable<String> {
     response.put("messageId", sqlRequest.msgId);
     response.put("result", resultSetsArray);
 
+    JSONArray messages = new JSONArray();
+
     Statement statement = null;
     ResultSet resultSet = null;
     Connection connection = null;
+    boolean failed = false;
 
     try {
-      connection = connectionPool.getConnection();
+      connection = acquireConnection();
+      response.put("connectionId", ConnectionTracker.connectionId(connection));
       statement = connection.createStatement();
+      sqlRequest.limitRows(statement);
+      StatementRegistry.register(sqlRequest, statement);
       EncodedLogger.log("Obtained connection from pool");
       boolean hasResults = statement.execute(sqlRequest.sql);
       EncodedLogger.log("Query executed. Has results: " + hasResults);
+      collectMessages(statement, messages);
 
       while (hasResults || (statement.getUpdateCount() != -1)) {
         if (!hasResults) {
           hasResults = statement.getMoreResults();
+          collectMessages(statement, messages);
           continue;
         }
 
         resultSet = statement.getResultSet();
-        ResultSetMetaData metaData = resultSet.getMetaData();
 
-        // Get column names
-        final int columnCount = metaData.getColumnCount();
-        final String[] columnNames = new String[columnCount + 1];
-        for (int columnIndex = 1; columnIndex <= columnCount; columnIndex++) {
-          columnNames[columnIndex] = metaData.getColumnLabel(columnIndex);
+        if (sqlRequest.pageSize > 0) {
+          // the rest of the result set is read with fetchCursor requests
+          final Connection cursorConnection = connection;
+          final PagedCursor cursor = new PagedCursor(statement, resultSet, sqlRequest.columnar,
+              () -> releaseConnection(cursorConnection));
+          resultSetsArray.add(cursor.readPage(sqlRequest.pageSize));
+          if (!cursor.isExhausted()) {
+            cursor.register(sqlRequest.msgId);
+            response.put("cursorId", sqlRequest.msgId);
+            response.put("hasMore", true);
+            // the cursor owns them now
+            resultSet = null;
+            statement = null;
+            connection = null;
+          }
+          break;
         }
 
-        final JSONArray resultRows = new JSONArray();
-        resultSetsArray.add(resultRows);
+        final ResultRows resultRows = new ResultRows(resultSet, sqlRequest.columnar);
+        resultSetsArray.add(resultRows.toJSON());
 
         while (resultSet.next()) {
-          final JSONObject rowData = new JSONObject();
-          resultRows.add(rowData);
-
-          // Process each column in the row
-          for (int columnIndex = 1; columnIndex <= columnCount; columnIndex++) {
-            final String columnName = columnNames[columnIndex];
-            final Object columnValue = resultSet.getObject(columnIndex);
-
-            // We prefer not to ignore this.
-            //
-            // Since when a value is NULL/null,
-            // the query has likely been configured to
-            // optionally have null fields
-            if (columnValue == null) {
-              rowData.put(columnName, null);
-              continue;
-            }
-
-            int dataType = metaData.getColumnType(columnIndex);
-            switch (dataType) {
-              // since some old sybase database models 
-              // does not support using rs.getTimestamp(...).toInstant()
-              // we prefer just converting it manually, to avoid
-              // unexpected exception for no implementations
-              case DateConstants.TYPE_TIMESTAMP:
-              case DateConstants.TYPE_DATE:
-                final String date = DateConstants.DATE_FORMAT
-                    .format(new Date(resultSet.getTimestamp(columnIndex).getTime()));
-                rowData.put(columnName, date); // ISO "yyyy-MM-dd" format
-                break;
-              case DateConstants.TYPE_TIME:
-                LocalTime time = resultSet.getObject(columnIndex, LocalTime.class);
-                rowData.put(columnName, time.format(DateTimeFormatter.ISO_TIME));
-                break;
-              default:
-                rowData.put(columnName, columnValue);
-            }
+          if (sqlRequest.rowLimitReached(resultRows.size())) {
+            response.put("truncated", true);
+            break;
           }
+          resultRows.addCurrentRow();
         }
         resultSet.close();
         hasResults = statement.getMoreResults();
+        collectMessages(statement, messages);
+      }
+      if (statement != null) {
+        statement.close();
       }
-      EncodedLogger.log("Closing connection with id=" + sqlRequest.id());
-      statement.close();
-      connection.close();
     } catch (SQLException ex) {
+      failed = true;
       response.put("error", ex.getMessage());
       EncodedLogger.logError("Error executing query");
       EncodedLogger.logException(ex);
-
-      try {
-        if (connection != null) {
-          EncodedLogger.logError("Closing connection due to error");
-          connection.close();
-        }
-      } catch (SQLException closingEx) {
-        EncodedLogger.logError("Error closing connection with id=" + sqlRequest.id());
-        EncodedLogger.logException(closingEx);
-      }
     } finally {
+      StatementRegistry.unregister(sqlRequest);
       closeResource(resultSet, "result set");
       closeResource(statement, "statement");
+      if (failed) {
+        resetConnection(connection);
+      }
+      releaseConnection(connection);
     }
 
+    if (!messages.isEmpty()) {
+      response.put("messages", messages);
+    }
     response.put("javaStartTime", sqlRequest.javaStartTime);
     response.put("javaEndTime", System.currentTimeMillis());
     final String jsonResponse = response.toJSONString();
     return jsonResponse;
   }
 
+  /**
+   * Returns the labels of the columns, indexed from 1 like the driver.
+   *
+   * @param metaData The metadata of the result set
+   * @return The column labels
+   */
+  static String[] columnNames(ResultSetMetaData metaData) throws SQLException {
+    final int columnCount = metaData.getColumnCount();
+    final String[] columnNames = new String[columnCount + 1];
+    for (int columnIndex = 1; columnIndex <= columnCount; columnIndex++) {
+      columnNames[columnIndex] = metaData.getColumnLabel(columnIndex);
+    }
+    return columnNames;
+  }
+
+  /**
+   * Converts the current row of the result set to JSON.
+   *
+   * @param resultSet   The result set, positioned on the row
+   * @param metaData    The metadata of the result set
+   * @param columnNames The column labels (see columnNames)
+   * @return The row
+   */
+  static JSONObject readRow(ResultSet resultSet, ResultSetMetaData metaData, String[] columnNames)
+      throws SQLException {
+    final JSONObject rowData = new JSONObject();
+    final int columnCount = columnNames.length - 1;
+
+    // Process each column in the row
+    for (int columnIndex = 1; columnIndex <= columnCount; columnIndex++) {
+      // We prefer not to ignore this.
+      //
+      // Since when a value is NULL/null,
+      // the query has likely been configured to
+      // optionally have null fields
+      rowData.put(columnNames[columnIndex], readValue(resultSet, metaData, columnIndex));
+    }
+    return rowData;
+  }
+
+  /**
+   * Converts a column of the current row to its JSON value.
+   *
+   * @param resultSet   The result set, positioned on the row
+   * @param metaData    The metadata of the result set
+   * @param columnIndex The column, from 1
+   * @return The value, or null for NULL
+   */
+  static Object readValue(ResultSet resultSet, ResultSetMetaData metaData, int columnIndex)
+      throws SQLException {
+    final Object columnValue = resultSet.getObject(columnIndex);
+    if (columnValue == null) {
+      return null;
+    }
+
+    int dataType = metaData.getColumnType(columnIndex);
+    switch (dataType) {
+      // since some old sybase database models 
+      // does not support using rs.getTimestamp(...).toInstant()
+      // we prefer just converting it manually, to avoid
+      // unexpected exception for no implementations
+      case DateConstants.TYPE_TIMESTAMP:
+      case DateConstants.TYPE_DATE:
+        // ISO "yyyy-MM-dd" format
+        return DateConstants.DATE_FORMAT
+            .format(new Date(resultSet.getTimestamp(columnIndex).getTime()));
+      case DateConstants.TYPE_TIME:
+        LocalTime time = resultSet.getObject(columnIndex, LocalTime.class);
+        return time.format(DateTimeFormatter.ISO_TIME);
+      case Types.BINARY:
+      case Types.VARBINARY:
+      case Types.LONGVARBINARY:
+      case Types.BLOB:
+        return HexEncoder.encode(resultSet.getBytes(columnIndex));
+      default:
+        return columnValue;
+    }
+  }
+
+  /**
+   * Takes the connection running the request from the pool.
+   *
+   * @return The database connection
+   * @throws SQLException If no connection can be obtained
+   */
+  protected Connection acquireConnection() throws SQLException {
+    final Connection connection = connectionPool.getConnection();
+    ConnectionTracker.checkout(ConnectionTracker.REGULAR_POOL, connection, sqlRequest.msgId, -1);
+    return connection;
+  }
+
+  /**
+   * Returns the connection to the pool once the request finished, even if it
+   * failed.
+   *
+   * @param connection The connection, or null if none was obtained
+   */
+  protected void releaseConnection(Connection connection) {
+    if (connection == null) {
+      return;
+    }
+    ConnectionTracker.checkin(ConnectionTracker.REGULAR_POOL, connection, sqlRequest.msgId, -1);
+    try {
+      EncodedLogger.log("Closing connection with id=" + sqlRequest.id());
+      connection.close();
+    } catch (SQLException ex) {
+      EncodedLogger.logError("Error closing connection with id=" + sqlRequest.id());
+      EncodedLogger.logException(ex);
+    }
+  }
+
+  /**
+   * Resets the session options a failed request may have left on the
+   * connection before it returns to the pool. A batch aborted between SET
+   * ROWCOUNT n and SET ROWCOUNT 0, as the builders and ORMs limit rows on
+   * ASE, would otherwise limit every later request on the connection. A
+   * connection that cannot be reset is evicted from the pool.
+   *
+   * @param connection The connection, or null if none was obtained
+   */
+  protected void resetConnection(Connection connection) {
+    if (connection == null) {
+      return;
+    }
+    try (Statement reset = connection.createStatement()) {
+      reset.execute("SET ROWCOUNT 0");
+    } catch (SQLException ex) {
+      EncodedLogger.logError("Unable to reset connection after request id=" + sqlRequest.id() + ", evicting it");
+      EncodedLogger.logException(ex);
+      connectionPool.evictConnection(connection);
+    }
+  }
+
+  /**
+   * Moves the warnings of the statement into the messages array. Sybase
+   * sends informational server messages, such as the SHOWPLAN output, as
+   * warnings.
+   *
+   * @param statement The statement being executed
+   * @param messages  The array receiving the message texts
+   */
+  private void collectMessages(Statement statement, JSONArray messages) throws SQLException {
+    SQLWarning warning = statement.getWarnings();
+    while (warning != null) {
+      messages.add(warning.getMessage());
+      warning = warning.getNextWarning();
+    }
+    statement.clearWarnings();
+  }
+
   /**
    * Safely closes a database resource with error handling.
    * 
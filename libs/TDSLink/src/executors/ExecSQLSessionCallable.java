@@ -0,0 +1,48 @@
+package executors;
+
+import java.sql.Connection;
+import java.sql.SQLException;
+
+import pool.ConnectionPool;
+import pool.ConnectionPoolSession;
+import requests.SQLRequest;
+
+/**
+ * A Callable implementation that executes the requests of a session on the
+ * connection reserved for it, which is kept after the request (see
+ * ConnectionPoolSession).
+ *
+ * @contributor CatHood0
+ */
+public class ExecSQLSessionCallable extends ExecSQLCallable {
+
+  private final ConnectionPoolSession sessionPool;
+
+  /**
+   * Constructs a new ExecSQLSessionCallable instance.
+   *
+   * @param connectionPool The regular connection pool
+   * @param sessionPool    The connections reserved by the sessions
+   * @param sqlRequest     The SQL request, with its sessionId
+   */
+  public ExecSQLSessionCallable(ConnectionPool connectionPool, ConnectionPoolSession sessionPool,
+      SQLRequest sqlRequest) {
+    super(connectionPool, sqlRequest);
+    this.sessionPool = sessionPool;
+  }
+
+  @Override
+  protected Connection acquireConnection() throws SQLException {
+    return sessionPool.getConnection(sqlRequest.sessionId);
+  }
+
+  @Override
+  protected void resetConnection(Connection connection) {
+    // the session keeps its SET options (see Session.Set)
+  }
+
+  @Override
+  protected void releaseConnection(Connection connection) {
+    // the connection belongs to the session until it is closed
+  }
+}
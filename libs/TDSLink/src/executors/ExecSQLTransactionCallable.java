@@ -1,21 +1,18 @@
 package executors;
 
 import java.sql.Connection;
-import java.sql.Date;
 import java.sql.ResultSet;
-import java.sql.ResultSetMetaData;
 import java.sql.Statement;
-import java.time.LocalTime;
-import java.time.format.DateTimeFormatter;
 import java.util.concurrent.Callable;
 
-import constants.DateConstants;
 import java.sql.SQLException;
 import net.minidev.json.JSONArray;
 import net.minidev.json.JSONObject;
 import pool.ConnectionPoolTransaction;
 import requests.SQLRequest;
+import utils.ConnectionTracker;
 import utils.EncodedLogger;
+import utils.StatementRegistry;
 
 /**
  * A Callable implementation for executing SQL queries within transactions.
@@ -58,6 +55,20 @@ public class ExecSQLTransactionCallable implements Callable<String> {
    */
   @Override
   public String call() throws Exception {
+    EncodedLogger.setTraceId(sqlRequest.traceId);
+    try {
+      return respond();
+    } finally {
+      EncodedLogger.clearTraceId();
+    }
+  }
+
+  /**
+   * Runs the transaction request and prints its response.
+   *
+   * @return JSON string containing query results or error information
+   */
+  private String respond() {
     String result = executeSqlTransaction();
     EncodedLogger.log("Transaction executed. Result size: " + result.length());
     // the unique way to send the response back to the client
@@ -97,8 +108,12 @@ public class ExecSQLTransactionCallable implements Callable<String> {
         return response.toJSONString();
       }
       EncodedLogger.log("Transaction connection established");
+      response.put("connectionId", ConnectionTracker.connectionId(connection));
 
       statement = connection.createStatement();
+      sqlRequest.limitRows(statement);
+
+      StatementRegistry.register(sqlRequest, statement);
       boolean hasResults = statement.execute(sqlRequest.sql);
 
       while (hasResults || (statement.getUpdateCount() != -1)) {
@@ -108,59 +123,15 @@ public class ExecSQLTransactionCallable implements Callable<String> {
         }
 
         resultSet = statement.getResultSet();
-        final ResultSetMetaData metaData = resultSet.getMetaData();
-
-        // Process column names
-        final int columnCount = metaData.getColumnCount();
-        final String[] columnNames = new String[columnCount + 1];
-        for (int columnIndex = 1; columnIndex <= columnCount; columnIndex++) {
-          columnNames[columnIndex] = metaData.getColumnLabel(columnIndex);
-        }
-
-        final JSONArray resultRows = new JSONArray();
-        resultSets.add(resultRows);
+        final ResultRows resultRows = new ResultRows(resultSet, sqlRequest.columnar);
+        resultSets.add(resultRows.toJSON());
 
         while (resultSet.next()) {
-          final JSONObject rowData = new JSONObject();
-          resultRows.add(rowData);
-
-          // Process each column value
-          for (int columnIndex = 1; columnIndex <= columnCount; columnIndex++) {
-            final String columnName = columnNames[columnIndex];
-            final Object columnValue = resultSet.getObject(columnIndex);
-
-            // We prefer not to ignore this.
-            //
-            // Since when a value is NULL/null,
-            // the query has likely been configured to
-            // optionally have null fields
-            if (columnValue == null) {
-              rowData.put(columnName, null);
-              continue;
-            }
-
-            int dataType = metaData.getColumnType(columnIndex);
-            switch (dataType) {
-              case DateConstants.TYPE_TIMESTAMP:
-              case DateConstants.TYPE_DATE:
-                final String date = DateConstants.DATE_FORMAT
-                    .format(new Date(resultSet.getTimestamp(columnIndex).getTime()));
-                rowData.put(columnName, date); // ISO "yyyy-MM-dd" format
-                break;
-              case DateConstants.TYPE_TIME:
-                /**
-                 * 
-                 * String timeFromRS = rs.getTime(c).toString();
-                 * String my8601formattedTime = "1970-01-01T" + timeFromRS + ".000Z";
-                 * row.put(columns[c], my8601formattedTime);
-                 */
-                LocalTime time = resultSet.getObject(columnIndex, LocalTime.class);
-                rowData.put(columnName, time.format(DateTimeFormatter.ISO_TIME));
-                break;
-              default:
-                rowData.put(columnName, columnValue);
-            }
+          if (sqlRequest.rowLimitReached(resultRows.size())) {
+            response.put("truncated", true);
+            break;
           }
+          resultRows.addCurrentRow();
         }
         resultSet.close();
         hasResults = statement.getMoreResults();
@@ -169,6 +140,7 @@ public class ExecSQLTransactionCallable implements Callable<String> {
     } catch (SQLException ex) {
       handleTransactionError(response, connection, ex);
     } finally {
+      StatementRegistry.unregister(sqlRequest);
       cleanupResources(resultSet, statement, connection);
     }
 
@@ -199,6 +171,17 @@ public class ExecSQLTransactionCallable implements Callable<String> {
       EncodedLogger.logException(rollbackEx);
     }
 
+    // an aborted SET ROWCOUNT n ... SET ROWCOUNT 0 batch would limit the
+    // next statements of the transaction
+    if (connection != null) {
+      try (Statement reset = connection.createStatement()) {
+        reset.execute("SET ROWCOUNT 0");
+      } catch (SQLException resetEx) {
+        EncodedLogger.logError("Unable to reset the transaction connection");
+        EncodedLogger.logException(resetEx);
+      }
+    }
+
     EncodedLogger.logError("Transaction error caused by: ");
     EncodedLogger.logException(exception);
   }
@@ -0,0 +1,129 @@
+package executors;
+
+import java.sql.ResultSet;
+import java.sql.ResultSetMetaData;
+import java.sql.SQLException;
+import java.sql.Statement;
+import java.util.concurrent.ConcurrentHashMap;
+
+import utils.EncodedLogger;
+
+/**
+ * A result set kept open between requests, so the client reads it a page at
+ * a time (fetchCursor requests) and neither side holds more than a page of
+ * rows in memory.
+ *
+ * <p>
+ * The cursor keeps its statement and connection until the last page is read
+ * or the client closes it (closeCursor requests). Open cursors are
+ * identified by the message id of the request that opened them.
+ * </p>
+ *
+ * @contributor CatHood0
+ */
+public class PagedCursor {
+
+  private static final ConcurrentHashMap<Integer, PagedCursor> cursors = new ConcurrentHashMap<>();
+
+  private final Statement statement;
+  private final ResultSet resultSet;
+  private final ResultSetMetaData metaData;
+  private final String[] columnNames;
+  private final boolean columnar;
+  private final Runnable release;
+  // the result set is positioned on a row that was not read yet
+  private boolean pending;
+  private boolean exhausted;
+
+  /**
+   * Creates a cursor over the result set.
+   *
+   * @param statement The statement that produced the result set
+   * @param resultSet The result set, before its first row
+   * @param columnar  Whether the pages are sent in the columnar format
+   * @param release   Returns the connection of the statement to its pool
+   */
+  PagedCursor(Statement statement, ResultSet resultSet, boolean columnar, Runnable release)
+      throws SQLException {
+    this.statement = statement;
+    this.resultSet = resultSet;
+    this.metaData = resultSet.getMetaData();
+    this.columnNames = ExecSQLCallable.columnNames(metaData);
+    this.columnar = columnar;
+    this.release = release;
+  }
+
+  /**
+   * Reads the next page of rows.
+   *
+   * @param pageSize The maximum number of rows of the page
+   * @return The rows of the page, as a result set of the response
+   */
+  synchronized Object readPage(int pageSize) throws SQLException {
+    final ResultRows rows = new ResultRows(resultSet, metaData, columnNames, columnar);
+    while (rows.size() < pageSize) {
+      if (!pending && !resultSet.next()) {
+        exhausted = true;
+        return rows.toJSON();
+      }
+      pending = false;
+      rows.addCurrentRow();
+    }
+    // looking one row ahead tells whether another page follows
+    pending = resultSet.next();
+    exhausted = !pending;
+    return rows.toJSON();
+  }
+
+  /**
+   * Indicates if the last page was read.
+   */
+  synchronized boolean isExhausted() {
+    return exhausted;
+  }
+
+  /**
+   * Keeps the cursor open for the following pages.
+   *
+   * @param id The message id of the request that opened it
+   */
+  void register(int id) {
+    cursors.put(id, this);
+  }
+
+  /**
+   * Returns the open cursor with the given id, or null.
+   */
+  static PagedCursor get(int id) {
+    return cursors.get(id);
+  }
+
+  /**
+   * Closes the cursor with the given id, returning its connection.
+   *
+   * @return false if the cursor is not open
+   */
+  static boolean close(int id) {
+    final PagedCursor cursor = cursors.remove(id);
+    if (cursor == null) {
+      return false;
+    }
+    cursor.close();
+    return true;
+  }
+
+  /**
+   * Closes the result set and the statement and returns the connection.
+   */
+  void close() {
+    try {
+      resultSet.close();
+      statement.close();
+    } catch (SQLException ex) {
+      EncodedLogger.logError("Error closing a paged cursor");
+      EncodedLogger.logException(ex);
+    } finally {
+      release.run();
+    }
+  }
+}
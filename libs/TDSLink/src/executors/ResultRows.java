@@ -0,0 +1,104 @@
+package executors;
+
+import java.sql.ResultSet;
+import java.sql.ResultSetMetaData;
+import java.sql.SQLException;
+
+import net.minidev.json.JSONArray;
+import net.minidev.json.JSONObject;
+
+/**
+ * The rows of a result set as they are sent to the client.
+ *
+ * <p>
+ * Clients that negotiated the columnar format receive the column labels once,
+ * in the order of the query, with their JDBC type codes (java.sql.Types) and
+ * database type names, and each row as an array of values:
+ * {"columns": ["id", "name"], "types": [4, 12], "typeNames": ["int",
+ * "varchar"], "rows": [[1, "a"], [2, "b"]]}. Older clients receive an array
+ * with one object per row.
+ * </p>
+ *
+ * @contributor CatHood0
+ */
+class ResultRows {
+
+  private final ResultSet resultSet;
+  private final ResultSetMetaData metaData;
+  private final String[] columnNames;
+  private final boolean columnar;
+  private final JSONArray rows = new JSONArray();
+  private final Object json;
+
+  /**
+   * Creates an empty set of rows for the result set.
+   *
+   * @param resultSet   The result set the rows are read from
+   * @param metaData    The metadata of the result set
+   * @param columnNames The column labels (see ExecSQLCallable.columnNames)
+   * @param columnar    Whether the client negotiated the columnar format
+   */
+  ResultRows(ResultSet resultSet, ResultSetMetaData metaData, String[] columnNames, boolean columnar)
+      throws SQLException {
+    this.resultSet = resultSet;
+    this.metaData = metaData;
+    this.columnNames = columnNames;
+    this.columnar = columnar;
+
+    if (!columnar) {
+      this.json = rows;
+      return;
+    }
+    final JSONArray columns = new JSONArray();
+    final JSONArray types = new JSONArray();
+    final JSONArray typeNames = new JSONArray();
+    for (int columnIndex = 1; columnIndex < columnNames.length; columnIndex++) {
+      columns.add(columnNames[columnIndex]);
+      types.add(metaData.getColumnType(columnIndex));
+      typeNames.add(metaData.getColumnTypeName(columnIndex));
+    }
+    final JSONObject set = new JSONObject();
+    set.put("columns", columns);
+    set.put("types", types);
+    set.put("typeNames", typeNames);
+    set.put("rows", rows);
+    this.json = set;
+  }
+
+  /**
+   * Creates an empty set of rows for the result set, reading its metadata.
+   */
+  ResultRows(ResultSet resultSet, boolean columnar) throws SQLException {
+    this(resultSet, resultSet.getMetaData(), ExecSQLCallable.columnNames(resultSet.getMetaData()), columnar);
+  }
+
+  /**
+   * Adds the row the result set is positioned on.
+   */
+  void addCurrentRow() throws SQLException {
+    if (!columnar) {
+      rows.add(ExecSQLCallable.readRow(resultSet, metaData, columnNames));
+      return;
+    }
+    final JSONArray values = new JSONArray();
+    for (int columnIndex = 1; columnIndex < columnNames.length; columnIndex++) {
+      values.add(ExecSQLCallable.readValue(resultSet, metaData, columnIndex));
+    }
+    rows.add(values);
+  }
+
+  /**
+   * Returns the number of rows added.
+   */
+  int size() {
+    return rows.size();
+  }
+
+  /**
+   * Returns the JSON value of the result set. Rows added later are included,
+   * so it can be put in the response before they are read.
+   */
+  Object toJSON() {
+    return json;
+  }
+}
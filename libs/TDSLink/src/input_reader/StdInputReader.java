@@ -5,6 +5,8 @@ import java.io.IOException;
 import java.io.InputStreamReader;
 import java.util.ArrayList;
 import java.util.List;
+import java.util.regex.Pattern;
+import net.minidev.json.JSONArray;
 import net.minidev.json.JSONObject;
 import net.minidev.json.parser.JSONParser;
 import net.minidev.json.parser.ParseException;
@@ -30,6 +32,9 @@ import utils.EncodedLogger;
  *              (like requesting the number of active connections)
  */
 public class StdInputReader {
+  // the password field of the JSON requests, value included
+  private static final Pattern PASSWORD_FIELD = Pattern.compile("(\"password\"\\s*:\\s*\")(?:[^\"\\\\]|\\\\.)*\"");
+
   private final List<SQLRequestListener> listeners = new ArrayList<>();
   private final BufferedReader inputBuffer = new BufferedReader(new InputStreamReader(System.in));
   private final JSONParser jsonParser = new JSONParser(JSONParser.DEFAULT_PERMISSIVE_MODE);
@@ -62,6 +67,13 @@ public class StdInputReader {
     }
   }
 
+  /**
+   * Masks the password of reauthenticate requests before logging them.
+   */
+  private static String redact(String input) {
+    return PASSWORD_FIELD.matcher(input).replaceAll("$1******\"");
+  }
+
   /**
    * Processes a single line of input from stdin.
    */
@@ -71,7 +83,7 @@ public class StdInputReader {
     if (normalizedInput.isBlank()) {
       return;
     }
-    EncodedLogger.log("Processing raw input: " + normalizedInput);
+    EncodedLogger.log("Processing raw input: " + redact(normalizedInput));
 
     try {
       SQLRequest request = parseRequest(normalizedInput);
@@ -127,6 +139,23 @@ public class StdInputReader {
       request.finishTrans = getBooleanValue(json, "finishTrans", true);
       request.timeout = getIntValue(json, "timeout", 3);
       request.timeoutUnit = getStringValue(json, "timeunit", "minutes");
+      request.cancelMsgId = getIntValue(json, "cancelMsgId", 0);
+      request.cancelTransId = getIntValue(json, "cancelTransId", 0);
+      request.killSpid = getIntValue(json, "killSpid", 0);
+      request.reauthenticate = getBooleanValue(json, "reauthenticate", false);
+      request.username = getStringValue(json, "username", null);
+      request.password = getStringValue(json, "password", null);
+      request.health = getBooleanValue(json, "health", false);
+      request.heartbeat = getBooleanValue(json, "heartbeat", false);
+      request.sessionId = getIntValue(json, "sessionId", -1);
+      request.closeSessionId = getIntValue(json, "closeSessionId", 0);
+      request.maxRows = getIntValue(json, "maxRows", 0);
+      request.traceId = getStringValue(json, "traceId", null);
+      request.capabilities = getStringList(json, "capabilities");
+      request.pageSize = getIntValue(json, "pageSize", 0);
+      request.fetchCursor = getIntValue(json, "fetchCursor", 0);
+      request.closeCursor = getIntValue(json, "closeCursor", 0);
+      request.columnar = getBooleanValue(json, "columnar", false);
       return request;
     } catch (ParseException ex) {
       EncodedLogger.logException(ex);
@@ -149,6 +178,20 @@ public class StdInputReader {
     }
   }
 
+  /**
+   * Helper method to extract string arrays from JSON, or null if missing.
+   */
+  private List<String> getStringList(JSONObject json, String key) {
+    if (!(json.get(key) instanceof JSONArray)) {
+      return null;
+    }
+    final List<String> values = new ArrayList<>();
+    for (Object value : (JSONArray) json.get(key)) {
+      values.add(String.valueOf(value));
+    }
+    return values;
+  }
+
   /**
    * Helper method to safely extract boolean values from JSON.
    */
@@ -5,6 +5,10 @@ import com.zaxxer.hikari.HikariDataSource;
 
 import java.sql.Connection;
 import java.sql.SQLException;
+import java.sql.Statement;
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Properties;
 import utils.EncodedLogger;
 
 /**
@@ -40,6 +44,8 @@ public class ConnectionPool {
    *                          (milliseconds)
    * @param keepaliveTime     Time between keepalive checks (milliseconds)
    * @param maxLifetime       Maximum lifetime of a connection (milliseconds)
+   * @param driverProperties  Additional jConnect connection properties
+   *                          (APPLICATIONNAME, HOSTNAME, ENABLE_SSL...)
    * @return Configured ConnectionPool instance
    * @throws SQLException If pool initialization fails
    */
@@ -47,7 +53,7 @@ public class ConnectionPool {
       String host, int port, String dbName, String username, String password,
       int minConnections, int maxConnections,
       int connectionTimeout, int idleTimeout,
-      int keepaliveTime, int maxLifetime)
+      int keepaliveTime, int maxLifetime, Properties driverProperties)
       throws SQLException {
 
     final HikariConfig config = createHikariConfig(
@@ -56,6 +62,12 @@ public class ConnectionPool {
         connectionTimeout, idleTimeout,
         keepaliveTime, maxLifetime);
 
+    // SybDataSource exposes each connection property as a setter
+    // (setAPPLICATIONNAME, setENABLE_SSL...)
+    for (String name : driverProperties.stringPropertyNames()) {
+      config.addDataSourceProperty(name, driverProperties.getProperty(name));
+    }
+
     return new ConnectionPool(new HikariDataSource(config));
   }
 
@@ -113,6 +125,51 @@ public class ConnectionPool {
     return this.dataSource.getConnection();
   }
 
+  /**
+   * Opens count physical connections at once and runs the probe on each of
+   * them, so the pool is filled and the credentials are checked before the
+   * first request. The connections are left idle in the pool.
+   *
+   * @param count The number of connections, usually minConnections
+   * @param probe The statement run on each connection
+   * @throws SQLException If a connection cannot be opened or the probe fails
+   */
+  public void warmUp(int count, String probe) throws SQLException {
+    final List<Connection> connections = new ArrayList<>(count);
+    try {
+      // holding them all forces distinct physical connections
+      for (int i = 0; i < count; i++) {
+        final Connection connection = this.dataSource.getConnection();
+        connections.add(connection);
+        try (Statement statement = connection.createStatement()) {
+          statement.execute(probe);
+        }
+      }
+    } finally {
+      for (Connection connection : connections) {
+        try {
+          connection.close();
+        } catch (SQLException ex) {
+          EncodedLogger.logException(ex);
+        }
+      }
+    }
+  }
+
+  /**
+   * Replaces the credentials used to open new connections. Idle connections
+   * are evicted right away and connections in use when they are returned,
+   * so the pool rotates to the new credentials without downtime.
+   * 
+   * @param username The new username
+   * @param password The new password
+   */
+  public void updateCredentials(String username, String password) {
+    this.dataSource.getHikariConfigMXBean().setUsername(username);
+    this.dataSource.getHikariConfigMXBean().setPassword(password);
+    this.dataSource.getHikariPoolMXBean().softEvictConnections();
+  }
+
   /**
    * Shuts down the connection pool, closing all active and idle connections.
    * 
@@ -135,6 +192,21 @@ public class ConnectionPool {
     return this.dataSource.getHikariPoolMXBean().getActiveConnections();
   }
 
+  /**
+   * Closes a connection taken from the pool instead of returning it, so its
+   * session state does not reach other requests.
+   *
+   * @param connection The connection to discard
+   */
+  public void evictConnection(Connection connection) {
+    this.dataSource.evictConnection(connection);
+    try {
+      connection.close();
+    } catch (SQLException ex) {
+      EncodedLogger.logException(ex);
+    }
+  }
+
   /**
    * Gets the current number of idle connections in the pool.
    * 
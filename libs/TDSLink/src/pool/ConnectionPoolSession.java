@@ -0,0 +1,88 @@
+package pool;
+
+import java.sql.Connection;
+import java.sql.SQLException;
+import java.util.ArrayList;
+import java.util.concurrent.ConcurrentHashMap;
+
+import utils.ConnectionTracker;
+
+/**
+ * Reserves connections of the regular pool for sessions, so every request of
+ * a session runs on the same connection and sees its session state (current
+ * database, SET options, temporary tables).
+ *
+ * <p>
+ * The connections stay in auto-commit mode. Since their session state must
+ * not leak into other requests, they are evicted from the pool when the
+ * session is closed instead of being returned to it.
+ * </p>
+ *
+ * @contributor CatHood0
+ */
+public class ConnectionPoolSession {
+
+  private final ConnectionPool pool;
+  private final ConcurrentHashMap<Integer, Connection> sessionConnections = new ConcurrentHashMap<>();
+
+  /**
+   * Constructs a new ConnectionPoolSession.
+   *
+   * @param pool The regular pool providing the connections
+   */
+  public ConnectionPoolSession(ConnectionPool pool) {
+    this.pool = pool;
+  }
+
+  /**
+   * Retrieves the connection of the session, reserving one on its first
+   * request.
+   *
+   * @param sessionId The session identifier
+   * @return The database connection of the session
+   * @throws SQLException If no connection can be obtained
+   */
+  public synchronized Connection getConnection(int sessionId) throws SQLException {
+    Connection connection = this.sessionConnections.get(sessionId);
+    if (connection == null) {
+      connection = this.pool.getConnection();
+      this.sessionConnections.put(sessionId, connection);
+      ConnectionTracker.checkout(ConnectionTracker.REGULAR_POOL, connection, 0, -1);
+    }
+    return connection;
+  }
+
+  /**
+   * Closes the session, evicting its connection from the pool.
+   *
+   * @param sessionId The session identifier
+   * @return false if the session had no connection
+   */
+  public synchronized boolean release(int sessionId) {
+    final Connection connection = this.sessionConnections.remove(sessionId);
+    if (connection == null) {
+      return false;
+    }
+    ConnectionTracker.checkin(ConnectionTracker.REGULAR_POOL, connection, 0, -1);
+    this.pool.evictConnection(connection);
+    return true;
+  }
+
+  /**
+   * Closes every session.
+   */
+  public synchronized void shutdown() {
+    for (Integer sessionId : new ArrayList<>(this.sessionConnections.keySet())) {
+      release(sessionId);
+    }
+  }
+
+  /**
+   * Gets the number of open sessions.
+   *
+   * @return Open session count
+   */
+  public int getSessionCount() {
+    return this.sessionConnections.size();
+  }
+}
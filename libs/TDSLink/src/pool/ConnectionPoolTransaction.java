@@ -5,6 +5,7 @@ import java.sql.Connection;
 import java.sql.Driver;
 import java.sql.DriverManager;
 import java.sql.SQLException;
+import java.sql.Statement;
 import java.util.ArrayList;
 import java.util.concurrent.ConcurrentHashMap;
 import java.util.concurrent.ConcurrentSkipListSet;
@@ -12,6 +13,7 @@ import java.util.List;
 import java.util.Properties;
 
 import com.sybase.jdbc4.jdbc.SybDataSource;
+import utils.ConnectionTracker;
 import utils.EncodedLogger;
 
 /**
@@ -35,6 +37,9 @@ public class ConnectionPoolTransaction {
   private final int maxTransactionConnections;
   private final ConcurrentSkipListSet<Connection> availableConnections;
   private final ConcurrentHashMap<Integer, Connection> activeTransactionConnections;
+  // creation time of each connection, to retire them past maxLifetime
+  private final ConcurrentHashMap<Connection, Long> creationTimes = new ConcurrentHashMap<>();
+  private final long maxLifetime;
 
   /**
    * Factory method to create a new ConnectionPoolTransaction instance.
@@ -45,21 +50,25 @@ public class ConnectionPoolTransaction {
    * @param username               Database username
    * @param password               Database password
    * @param transactionConnections Number of connections to maintain in the pool
+   * @param maxLifetime            Maximum lifetime of a connection in
+   *                               milliseconds (0 for no limit)
+   * @param driverProperties       Additional jConnect connection properties
    * @return Configured ConnectionPoolTransaction instance
    * @throws SQLException           If connection cannot be established
    * @throws ClassNotFoundException If JDBC driver not found
    */
   public static ConnectionPoolTransaction create(
       String host, int port, String dbName,
-      String username, String password, int transactionConnections)
+      String username, String password, int transactionConnections,
+      long maxLifetime, Properties driverProperties)
       throws SQLException, ClassNotFoundException {
     final String url = buildJdbcUrl(host, port, dbName);
     registerSybaseDriver();
 
-    final Properties props = buildProperties(username, password);
+    final Properties props = buildProperties(username, password, driverProperties);
     final List<Connection> initialConnections = initializeConnectionPool(url, props, transactionConnections);
 
-    return new ConnectionPoolTransaction(url, props, initialConnections, transactionConnections);
+    return new ConnectionPoolTransaction(url, props, initialConnections, transactionConnections, maxLifetime);
   }
 
   /**
@@ -88,10 +97,12 @@ public class ConnectionPoolTransaction {
   }
 
   /**
-   * Creates connection properties with credentials.
+   * Creates connection properties with credentials and the additional
+   * driver properties.
    */
-  private static Properties buildProperties(String username, String password) {
+  private static Properties buildProperties(String username, String password, Properties driverProperties) {
     Properties props = new Properties();
+    props.putAll(driverProperties);
     props.put("user", username);
     props.put("password", password);
     return props;
@@ -119,6 +130,17 @@ public class ConnectionPoolTransaction {
     return connection;
   }
 
+  /**
+   * Opens a connection outside the pool, in auto-commit mode. The caller
+   * must close it.
+   *
+   * @return A new database connection
+   * @throws SQLException If the connection cannot be opened
+   */
+  public Connection openConnection() throws SQLException {
+    return DriverManager.getConnection(this.databaseUrl, this.connectionProperties);
+  }
+
   /**
    * Constructs a new ConnectionPoolTransaction.
    * 
@@ -129,12 +151,66 @@ public class ConnectionPoolTransaction {
    */
   private ConnectionPoolTransaction(String url, Properties props,
       List<Connection> initialConnections,
-      int maxTransactionConnections) {
+      int maxTransactionConnections, long maxLifetime) {
     this.databaseUrl = url;
     this.connectionProperties = props;
     this.availableConnections = new ConcurrentSkipListSet<>(initialConnections);
     this.activeTransactionConnections = new ConcurrentHashMap<>();
     this.maxTransactionConnections = maxTransactionConnections;
+    this.maxLifetime = maxLifetime;
+
+    final long now = System.currentTimeMillis();
+    for (Connection connection : initialConnections) {
+      this.creationTimes.put(connection, now);
+    }
+  }
+
+  /**
+   * Opens a pooled connection, recording its creation time.
+   */
+  private Connection newConnection() throws SQLException {
+    final Connection connection = createNewConnection(this.databaseUrl, this.connectionProperties);
+    this.creationTimes.put(connection, System.currentTimeMillis());
+    return connection;
+  }
+
+  /**
+   * Indicates if the connection outlived maxLifetime. Firewalls may have
+   * silently dropped the socket of such connections.
+   */
+  private boolean expired(Connection connection) {
+    final Long createdAt = this.creationTimes.get(connection);
+    return this.maxLifetime > 0 && createdAt != null
+        && System.currentTimeMillis() - createdAt > this.maxLifetime;
+  }
+
+  /**
+   * Closes a connection taken out of the pool.
+   */
+  private void retire(Connection connection) {
+    this.creationTimes.remove(connection);
+    try {
+      if (!connection.isClosed()) {
+        connection.close();
+      }
+    } catch (SQLException ex) {
+      EncodedLogger.logException(ex);
+    }
+  }
+
+  /**
+   * Takes an available connection, replacing the expired ones.
+   */
+  private Connection takeAvailableConnection() throws SQLException {
+    while (!this.availableConnections.isEmpty()) {
+      final Connection connection = this.availableConnections.removeFirst();
+      if (!expired(connection)) {
+        return connection;
+      }
+      EncodedLogger.log("Retiring transaction connection past its max lifetime");
+      retire(connection);
+    }
+    return newConnection();
   }
 
   /**
@@ -151,9 +227,9 @@ public class ConnectionPoolTransaction {
       // if we don't get the transaction connection
       // just get the first one that is already active to be used
       // and updates its id value to match as expected
-      connection = !this.availableConnections.isEmpty() ? this.availableConnections.removeFirst()
-          : createNewConnection(this.databaseUrl, this.connectionProperties);
+      connection = takeAvailableConnection();
       this.activeTransactionConnections.put(transactionId, connection);
+      ConnectionTracker.checkout(ConnectionTracker.TRANSACTION_POOL, connection, 0, transactionId);
     }
 
     return connection;
@@ -170,6 +246,7 @@ public class ConnectionPoolTransaction {
 
     if (connection == null)
       return;
+    ConnectionTracker.checkin(ConnectionTracker.TRANSACTION_POOL, connection, 0, transactionId);
     try {
       if (!connection.isClosed()) {
         // if this connections has not auto-commit
@@ -193,11 +270,11 @@ public class ConnectionPoolTransaction {
         connection.close();
       }
     } finally {
+      this.creationTimes.remove(connection);
       // Always attempt to replenish the pool
       if (this.getAvailableConnectionCount() < this.maxTransactionConnections) {
         try {
-          this.availableConnections.add(
-              createNewConnection(this.databaseUrl, this.connectionProperties));
+          this.availableConnections.add(newConnection());
         } catch (SQLException ex) {
           EncodedLogger.logException(ex);
         }
@@ -205,6 +282,54 @@ public class ConnectionPoolTransaction {
     }
   }
 
+  /**
+   * Runs the probe on each available connection, which the pool opens on
+   * creation.
+   *
+   * @param probe The statement run on each connection
+   * @throws SQLException If the probe fails
+   */
+  public synchronized void warmUp(String probe) throws SQLException {
+    for (Connection connection : this.availableConnections) {
+      try (Statement statement = connection.createStatement()) {
+        statement.execute(probe);
+      }
+      // the connections are not in auto-commit mode
+      connection.rollback();
+    }
+  }
+
+  /**
+   * Replaces the credentials used to open new connections, after checking
+   * them with a test connection. Idle connections are replaced right away;
+   * connections in use are replaced when their transaction finishes.
+   *
+   * @param username The new username
+   * @param password The new password
+   * @throws SQLException If the credentials are rejected
+   */
+  public synchronized void updateCredentials(String username, String password) throws SQLException {
+    final Properties props = new Properties();
+    props.putAll(this.connectionProperties);
+    props.put("user", username);
+    props.put("password", password);
+    DriverManager.getConnection(this.databaseUrl, props).close();
+
+    this.connectionProperties.put("user", username);
+    this.connectionProperties.put("password", password);
+
+    final List<Connection> idle = new ArrayList<>(this.availableConnections);
+    this.availableConnections.clear();
+    for (Connection conn : idle) {
+      retire(conn);
+      try {
+        this.availableConnections.add(newConnection());
+      } catch (SQLException ex) {
+        EncodedLogger.logException(ex);
+      }
+    }
+  }
+
   /**
    * Shuts down the connection pool and closes all connections.
    * 
@@ -1,5 +1,9 @@
 package requests;
 
+import java.sql.SQLException;
+import java.sql.Statement;
+import java.util.List;
+
 /**
  * This class is used to store the information of a SQL request.
  * 
@@ -15,6 +19,49 @@ public class SQLRequest {
   public String sql; // The sql statement to be executed
   public long sentTime; // The time the request was sent
   public long javaStartTime; // The time the request was received
+  public int cancelMsgId; // The message id of the request to cancel
+  public int cancelTransId; // The transaction whose running statement must be cancelled
+  public int killSpid; // The server process to kill
+  public boolean reauthenticate; // Indicates if the pools must switch to new credentials
+  public String username; // The new username of a reauthenticate request
+  public String password; // The new password of a reauthenticate request
+  public boolean health; // Indicates if the request asks for the health of the bridge
+  public boolean heartbeat; // Indicates if the request only checks that the bridge is alive
+  public int sessionId = -1; // The session whose connection runs the request
+  public int closeSessionId; // The session to close, releasing its connection
+  public int maxRows; // The rows returned per result set (0 for no limit)
+  public String traceId; // The correlation id of the client request, echoed in the logs
+  public List<String> capabilities; // The features offered by the client in the handshake
+  public int pageSize; // The rows of each page of a paged query (0 to read the whole result)
+  public int fetchCursor; // The paged cursor whose next page is requested
+  public int closeCursor; // The paged cursor to close before reading every page
+  public boolean columnar; // Indicates if the result sets are sent as columns and arrays of values
+
+  /**
+   * Indicates if the request controls other requests instead of running SQL.
+   */
+  public boolean isControl() {
+    return cancelMsgId > 0 || cancelTransId > 0 || killSpid > 0 || reauthenticate || health || heartbeat || closeSessionId > 0
+        || capabilities != null || fetchCursor > 0 || closeCursor > 0;
+  }
+
+  /**
+   * Limits the rows the statement fetches to maxRows, fetching one more to
+   * find out if the result set was truncated.
+   */
+  public void limitRows(Statement statement) throws SQLException {
+    if (maxRows > 0) {
+      statement.setMaxRows(maxRows + 1);
+    }
+  }
+
+  /**
+   * Indicates if a result set holding the given rows reached maxRows, so the
+   * remaining ones must be dropped.
+   */
+  public boolean rowLimitReached(int rows) {
+    return maxRows > 0 && rows >= maxRows;
+  }
 
   public String id() {
     return String.valueOf(transId > -1 ? transId : msgId);
@@ -0,0 +1,52 @@
+package utils;
+
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.List;
+
+/**
+ * The optional features of the bridge, negotiated with the client so that
+ * clients and bridges of different versions work together on the features
+ * both support.
+ *
+ * <p>
+ * The client sends the features it knows in a handshake request and the
+ * bridge answers with the common ones. Clients that predate the handshake
+ * never send it, and get every feature as before.
+ * </p>
+ */
+public class Capabilities {
+
+  /**
+   * The features this bridge supports.
+   */
+  public static final List<String> SUPPORTED = Arrays.asList(
+      "cancel", // cancelMsgId and cancelTransId
+      "kill", // killSpid
+      "reauthenticate", // switching the pools to new credentials
+      "health", // the health of the JVM and the pools
+      "sessions", // sessionId and closeSessionId
+      "maxRows", // the row limit of the result sets
+      "traceId", // the trace id echoed in the logs
+      "paging", // pageSize, fetchCursor and closeCursor
+      "columnar"); // result sets as {"columns": [...], "rows": [[...]]}
+
+  private Capabilities() {
+  }
+
+  /**
+   * Selects the features supported by both the client and the bridge.
+   *
+   * @param offered The features offered by the client
+   * @return The common features, in the order of the client
+   */
+  public static List<String> negotiate(List<String> offered) {
+    final List<String> common = new ArrayList<>();
+    for (String capability : offered) {
+      if (SUPPORTED.contains(capability) && !common.contains(capability)) {
+        common.add(capability);
+      }
+    }
+    return common;
+  }
+}
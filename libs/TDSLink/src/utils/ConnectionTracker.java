@@ -0,0 +1,90 @@
+package utils;
+
+import java.sql.Connection;
+import java.sql.SQLException;
+import java.util.Collections;
+import java.util.Map;
+import java.util.WeakHashMap;
+import java.util.concurrent.atomic.AtomicInteger;
+
+import net.minidev.json.JSONObject;
+
+/**
+ * Reports the checkouts and checkins of pooled connections to the client as
+ * event lines on stdout, so it can mirror the state of the pools:
+ *
+ * <pre>
+ * {"event": "checkout", "pool": "regular", "connectionId": 3, "messageId": 12, "transactionId": -1}
+ * </pre>
+ *
+ * <p>
+ * Each physical connection gets a stable id, also when it is handed out
+ * wrapped by the pool.
+ * </p>
+ */
+public class ConnectionTracker {
+
+  public static final String REGULAR_POOL = "regular";
+  public static final String TRANSACTION_POOL = "transaction";
+
+  private static final Map<Connection, Integer> ids = Collections.synchronizedMap(new WeakHashMap<>());
+  private static final AtomicInteger nextId = new AtomicInteger();
+
+  private ConnectionTracker() {
+  }
+
+  /**
+   * Reports that a connection was taken from a pool.
+   *
+   * @param pool          The pool of the connection
+   * @param connection    The connection
+   * @param messageId     The request using it, or 0 for transactions
+   * @param transactionId The transaction pinning it, or -1
+   */
+  public static void checkout(String pool, Connection connection, int messageId, int transactionId) {
+    emit("checkout", pool, connection, messageId, transactionId);
+  }
+
+  /**
+   * Reports that a connection was returned to its pool.
+   *
+   * @param pool          The pool of the connection
+   * @param connection    The connection
+   * @param messageId     The request that used it, or 0 for transactions
+   * @param transactionId The transaction that pinned it, or -1
+   */
+  public static void checkin(String pool, Connection connection, int messageId, int transactionId) {
+    emit("checkin", pool, connection, messageId, transactionId);
+  }
+
+  private static void emit(String event, String pool, Connection connection, int messageId, int transactionId) {
+    if (connection == null) {
+      return;
+    }
+    final JSONObject json = new JSONObject();
+    json.put("event", event);
+    json.put("pool", pool);
+    json.put("connectionId", connectionId(connection));
+    json.put("messageId", messageId);
+    json.put("transactionId", transactionId);
+    System.out.println(json.toJSONString());
+  }
+
+  /**
+   * Returns the id of the physical connection behind the pool wrapper. The
+   * responses carry it, so the client can check that the statements of a
+   * transaction or session ran on the same connection.
+   *
+   * @param connection The connection, as handed out by its pool
+   * @return The id of the physical connection
+   */
+  public static int connectionId(Connection connection) {
+    Connection physical = connection;
+    try {
+      physical = connection.unwrap(Connection.class);
+    } catch (SQLException ex) {
+      // not a wrapper
+    }
+    return ids.computeIfAbsent(physical, key -> nextId.incrementAndGet());
+  }
+}
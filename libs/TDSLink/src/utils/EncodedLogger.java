@@ -5,13 +5,39 @@ public class EncodedLogger {
   private static final String ERROR_PREFIX = "JAVAERROR:";
   private static final String EXCEPTION_PREFIX = "JAVAEXCEPTION:";
   private static final String LOG_PREFIX = "JAVALOG:";
+  // The trace id of the request the current thread runs
+  private static final ThreadLocal<String> TRACE_ID = new ThreadLocal<>();
 
   private EncodedLogger() {
   }
 
+  /**
+   * Tags the logs of the current thread with the trace id of the request it
+   * runs, until clearTraceId. A null or empty id clears it.
+   */
+  public static void setTraceId(String traceId) {
+    if (traceId == null || traceId.isEmpty()) {
+      TRACE_ID.remove();
+    } else {
+      TRACE_ID.set(traceId);
+    }
+  }
+
+  public static void clearTraceId() {
+    TRACE_ID.remove();
+  }
+
+  /**
+   * Returns the trace tag of the current thread, or an empty string.
+   */
+  private static String traceTag() {
+    final String traceId = TRACE_ID.get();
+    return traceId == null ? "" : "[traceId=" + traceId + "]";
+  }
+
   public static void logError(String str) {
     if (log) {
-      String message = buildStr(ERROR_PREFIX, str, "");
+      String message = buildStr(ERROR_PREFIX, traceTag(), str, "");
       if (message.length() > 1000) {
         message = message.substring(0, 1000) + " ... (truncated)";
       }
@@ -19,17 +45,34 @@ public class EncodedLogger {
     }
   }
 
+  /**
+   * Reports the error of a request that will get no response, tagged with
+   * its message id so the client can fail it. Printed even with logging
+   * disabled, since the client waits for it.
+   */
+  public static void logRequestError(int msgId, String str) {
+    System.err.println(buildStr(ERROR_PREFIX, "[msgId=" + msgId + "]", str));
+  }
+
+  /**
+   * Reports an error that stops the bridge. Printed even with logging
+   * disabled, so the client can tell why the bridge exited.
+   */
+  public static void logFatal(String str) {
+    System.err.println(buildStr(ERROR_PREFIX, str));
+  }
+
   public static void logException(Exception ex) {
     if (log) {
-      final String message = buildStr(EXCEPTION_PREFIX, "(1)", ex.toString(), "\n", EXCEPTION_PREFIX, "(2)",
-          ex.getMessage());
+      final String message = buildStr(EXCEPTION_PREFIX, traceTag(), "(1)", ex.toString(), "\n", EXCEPTION_PREFIX,
+          traceTag(), "(2)", ex.getMessage());
       System.err.println(message);
     }
   }
 
   public static void log(String str) {
     if (log) {
-      String message = buildStr(LOG_PREFIX, str);
+      String message = buildStr(LOG_PREFIX, traceTag(), str);
       if (message.length() > 1000) {
         message = message.substring(0, 1000) + " ... (truncated)";
       }
@@ -40,6 +83,9 @@ public class EncodedLogger {
   private static String buildStr(String... content) {
     String message = "";
     for (String str : content) {
+      if ("".equals(str)) {
+        continue;
+      }
       message += (str + " ");
     }
     return message.trim();
@@ -0,0 +1,31 @@
+package utils;
+
+/**
+ * Encodes binary column values (binary, varbinary, image) as Sybase hex
+ * literals, so they reach the client as "0x..." strings instead of an
+ * ambiguous JSON array of signed bytes.
+ */
+public class HexEncoder {
+
+  private static final char[] HEX_DIGITS = "0123456789abcdef".toCharArray();
+
+  private HexEncoder() {
+  }
+
+  /**
+   * Encodes the bytes as a hex literal.
+   *
+   * @param bytes The bytes to encode
+   * @return The "0x" prefixed hex string
+   */
+  public static String encode(byte[] bytes) {
+    final char[] hex = new char[2 + bytes.length * 2];
+    hex[0] = '0';
+    hex[1] = 'x';
+    for (int i = 0; i < bytes.length; i++) {
+      hex[2 + i * 2] = HEX_DIGITS[(bytes[i] >> 4) & 0x0F];
+      hex[3 + i * 2] = HEX_DIGITS[bytes[i] & 0x0F];
+    }
+    return new String(hex);
+  }
+}
@@ -0,0 +1,75 @@
+package utils;
+
+import java.sql.SQLException;
+import java.sql.Statement;
+import java.util.concurrent.ConcurrentHashMap;
+
+import requests.SQLRequest;
+
+/**
+ * Keeps the statements being executed, so a running request can be
+ * cancelled by its message id or by the transaction it belongs to.
+ */
+public class StatementRegistry {
+
+  private static final ConcurrentHashMap<Integer, Statement> byMessage = new ConcurrentHashMap<>();
+  private static final ConcurrentHashMap<Integer, Statement> byTransaction = new ConcurrentHashMap<>();
+
+  private StatementRegistry() {
+  }
+
+  /**
+   * Registers the statement executing the request.
+   *
+   * @param request   The request being executed
+   * @param statement The statement executing it
+   */
+  public static void register(SQLRequest request, Statement statement) {
+    byMessage.put(request.msgId, statement);
+    if (request.transId != -1) {
+      byTransaction.put(request.transId, statement);
+    }
+  }
+
+  /**
+   * Unregisters the statement of a request once it finished.
+   *
+   * @param request The executed request
+   */
+  public static void unregister(SQLRequest request) {
+    byMessage.remove(request.msgId);
+    if (request.transId != -1) {
+      byTransaction.remove(request.transId);
+    }
+  }
+
+  /**
+   * Cancels the statement of the request with the given message id.
+   *
+   * @param msgId The message id of the request
+   * @return false if the request is not running
+   * @throws SQLException if the driver fails to cancel the statement
+   */
+  public static boolean cancelMessage(int msgId) throws SQLException {
+    return cancel(byMessage.get(msgId));
+  }
+
+  /**
+   * Cancels the statement running in the given transaction.
+   *
+   * @param transId The transaction id
+   * @return false if the transaction is not running any statement
+   * @throws SQLException if the driver fails to cancel the statement
+   */
+  public static boolean cancelTransaction(int transId) throws SQLException {
+    return cancel(byTransaction.get(transId));
+  }
+
+  private static boolean cancel(Statement statement) throws SQLException {
+    if (statement == null) {
+      return false;
+    }
+    statement.cancel();
+    return true;
+  }
+}
@@ -0,0 +1,88 @@
+package utils;
+
+import java.io.FileInputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.security.GeneralSecurityException;
+import java.security.KeyStore;
+import java.security.cert.Certificate;
+import java.security.cert.CertificateFactory;
+import java.util.Properties;
+
+import javax.net.ssl.SSLContext;
+import javax.net.ssl.TrustManagerFactory;
+
+/**
+ * Configures the certificates trusted by the SSL connections of the driver
+ * from the tls.* options of the bridge:
+ *
+ * <ul>
+ * <li>tls.trustStore, tls.trustStorePassword, tls.trustStoreType: a JKS or
+ * PKCS12 trust store</li>
+ * <li>tls.caFile: a PEM bundle of CA certificates</li>
+ * </ul>
+ *
+ * <p>
+ * The ENABLE_SSL, SSL_TRUST_ALL_CERTS and SSL_HOSTNAME_IN_CERT connection
+ * properties are passed to the driver as jdbc.* options.
+ * </p>
+ */
+public class TLSConfigurator {
+
+  private TLSConfigurator() {
+  }
+
+  /**
+   * Applies the tls.* options.
+   *
+   * @param properties The bridge properties
+   * @throws IOException              if a file cannot be read
+   * @throws GeneralSecurityException if the certificates are invalid
+   */
+  public static void configure(Properties properties) throws IOException, GeneralSecurityException {
+    final String trustStore = properties.getProperty("tls.trustStore");
+    if (trustStore != null && !trustStore.isEmpty()) {
+      System.setProperty("javax.net.ssl.trustStore", trustStore);
+      setIfPresent(properties, "tls.trustStorePassword", "javax.net.ssl.trustStorePassword");
+      setIfPresent(properties, "tls.trustStoreType", "javax.net.ssl.trustStoreType");
+    }
+
+    final String caFile = properties.getProperty("tls.caFile");
+    if (caFile != null && !caFile.isEmpty()) {
+      SSLContext.setDefault(contextFromBundle(caFile));
+    }
+  }
+
+  private static void setIfPresent(Properties properties, String key, String systemProperty) {
+    final String value = properties.getProperty(key);
+    if (value != null && !value.isEmpty()) {
+      System.setProperty(systemProperty, value);
+    }
+  }
+
+  /**
+   * Creates an SSL context trusting the certificates of a PEM bundle.
+   */
+  private static SSLContext contextFromBundle(String path) throws IOException, GeneralSecurityException {
+    final KeyStore keyStore = KeyStore.getInstance(KeyStore.getDefaultType());
+    keyStore.load(null, null);
+
+    try (InputStream input = new FileInputStream(path)) {
+      int index = 0;
+      for (Certificate certificate : CertificateFactory.getInstance("X.509").generateCertificates(input)) {
+        keyStore.setCertificateEntry("ca-" + index++, certificate);
+      }
+      if (index == 0) {
+        throw new GeneralSecurityException("No certificates found in " + path);
+      }
+    }
+
+    final TrustManagerFactory trustManagers = TrustManagerFactory
+        .getInstance(TrustManagerFactory.getDefaultAlgorithm());
+    trustManagers.init(keyStore);
+
+    final SSLContext context = SSLContext.getInstance("TLS");
+    context.init(null, trustManagers.getTrustManagers(), null);
+    return context;
+  }
+}
@@ -0,0 +1,188 @@
+package gosybase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+const (
+	// readTextChunkSize is the number of bytes fetched by each READTEXT.
+	readTextChunkSize = 64 * 1024
+	// writeTextChunkSize is the number of bytes sent by each WRITETEXT or
+	// UPDATETEXT, kept small so the literal stays below the server limits.
+	writeTextChunkSize = 8 * 1024
+)
+
+// ReadText copies the text or image column of the row of table matching
+// where into w, fetching it in chunks with READTEXT instead of as a single
+// value. It returns the number of bytes written, or ErrNoRows if no row
+// matches. A NULL column writes nothing.
+//
+//	db.ReadText("documents", "body", "id = 10", file)
+func (ds *Database) ReadText(table, column, where string, w io.Writer) (int64, error) {
+	ctx, err := ds.lintText(table, column, where)
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := ds.RawQueryContext(ctx, fmt.Sprintf("SELECT datalength(%s) AS length FROM %s WHERE %s", column, table, where))
+	if err != nil {
+		return 0, err
+	}
+	if len(response.Results) == 0 {
+		return 0, ErrNoRows
+	}
+	length, err := toInt64(response.Results[0]["length"])
+	if err != nil {
+		return 0, err
+	}
+
+	var written int64
+	for offset := int64(0); offset < length; offset += readTextChunkSize {
+		response, err := ds.RawQueryContext(ctx, textPointer(table, column, where)+
+			fmt.Sprintf(" READTEXT %s.%s @textptr %d %d", table, column, offset, min(readTextChunkSize, length-offset)))
+		if err != nil {
+			return written, err
+		}
+		if len(response.Results) == 0 {
+			return written, fmt.Errorf("READTEXT returned no data at offset %d", offset)
+		}
+
+		chunk, err := textChunk(response.Results[0], min(readTextChunkSize, length-offset))
+		if err != nil {
+			return written, err
+		}
+		n, err := w.Write(chunk)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// WriteText replaces the text column of the row of table matching where
+// with the content of r, sending it in chunks with WRITETEXT and
+// UPDATETEXT. The writes are logged, so the database does not need the
+// select into/bulkcopy option. An empty r leaves the column NULL. It
+// returns the number of bytes read from r.
+//
+//	db.WriteText("documents", "body", "id = 10", file)
+func (ds *Database) WriteText(table, column, where string, r io.Reader) (int64, error) {
+	return ds.writeLOB(table, column, where, r, false)
+}
+
+// WriteImage is like WriteText for image columns, sending the content of r
+// as binary literals.
+func (ds *Database) WriteImage(table, column, where string, r io.Reader) (int64, error) {
+	return ds.writeLOB(table, column, where, r, true)
+}
+
+func (ds *Database) writeLOB(table, column, where string, r io.Reader, binary bool) (int64, error) {
+	ctx, err := ds.lintText(table, column, where)
+	if err != nil {
+		return 0, err
+	}
+
+	// a NULL column has no text pointer until it is explicitly set to NULL
+	if _, err := ds.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET %s = NULL WHERE %s", table, column, where)); err != nil {
+		return 0, err
+	}
+
+	var (
+		read    int64
+		pending []byte
+		first   = true
+		buffer  = make([]byte, writeTextChunkSize)
+	)
+	for {
+		n, readErr := io.ReadFull(r, buffer)
+		read += int64(n)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return read, readErr
+		}
+		done := readErr != nil
+
+		chunk := append(pending, buffer[:n]...)
+		pending = nil
+		if !binary && !done {
+			// never split a character between two chunks
+			chunk, pending = splitIncompleteRune(chunk)
+		}
+
+		if len(chunk) > 0 {
+			var literal string
+			if binary {
+				literal = ds.dialect.Literal(chunk)
+			} else {
+				literal = ds.dialect.Literal(string(chunk))
+			}
+
+			statement := fmt.Sprintf(" UPDATETEXT %s.%s @textptr NULL 0 WITH LOG %s", table, column, literal)
+			if first {
+				statement = fmt.Sprintf(" WRITETEXT %s.%s @textptr WITH LOG %s", table, column, literal)
+			}
+			if _, err := ds.ExecContext(ctx, textPointer(table, column, where)+statement); err != nil {
+				return read, err
+			}
+			first = false
+		}
+
+		if done {
+			return read, nil
+		}
+	}
+}
+
+// lintText checks the table, column and where fragments in strict mode and
+// returns the context of the statements built around them, which skip the
+// checks.
+func (ds *Database) lintText(table, column, where string) (context.Context, error) {
+	ctx := context.Background()
+	for _, fragment := range []string{table, column, where} {
+		if err := ds.lint(ctx, fragment); err != nil {
+			return nil, err
+		}
+	}
+	return AllowUnsafe(ctx), nil
+}
+
+// textPointer declares @textptr with the text pointer of column.
+func textPointer(table, column, where string) string {
+	return fmt.Sprintf("DECLARE @textptr varbinary(16) SELECT @textptr = textptr(%s) FROM %s WHERE %s", column, table, where)
+}
+
+// textChunk extracts the data of the single column returned by READTEXT.
+// Image chunks arrive as hex strings twice as long as the requested size.
+func textChunk(row map[string]any, size int64) ([]byte, error) {
+	for _, value := range row {
+		switch v := value.(type) {
+		case nil:
+			return nil, nil
+		case string:
+			if int64(len(v)) != 2+2*size {
+				return []byte(v), nil
+			}
+		}
+		return toBytes(value)
+	}
+	return nil, errors.New("READTEXT returned no columns")
+}
+
+// splitIncompleteRune splits off the bytes of a UTF-8 character cut at the
+// end of chunk.
+func splitIncompleteRune(chunk []byte) ([]byte, []byte) {
+	for i := len(chunk) - 1; i >= 0 && i >= len(chunk)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(chunk[i]) {
+			if !utf8.FullRune(chunk[i:]) {
+				return chunk[:i], bytes.Clone(chunk[i:])
+			}
+			break
+		}
+	}
+	return chunk, nil
+}
@@ -0,0 +1,47 @@
+package gosybase
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+)
+
+// SetLogger replaces the logger of the database and its bridge, set on
+// connect from Config.Logger. A nil logger logs to slog.Default().
+//
+//	db.SetLogger(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+func (ds *Database) SetLogger(logger *slog.Logger) {
+	ds.logger = logger
+	ds.propagateLogger()
+}
+
+// Logger returns the logger of the database, with its labels.
+func (ds *Database) Logger() *slog.Logger {
+	logger := ds.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if len(ds.labels) == 0 {
+		return logger
+	}
+	attrs := make([]any, 0, len(ds.labels))
+	for _, key := range slices.Sorted(maps.Keys(ds.labels)) {
+		attrs = append(attrs, slog.String(key, ds.labels[key]))
+	}
+	return logger.With(slog.Group("labels", attrs...))
+}
+
+// propagateLogger passes the logger to the backend, so the bridge logs
+// carry the labels too.
+func (ds *Database) propagateLogger() {
+	backend, ok := ds.db.(interface{ SetLogger(*slog.Logger) })
+	if !ok {
+		return
+	}
+	if ds.logger == nil && len(ds.labels) == 0 {
+		// follows later changes of slog.Default()
+		backend.SetLogger(nil)
+		return
+	}
+	backend.SetLogger(ds.Logger())
+}
@@ -0,0 +1,33 @@
+package gosybase
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrTruncated is returned, along with the rows received, when a result set
+// had more rows than Config.MaxRows (or the limit of WithMaxRows) and the
+// bridge dropped the rest.
+var ErrTruncated = errors.New("result set truncated at the max rows limit")
+
+type maxRowsKey struct{}
+
+// WithMaxRows returns a context whose queries return at most maxRows rows
+// per result set, overriding Config.MaxRows. A negative maxRows removes the
+// limit. Backends without row limits ignore it.
+//
+//	response, err := db.RawQueryContext(gosybase.WithMaxRows(ctx, 500), "SELECT * FROM audit")
+//	if errors.Is(err, gosybase.ErrTruncated) {
+//		// response holds the first 500 rows
+//	}
+func WithMaxRows(ctx context.Context, maxRows int) context.Context {
+	return context.WithValue(ctx, maxRowsKey{}, maxRows)
+}
+
+// truncated returns ErrTruncated if the row limit cut the response.
+func truncated(response *RawResponse) error {
+	if response != nil && response.Truncated {
+		return ErrTruncated
+	}
+	return nil
+}
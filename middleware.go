@@ -0,0 +1,61 @@
+package gosybase
+
+import (
+	"context"
+	"fmt"
+)
+
+// Rewriter rewrites a query just before it is sent to the bridge, e.g. to
+// inject hints, wrap it in SET ROWCOUNT or route tables to an archive
+// schema. Returning an error aborts the query.
+type Rewriter func(ctx context.Context, query string) (string, error)
+
+// Use appends rewriters to the middleware chain. Rewriters run in the
+// order they were added, each one receiving the output of the previous.
+//
+//	db.Use(func(ctx context.Context, query string) (string, error) {
+//		return "SET ROWCOUNT 1000 " + query + " SET ROWCOUNT 0", nil
+//	})
+func (ds *Database) Use(rewriters ...Rewriter) {
+	ds.rewriters = append(ds.rewriters, rewriters...)
+}
+
+// rewrite checks query in strict mode (see SetStrict), runs it through the
+// middleware chain and adds the tag of ctx (see WithTag).
+func (ds *Database) rewrite(ctx context.Context, query string) (string, error) {
+	if err := ds.lint(ctx, query); err != nil {
+		return "", err
+	}
+	for _, rewriter := range ds.rewriters {
+		rewritten, err := rewriter(ctx, query)
+		if err != nil {
+			return "", fmt.Errorf("query rewrite failed: %w", err)
+		}
+		query = rewritten
+	}
+	return tagged(ctx, query), nil
+}
+
+// raw rewrites query and sends it to the bridge.
+func (ds *Database) raw(ctx context.Context, query string) (*RawResponse, error) {
+	query, err := ds.rewrite(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return ds.rawWith(ctx, query)
+}
+
+// rawWith sends query with the row limit (WithMaxRows) and the trace ID
+// (WithTraceID) of ctx, if any and if the backend supports them.
+func (ds *Database) rawWith(ctx context.Context, query string) (*RawResponse, error) {
+	maxRows, limited := ctx.Value(maxRowsKey{}).(int)
+	traceID := traceIDOf(ctx)
+	if limited || traceID != "" {
+		if sender, ok := ds.db.(interface {
+			RawWith(sql string, opts RawOptions) (*RawResponse, error)
+		}); ok {
+			return sender.RawWith(query, RawOptions{MaxRows: maxRows, TraceID: traceID})
+		}
+	}
+	return ds.db.Raw(query)
+}
@@ -0,0 +1,16 @@
+module github.com/CatHood0/Go-Sybase/migratesybase
+
+go 1.24.3
+
+require (
+	github.com/CatHood0/Go-Sybase v0.0.0
+	github.com/golang-migrate/migrate/v4 v4.18.1
+)
+
+require (
+	github.com/hashicorp/errwrap v1.1.0 // indirect
+	github.com/hashicorp/go-multierror v1.1.1 // indirect
+	go.uber.org/atomic v1.7.0 // indirect
+)
+
+replace github.com/CatHood0/Go-Sybase => ../
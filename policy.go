@@ -0,0 +1,33 @@
+package gosybase
+
+import sybase "github.com/CatHood0/Go-Sybase/internal"
+
+// Policy decides whether a statement may be sent to the server. Set them in
+// Config.Policies: the first one returning an error blocks the statement
+// with ErrPolicyViolation, before it reaches the bridge.
+//
+//	config.Policies = []gosybase.Policy{
+//		gosybase.DenyDeleteWithoutWhere,
+//		gosybase.DenyTruncate,
+//		gosybase.DenyCrossDatabase(config.Database),
+//	}
+type Policy = sybase.Policy
+
+// ErrPolicyViolation is returned for the statements blocked by a Policy.
+var ErrPolicyViolation = sybase.ErrPolicyViolation
+
+var (
+	// Deny blocks the statements matching a pattern.
+	Deny = sybase.Deny
+	// Allow only lets through the statements matching one of the patterns.
+	Allow = sybase.Allow
+	// DenyTruncate blocks TRUNCATE TABLE.
+	DenyTruncate Policy = sybase.DenyTruncate
+	// DenyDeleteWithoutWhere blocks the DELETE statements without WHERE.
+	DenyDeleteWithoutWhere Policy = sybase.DenyDeleteWithoutWhere
+	// DenyUpdateWithoutWhere blocks the UPDATE statements without WHERE.
+	DenyUpdateWithoutWhere Policy = sybase.DenyUpdateWithoutWhere
+	// DenyCrossDatabase blocks USE and the names qualified with a database
+	// other than the given one.
+	DenyCrossDatabase = sybase.DenyCrossDatabase
+)
@@ -0,0 +1,28 @@
+package gosybase
+
+import (
+	sybase "github.com/CatHood0/Go-Sybase/internal"
+)
+
+// RawResponse holds every row returned by a query.
+type RawResponse = sybase.RawResponse
+
+// ColumnType is the type of a result set column as reported by the bridge
+// (see RawResponse.ColumnTypes and Config.TypedValues).
+type ColumnType = sybase.ColumnType
+
+// Querier is implemented by *Database, *Tx and *Session, so application code
+// can accept it and run inside or outside a transaction, or against a mock.
+type Querier interface {
+	RawQuery(query string) (*RawResponse, error)
+	QueryFirst(query string) (map[string]any, error)
+	Query(query string, callback func(map[string]any) error) error
+	Exec(query string) (any, error)
+	Begin() (*Tx, error)
+}
+
+var (
+	_ Querier = (*Database)(nil)
+	_ Querier = (*Tx)(nil)
+	_ Querier = (*Session)(nil)
+)
@@ -0,0 +1,123 @@
+package gosybase
+
+import (
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+// Repository provides the common CRUD operations for the rows of a single
+// table mapped to T through `db` tags (see builder.StructFields).
+//
+//	users := gosybase.NewRepository[User](db, "users", "id")
+//	user, err := users.Find(1)
+type Repository[T any] struct {
+	db            *Database
+	table         string
+	idColumn      string
+	deletedColumn string
+	unscoped      bool
+}
+
+// NewRepository creates a repository for table, whose rows are identified by idColumn.
+func NewRepository[T any](db *Database, table, idColumn string) *Repository[T] {
+	return &Repository[T]{db: db, table: table, idColumn: idColumn}
+}
+
+// WithSoftDelete enables soft deletes using column (usually deleted_at):
+// Delete sets it to getdate() instead of removing the row, and Find and
+// FindAll skip the rows where it is not NULL (see the WithSoftDelete
+// builder methods). Use Unscoped to bypass it.
+func (r *Repository[T]) WithSoftDelete(column string) *Repository[T] {
+	r.deletedColumn = column
+	return r
+}
+
+// Unscoped returns a copy of the repository that ignores soft deletes, so
+// Find and FindAll also return deleted rows and Delete removes them for good.
+//
+//	users.Unscoped().Delete(1)
+func (r *Repository[T]) Unscoped() *Repository[T] {
+	unscoped := *r
+	unscoped.unscoped = true
+	return &unscoped
+}
+
+// scope applies the soft deletes of r to query.
+func (r *Repository[T]) scope(query *builder.SelectQuery) *builder.SelectQuery {
+	if r.deletedColumn == "" {
+		return query
+	}
+	query = query.WithSoftDelete(r.deletedColumn)
+	if r.unscoped {
+		query = query.Unscoped()
+	}
+	return query
+}
+
+// Find returns the row whose id column equals id, or ErrNoRows.
+func (r *Repository[T]) Find(id any) (*T, error) {
+	response, err := r.db.QueryBuilder(r.scope(r.db.NewSelect().
+		SelectColumns("*").
+		From(r.table).
+		WhereEq(r.idColumn, id)))
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Results) == 0 {
+		return nil, ErrNoRows
+	}
+	return mapToStruct[T](response.Results[0])
+}
+
+// FindAll returns every row matching the where condition. The condition
+// may use ? placeholders, bound to args with the database dialect. An
+// empty condition returns every row of the table.
+//
+//	users.FindAll("age > ? AND active = ?", 18, true)
+func (r *Repository[T]) FindAll(where string, args ...any) ([]T, error) {
+	query := r.db.NewSelect().SelectColumns("*").From(r.table)
+	if where != "" {
+		condition, err := r.db.dialect.Bind(where, args...)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("(" + condition + ")")
+	}
+
+	response, err := r.db.QueryBuilder(r.scope(query))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]T, 0, len(response.Results))
+	for _, result := range response.Results {
+		row, err := mapToStruct[T](result)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, *row)
+	}
+	return rows, nil
+}
+
+// Insert inserts v and returns the generated identity value (see InsertStruct).
+func (r *Repository[T]) Insert(v *T) (int64, error) {
+	return InsertStruct(r.db, r.table, v)
+}
+
+// Update writes every mapped column of v to the row identified by its id column (see UpdateStruct).
+func (r *Repository[T]) Update(v *T) (any, error) {
+	return UpdateStruct(r.db, r.table, v, r.idColumn)
+}
+
+// Delete removes the row whose id column equals id. With soft deletes
+// enabled the row is marked as deleted instead.
+func (r *Repository[T]) Delete(id any) (any, error) {
+	query := r.db.NewDelete().From(r.table).WhereEq(r.idColumn, id)
+	if r.deletedColumn != "" {
+		query = query.WithSoftDelete(r.deletedColumn)
+		if r.unscoped {
+			query = query.Unscoped()
+		}
+	}
+	return r.db.ExecBuilder(query)
+}
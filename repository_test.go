@@ -0,0 +1,65 @@
+package gosybase_test
+
+import (
+	"testing"
+
+	gosybase "github.com/CatHood0/Go-Sybase"
+	builder "github.com/CatHood0/Go-Sybase/builders"
+	"github.com/CatHood0/Go-Sybase/gosybasetest"
+)
+
+type order struct {
+	ID     int `db:"id"`
+	Status int `db:"status"`
+}
+
+func TestRepositoryConditionsWithAndOr(t *testing.T) {
+	bridge := gosybasetest.NewBridge().Default(gosybasetest.Response{Rows: []map[string]any{{"id": 1.0, "status": 2.0}}})
+	orders := gosybase.NewRepository[order](bridge.Open(builder.DialectASE), "ORDERS", "id").WithSoftDelete("deleted_at")
+
+	if _, err := orders.Find(1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := orders.FindAll("status = ? OR status = ?", 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := orders.Unscoped().FindAll("status = ? AND id > ?", 1, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"SELECT * FROM ORDERS WHERE id = 1 AND deleted_at IS NULL;",
+		"SELECT * FROM ORDERS WHERE (status = 1 OR status = 2) AND deleted_at IS NULL;",
+		"SELECT * FROM ORDERS WHERE (status = 1 AND id > 0);",
+	}
+	queries := bridge.Queries()
+	if len(queries) != len(want) {
+		t.Fatalf("queries = %q", queries)
+	}
+	for i := range want {
+		if queries[i] != want[i] {
+			t.Fatalf("query %d = %q, want %q", i, queries[i], want[i])
+		}
+	}
+}
+
+func TestRepositorySoftDelete(t *testing.T) {
+	bridge := gosybasetest.NewBridge()
+	orders := gosybase.NewRepository[order](bridge.Open(builder.DialectASE), "orders", "id").WithSoftDelete("deleted_at")
+
+	if _, err := orders.Delete(1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := orders.Unscoped().Delete(2); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"UPDATE orders SET deleted_at = getdate() WHERE id = 1 AND deleted_at IS NULL;",
+		"DELETE FROM orders WHERE id = 2;",
+	}
+	queries := bridge.Queries()
+	if len(queries) != len(want) || queries[0] != want[0] || queries[1] != want[1] {
+		t.Fatalf("queries = %q, want %q", queries, want)
+	}
+}
@@ -3,8 +3,12 @@ package gosybase
 import (
 	"errors"
 	"fmt"
+	"reflect"
 )
 
+// ErrNoRows is returned when a query expected at least one row and got none.
+var ErrNoRows = errors.New("no rows in result set")
+
 // Row is the result of calling [DB.QueryRow] to select a single row.
 type Row struct {
 	// One of these two will be non-nil:
@@ -14,6 +18,7 @@ type Row struct {
 
 type Rows struct {
 	cols     []map[string]any
+	columns  []string // column order of the query, when the bridge reports it
 	curIndex int
 	err      error
 }
@@ -32,7 +37,7 @@ func (r *Row) Scan(dest ...any) error {
 		if err := r.rows.Err(); err != nil {
 			return err
 		}
-		return errors.New("No rows")
+		return ErrNoRows
 	}
 	err := r.rows.Scan(dest...)
 	if err != nil {
@@ -113,10 +118,22 @@ func (rs *Rows) Next() bool {
 // that error will be wrapped in the returned error.
 func (rs *Rows) Scan(dest ...any) error {
 	rowsValue := rs.cols[rs.curIndex]
+	if rs.columns != nil {
+		if len(dest) != len(rs.columns) {
+			return fmt.Errorf("sql: expected %d destination arguments in Scan, not %d", len(rs.columns), len(dest))
+		}
+		for index, key := range rs.columns {
+			if err := assignRowValue(dest[index], rowsValue[key]); err != nil {
+				return fmt.Errorf(`sql: Scan error on column index %d, name %q: %w`, index, key, err)
+			}
+		}
+		rs.curIndex += 1
+		return nil
+	}
+
 	var index uint = 0
 	for key, value := range rowsValue {
-		columnDest := dest[index]
-		err := assignRowValue(&columnDest, value)
+		err := assignRowValue(dest[index], value)
 		if err != nil {
 			return fmt.Errorf(`sql: Scan error on column index %d, name %q: %w`, rs.curIndex, key, err)
 		}
@@ -126,8 +143,12 @@ func (rs *Rows) Scan(dest ...any) error {
 	return nil
 }
 
-func assignRowValue(dest *any, value any) error {
-	return nil
+func assignRowValue(dest any, value any) error {
+	target := reflect.ValueOf(dest)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("destination not a pointer: %T", dest)
+	}
+	return convertAssign(target.Elem(), value)
 }
 
 // Err provides a way for wrapping packages to check for
@@ -0,0 +1,128 @@
+package gosybase
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// First runs query, binding its ? placeholders to args with the database
+// dialect, and returns its first row mapped into T, or ErrNoRows. T may be
+// a struct, mapped like Repository rows through the `db` tags, or a scalar
+// taking the only column of the row.
+//
+//	user, err := gosybase.First[User](db, "SELECT * FROM users WHERE login = ?", login)
+//	total, err := gosybase.First[int64](db, "SELECT count(*) FROM users")
+func First[T any](db *Database, query string, args ...any) (*T, error) {
+	query, err := db.bind(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := db.RawQuery(query)
+	if err != nil && !errors.Is(err, ErrTruncated) {
+		return nil, err
+	}
+	if len(response.Results) == 0 {
+		return nil, ErrNoRows
+	}
+
+	var value T
+	if err := assignRow(reflect.ValueOf(&value).Elem(), response.Results[0], ""); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// Pluck runs query, binding its ? placeholders to args with the database
+// dialect, and returns its only column as a slice of T.
+//
+//	ids, err := gosybase.Pluck[int64](db, "SELECT id FROM users WHERE active = ?", true)
+func Pluck[T any](db *Database, query string, args ...any) ([]T, error) {
+	query, err := db.bind(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := db.RawQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]T, len(response.Results))
+	for i, row := range response.Results {
+		if err := assignRow(reflect.ValueOf(&values[i]).Elem(), row, ""); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// bind replaces the ? placeholders of query with the literals of args.
+func (ds *Database) bind(query string, args ...any) (string, error) {
+	if len(args) == 0 {
+		return query, nil
+	}
+	return ds.dialect.Bind(query, args...)
+}
+
+// QueryMap runs query and returns its rows keyed by the keyColumn value,
+// the usual way to load a lookup table into memory. V may be a struct,
+// mapped like Repository rows through the `db` tags, or a scalar taking
+// the only column besides keyColumn. Rows with a repeated key replace the
+// earlier ones.
+//
+//	names, err := gosybase.QueryMap[int, string](db, "SELECT id, name FROM countries", "id")
+//	users, err := gosybase.QueryMap[string, User](db, "SELECT * FROM users", "login")
+func QueryMap[K comparable, V any](db Querier, query string, keyColumn string) (map[K]V, error) {
+	response, err := db.RawQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[K]V, len(response.Results))
+	for _, row := range response.Results {
+		keyValue, ok := row[keyColumn]
+		if !ok {
+			return nil, fmt.Errorf("column %q not found in the result set", keyColumn)
+		}
+		var key K
+		if err := convertAssign(reflect.ValueOf(&key).Elem(), keyValue); err != nil {
+			return nil, fmt.Errorf("unable to assign column %q to the key: %w", keyColumn, err)
+		}
+
+		var value V
+		if err := assignRow(reflect.ValueOf(&value).Elem(), row, keyColumn); err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// assignRow stores row into dest: every column when dest is a struct, or
+// else the only column other than the excluded one.
+func assignRow(dest reflect.Value, row map[string]any, excluded string) error {
+	if dest.Kind() == reflect.Struct && dest.Type() != reflect.TypeFor[time.Time]() {
+		return assignStruct(dest, row)
+	}
+
+	var column string
+	for name := range row {
+		if name == excluded {
+			continue
+		}
+		if column != "" {
+			return fmt.Errorf("cannot assign a row with several columns to %s", dest.Type())
+		}
+		column = name
+	}
+	if column == "" {
+		return fmt.Errorf("no column to assign to %s", dest.Type())
+	}
+	if err := convertAssign(dest, row[column]); err != nil {
+		return fmt.Errorf("unable to assign column %q to %s: %w", column, dest.Type(), err)
+	}
+	return nil
+}
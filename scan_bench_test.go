@@ -0,0 +1,63 @@
+package gosybase
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// benchRows returns rows as the bridge decodes them.
+func benchRows(n int) []map[string]any {
+	rows := make([]map[string]any, n)
+	for i := range rows {
+		rows[i] = map[string]any{
+			"id":      float64(i),
+			"name":    fmt.Sprintf("user %d", i),
+			"balance": float64(i) + 0.25,
+			"active":  true,
+			"created": "2024-01-02T03:04:05.000Z",
+		}
+	}
+	return rows
+}
+
+type benchUser struct {
+	ID      int       `db:"id"`
+	Name    string    `db:"name"`
+	Balance float64   `db:"balance"`
+	Active  bool      `db:"active"`
+	Created time.Time `db:"created"`
+}
+
+func BenchmarkRowsScan(b *testing.B) {
+	rows := benchRows(1000)
+	columns := []string{"id", "name", "balance", "active", "created"}
+	var (
+		id      int
+		name    string
+		balance float64
+		active  bool
+		created time.Time
+	)
+	b.ReportAllocs()
+	for b.Loop() {
+		rs := Rows{cols: rows, columns: columns}
+		for rs.Next() {
+			if err := rs.Scan(&id, &name, &balance, &active, &created); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkMapToStruct(b *testing.B) {
+	rows := benchRows(1000)
+	b.ReportAllocs()
+	for b.Loop() {
+		for _, row := range rows {
+			if _, err := mapToStruct[benchUser](row); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
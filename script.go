@@ -0,0 +1,43 @@
+package gosybase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SplitScript splits a script in the isql format into its batches. A line
+// holding only "go", in any case, ends a batch; empty batches are dropped.
+func SplitScript(script string) []string {
+	var batches []string
+	var batch strings.Builder
+	flush := func() {
+		if text := strings.TrimSpace(batch.String()); text != "" {
+			batches = append(batches, text)
+		}
+		batch.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(script, "\n") {
+		if strings.EqualFold(strings.TrimSpace(line), "go") {
+			flush()
+			continue
+		}
+		batch.WriteString(line)
+	}
+	flush()
+	return batches
+}
+
+// ExecScript runs the batches of an isql script (see SplitScript) in
+// order, stopping at the first error, so schema scripts run without
+// shelling out to isql. Scripts with comments need AllowUnsafe(ctx) in
+// strict mode.
+func (ds *Database) ExecScript(ctx context.Context, script string) error {
+	for i, batch := range SplitScript(script) {
+		if _, err := ds.ExecContext(ctx, batch); err != nil {
+			return fmt.Errorf("batch %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package gosybase
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+// ServerInfo describes the server a Database is connected to, as detected
+// from @@version on connect.
+type ServerInfo struct {
+	Version string          // Full @@version string
+	Product builder.Dialect // ASE, SQL Anywhere or IQ
+	Major   int
+	Minor   int
+}
+
+// versionPattern matches the major and minor numbers of @@version, both in
+// the ASE format (Adaptive Server Enterprise/16.0 SP04 ...) and in the
+// plain SQL Anywhere/IQ format (17.0.11.6933).
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// AtLeast reports whether the server version is major.minor or later.
+func (s ServerInfo) AtLeast(major, minor int) bool {
+	return s.Major > major || (s.Major == major && s.Minor >= minor)
+}
+
+// SupportsMerge reports whether the server accepts MERGE statements
+// (ASE 15.7, SQL Anywhere 11 and IQ 15 onwards).
+func (s ServerInfo) SupportsMerge() bool {
+	switch s.Product {
+	case builder.DialectASE:
+		return s.AtLeast(15, 7)
+	case builder.DialectSQLAnywhere:
+		return s.AtLeast(11, 0)
+	case builder.DialectIQ:
+		return s.AtLeast(15, 0)
+	}
+	return false
+}
+
+// SupportsOffset reports whether the server supports TOP n START AT.
+func (s ServerInfo) SupportsOffset() bool {
+	return s.Product.SupportsOffset()
+}
+
+func (s ServerInfo) String() string {
+	return fmt.Sprintf("%s %d.%d", s.Product, s.Major, s.Minor)
+}
+
+// ServerInfo returns the server detected on connect. If the product could
+// not be identified, Product is Config.Dialect; if @@version failed, it is
+// the only field set.
+func (ds *Database) ServerInfo() ServerInfo {
+	return ds.serverInfo
+}
+
+// detectServer queries the server version and edition and switches the
+// dialect to the detected one, so pagination and date literals match the
+// server. The dialect only changes when the product is positively
+// identified; otherwise, or on failure, the configured dialect is kept.
+func (ds *Database) detectServer() {
+	ds.serverInfo = ServerInfo{Product: ds.dialect}
+
+	response, err := ds.QueryFirst("SELECT @@version AS version")
+	if err != nil {
+		ds.Logger().Warn("unable to detect the server version", "error", err)
+		return
+	}
+
+	info := parseServerInfo(fmt.Sprint(response["version"]))
+	info.Product = ds.dialect
+	product, ok := productFromVersion(info.Version)
+	if !ok {
+		// SQL Anywhere and IQ only report the version number
+		response, err := ds.QueryFirst("SELECT property('ProductName') AS product")
+		if err != nil {
+			ds.Logger().Warn("unable to detect the server edition", "error", err)
+			ds.serverInfo = info
+			return
+		}
+		name := fmt.Sprint(response["product"])
+		if product, ok = productFromName(name); !ok {
+			ds.Logger().Warn("unknown server edition, keeping the configured dialect", "product", name, "version", info.Version)
+			ds.serverInfo = info
+			return
+		}
+	}
+
+	info.Product = product
+	ds.serverInfo = info
+	ds.dialect = product
+}
+
+// parseServerInfo parses the version numbers of @@version. Product is left
+// to the caller (see productFromVersion and productFromName).
+func parseServerInfo(version string) ServerInfo {
+	info := ServerInfo{Version: version}
+	if match := versionPattern.FindStringSubmatch(version); match != nil {
+		info.Major, _ = strconv.Atoi(match[1])
+		info.Minor, _ = strconv.Atoi(match[2])
+	}
+	return info
+}
+
+// productFromVersion identifies ASE, the only product naming itself in
+// @@version (Adaptive Server Enterprise/16.0 SP04 ...).
+func productFromVersion(version string) (builder.Dialect, bool) {
+	if strings.Contains(version, "Adaptive Server Enterprise") || strings.Contains(version, "SAP ASE") {
+		return builder.DialectASE, true
+	}
+	return 0, false
+}
+
+// productFromName identifies the product from property('ProductName'),
+// which SQL Anywhere (SQL Anywhere, formerly Adaptive Server Anywhere) and
+// IQ (SAP IQ, formerly Sybase IQ) report.
+func productFromName(name string) (builder.Dialect, bool) {
+	switch {
+	case strings.Contains(name, "IQ"):
+		return builder.DialectIQ, true
+	case strings.Contains(name, "SQL Anywhere"), strings.Contains(name, "Adaptive Server Anywhere"):
+		return builder.DialectSQLAnywhere, true
+	}
+	return 0, false
+}
@@ -0,0 +1,80 @@
+package gosybase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+// serverBackend answers @@version and property('ProductName') like a server.
+type serverBackend struct {
+	version string
+	product string // empty: property() doesn't exist, as in ASE
+}
+
+func (b serverBackend) Raw(sql string) (*RawResponse, error) {
+	switch {
+	case strings.Contains(sql, "@@version"):
+		return &RawResponse{Results: []map[string]any{{"version": b.version}}}, nil
+	case strings.Contains(sql, "ProductName") && b.product != "":
+		return &RawResponse{Results: []map[string]any{{"product": b.product}}}, nil
+	}
+	return nil, errors.New("Function 'property' not found")
+}
+
+func (b serverBackend) Begin() (BackendTx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (b serverBackend) Disconnect() error {
+	return nil
+}
+
+func TestDetectServer(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		product    string
+		configured builder.Dialect
+		want       builder.Dialect
+		major      int
+		minor      int
+	}{
+		{
+			"ASE 16",
+			"Adaptive Server Enterprise/16.0 SP04 PL02/EBF 30014 SMP/P/x86_64/SLES 12.1/ase160sp04pl02x/3468/64-bit/FBO/Mon Nov 15 03:08:08 2021",
+			"", builder.DialectSQLAnywhere, builder.DialectASE, 16, 0,
+		},
+		{
+			"ASE 15.7",
+			"Adaptive Server Enterprise/15.7/EBF 25128 SMP SP137 /P/x86_64/Enterprise Linux/ase157sp137x/4185/64-bit/FBO/Thu Oct 22 09:38:47 2015",
+			"", builder.DialectSQLAnywhere, builder.DialectASE, 15, 7,
+		},
+		{"SQL Anywhere 17", "17.0.11.6933", "SQL Anywhere", builder.DialectASE, builder.DialectSQLAnywhere, 17, 0},
+		{"Adaptive Server Anywhere 9", "9.0.2.3951", "Adaptive Server Anywhere", builder.DialectASE, builder.DialectSQLAnywhere, 9, 0},
+		{"SAP IQ 16.1", "16.1.40.1549", "SAP IQ", builder.DialectASE, builder.DialectIQ, 16, 1},
+		{"Sybase IQ 15.4", "15.4.0.6567", "Sybase IQ", builder.DialectASE, builder.DialectIQ, 15, 4},
+		{
+			"unknown product without property",
+			"Microsoft SQL Server 2019 (RTM) - 15.0.2000.5 (X64)",
+			"", builder.DialectIQ, builder.DialectIQ, 15, 0,
+		},
+		{"unknown product name", "12.0.1.3152", "UltraLite", builder.DialectIQ, builder.DialectIQ, 12, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := NewDatabase(serverBackend{version: test.version, product: test.product}, test.configured)
+			db.detectServer()
+
+			info := db.ServerInfo()
+			if db.Dialect() != test.want || info.Product != test.want {
+				t.Fatalf("dialect = %s, product = %s, want %s", db.Dialect(), info.Product, test.want)
+			}
+			if info.Version != test.version || info.Major != test.major || info.Minor != test.minor {
+				t.Fatalf("ServerInfo = %+v, want version %d.%d", info, test.major, test.minor)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package gosybase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrSessionsNotSupported is returned by NewSession when the backend cannot
+// reserve connections.
+var ErrSessionsNotSupported = errors.New("backend does not support sessions")
+
+// BackendSession runs the queries of a session opened by a Backend.
+type BackendSession interface {
+	Raw(sql string) (*RawResponse, error)
+	Close() error
+}
+
+// SessionOptions configures the state of a new Session.
+type SessionOptions struct {
+	Database string   // Database the session switches to with USE
+	Set      []string // SET options, such as "ROWCOUNT 100" or "ANSINULL ON"
+	Init     []string // Statements run after the options, such as creating temporary tables
+}
+
+// Session runs its statements on a bridge connection reserved for it, in
+// auto-commit mode, so they share the session state: current database, SET
+// options, temporary tables and @@identity. Close releases the connection,
+// which is discarded with its state.
+type Session struct {
+	ds      *Database
+	session BackendSession
+}
+
+// NewSession reserves a connection and prepares its session state.
+//
+//	session, err := db.NewSession(gosybase.SessionOptions{
+//		Database: "reports",
+//		Set:      []string{"ROWCOUNT 1000"},
+//		Init:     []string{"CREATE TABLE #ids (id int)"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer session.Close()
+func (ds *Database) NewSession(opts SessionOptions) (*Session, error) {
+	opener, ok := ds.db.(interface {
+		NewSession() (BackendSession, error)
+	})
+	if !ok {
+		return nil, ErrSessionsNotSupported
+	}
+	backendSession, err := opener.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	session := &Session{ds: ds, session: backendSession}
+
+	statements := make([]string, 0, 1+len(opts.Set)+len(opts.Init))
+	if opts.Database != "" {
+		statements = append(statements, "USE "+opts.Database)
+	}
+	for _, option := range opts.Set {
+		statements = append(statements, "SET "+option)
+	}
+	statements = append(statements, opts.Init...)
+
+	for _, statement := range statements {
+		if _, err := session.Exec(statement); err != nil {
+			session.Close()
+			return nil, err
+		}
+	}
+	return session, nil
+}
+
+// RawQuery executes query in the session, returning every row.
+func (s *Session) RawQuery(query string) (*RawResponse, error) {
+	return s.raw(context.Background(), query)
+}
+
+// raw is RawQuery passing ctx to the rewriters.
+func (s *Session) raw(ctx context.Context, query string) (*RawResponse, error) {
+	query, err := s.ds.rewrite(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.session.Raw(query)
+	if err != nil {
+		s.ds.Logger().Error("query failed", "error", err)
+		return nil, fmt.Errorf("unable to execute the query by: %w", err)
+	}
+	return response, truncated(response)
+}
+
+// QueryFirst executes query in the session, returning its first row.
+func (s *Session) QueryFirst(query string) (map[string]any, error) {
+	response, err := s.RawQuery(query)
+	if err != nil && !errors.Is(err, ErrTruncated) {
+		return map[string]any{}, err
+	}
+	if len(response.Results) < 1 {
+		return map[string]any{}, fmt.Errorf("no result was found")
+	}
+	return response.Results[0], nil
+}
+
+// Query executes query in the session, calling callback for every row.
+func (s *Session) Query(query string, callback func(map[string]any) error) error {
+	response, err := s.RawQuery(query)
+	if err != nil && !errors.Is(err, ErrTruncated) {
+		return err
+	}
+	defer s.ds.release(response)
+	for _, result := range response.Results {
+		if err := callback(result); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Exec executes a statement in the session.
+func (s *Session) Exec(query string) (any, error) {
+	return s.RawQuery(query)
+}
+
+// Begin starts a transaction on the connection of the session, which sees
+// its temporary tables.
+func (s *Session) Begin() (*Tx, error) {
+	if _, err := s.Exec("BEGIN TRANSACTION"); err != nil {
+		return nil, err
+	}
+	return &Tx{ds: s.ds, tx: sessionTx{s.session}, savepoints: new(int)}, nil
+}
+
+// Close releases the connection of the session.
+func (s *Session) Close() error {
+	return s.session.Close()
+}
+
+// sessionTx adapts a transaction started with BEGIN TRANSACTION on a session
+// to BackendTx.
+type sessionTx struct {
+	BackendSession
+}
+
+func (tx sessionTx) Commit() error {
+	_, err := tx.Raw("COMMIT TRANSACTION")
+	return err
+}
+
+func (tx sessionTx) Rollback() error {
+	_, err := tx.Raw("ROLLBACK TRANSACTION")
+	return err
+}
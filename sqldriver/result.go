@@ -0,0 +1,60 @@
+package sqldriver
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+
+	gosybase "github.com/CatHood0/Go-Sybase"
+)
+
+// execSuffix is appended to the batches of Exec to read the rows affected
+// and the identity inserted by its last statement, on the same server
+// connection.
+const execSuffix = "\nSELECT @@rowcount AS rows_affected, @@identity AS last_insert_id"
+
+// result is the result of Exec. @@identity is the identity of the last row
+// inserted, so multi-row inserts report their last id.
+type result struct {
+	rowsAffected int64
+	lastInsertID int64
+}
+
+// newResult reads the counters from the last result set of the response.
+func newResult(response *gosybase.RawResponse) result {
+	var set []map[string]any
+	if n := len(response.ResultSets); n > 0 {
+		set = response.ResultSets[n-1]
+	}
+	if len(set) == 0 {
+		return result{}
+	}
+	return result{
+		rowsAffected: toInt64(set[0]["rows_affected"]),
+		lastInsertID: toInt64(set[0]["last_insert_id"]),
+	}
+}
+
+func (r result) LastInsertId() (int64, error) {
+	return r.lastInsertID, nil
+}
+
+func (r result) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
+
+var _ driver.Result = result{}
+
+func toInt64(value any) int64 {
+	switch v := value.(type) {
+	case float64:
+		return int64(v)
+	case json.Number:
+		n, _ := v.Int64()
+		return n
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	}
+	return 0
+}
@@ -0,0 +1,176 @@
+package sqldriver
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	gosybase "github.com/CatHood0/Go-Sybase"
+)
+
+// dateLayout is the format used by the bridge for date and time columns.
+const dateLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// rows iterates over the result sets of a response. The columns are those
+// reported by a columnar bridge, in the order of the query, or else the
+// sorted keys of the first row.
+type rows struct {
+	response *gosybase.RawResponse
+	set      int // current result set
+	row      int // next row of the set
+	columns  []string
+	types    []gosybase.ColumnType // nil if the bridge didn't report them
+}
+
+var (
+	_ driver.RowsNextResultSet              = (*rows)(nil)
+	_ driver.RowsColumnTypeDatabaseTypeName = (*rows)(nil)
+)
+
+func newRows(response *gosybase.RawResponse) *rows {
+	r := &rows{response: response}
+	r.load()
+	return r
+}
+
+// load reads the columns of the current result set.
+func (r *rows) load() {
+	r.row = 0
+	r.columns, r.types = nil, nil
+	if r.set < len(r.response.Columns) {
+		r.columns = r.response.Columns[r.set]
+	}
+	if r.set < len(r.response.ColumnTypes) {
+		r.types = r.response.ColumnTypes[r.set]
+	}
+	if r.columns == nil {
+		if set := r.current(); len(set) > 0 {
+			for column := range set[0] {
+				r.columns = append(r.columns, column)
+			}
+			sort.Strings(r.columns)
+		}
+	}
+	if r.columns == nil {
+		r.columns = []string{}
+	}
+}
+
+// current returns the rows of the current result set.
+func (r *rows) current() []map[string]any {
+	if len(r.response.ResultSets) == 0 {
+		if r.set == 0 {
+			return r.response.Results
+		}
+		return nil
+	}
+	if r.set < len(r.response.ResultSets) {
+		return r.response.ResultSets[r.set]
+	}
+	return nil
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	set := r.current()
+	if r.row >= len(set) {
+		return io.EOF
+	}
+	row := set[r.row]
+	r.row++
+
+	for i, column := range r.columns {
+		var columnType *gosybase.ColumnType
+		if i < len(r.types) {
+			columnType = &r.types[i]
+		}
+		value, err := driverValue(row[column], columnType)
+		if err != nil {
+			return fmt.Errorf("column %s: %w", column, err)
+		}
+		dest[i] = value
+	}
+	return nil
+}
+
+func (r *rows) HasNextResultSet() bool {
+	return r.set+1 < len(r.response.ResultSets)
+}
+
+func (r *rows) NextResultSet() error {
+	if !r.HasNextResultSet() {
+		return io.EOF
+	}
+	r.set++
+	r.load()
+	return nil
+}
+
+// ColumnTypeDatabaseTypeName returns the server type of the column (e.g.
+// "DATETIME"), or "" if the bridge didn't report it.
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	if index >= len(r.types) {
+		return ""
+	}
+	return strings.ToUpper(r.types[index].DatabaseType)
+}
+
+// driverValue converts a value of the response to a driver.Value. With the
+// column types reported, integers become int64, dates and times time.Time
+// and binary columns []byte, even without Config.TypedValues; decimals are
+// kept as strings so they don't lose digits.
+func driverValue(value any, columnType *gosybase.ColumnType) (driver.Value, error) {
+	switch v := value.(type) {
+	case nil, bool, string, time.Time, []byte, int64:
+		if s, ok := v.(string); ok && columnType != nil {
+			return typedString(s, *columnType)
+		}
+		return v, nil
+	case float64:
+		if columnType != nil && columnType.IsInteger() && v == math.Trunc(v) {
+			return int64(v), nil
+		}
+		return v, nil
+	case json.Number:
+		if columnType != nil && columnType.IsInteger() {
+			if n, err := v.Int64(); err == nil {
+				return n, nil
+			}
+		}
+		return v.String(), nil
+	case int:
+		return int64(v), nil
+	}
+	// nested JSON values of the mocks and unusual bridge types
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// typedString converts the dates and binary values the bridge sends as
+// strings when Config.TypedValues is not set.
+func typedString(value string, columnType gosybase.ColumnType) (driver.Value, error) {
+	switch {
+	case columnType.IsTemporal() && strings.Contains(value, "-"):
+		// TIME columns have no date and are kept as strings
+		return time.Parse(dateLayout, value)
+	case columnType.IsBinary():
+		return hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	}
+	return value, nil
+}
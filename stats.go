@@ -0,0 +1,42 @@
+package gosybase
+
+import (
+	"time"
+
+	sybase "github.com/CatHood0/Go-Sybase/internal"
+)
+
+type (
+	// PoolStats is the state of the bridge connection pools.
+	PoolStats = sybase.PoolStats
+	// ConnectionUse is a bridge connection checked out of its pool.
+	ConnectionUse = sybase.ConnectionUse
+)
+
+// Stats returns the state of the bridge connection pools, mirrored from the
+// checkout and checkin events the bridge reports. Each connection in use
+// carries the statement it is running, when known. Backends that do not
+// report their pools return zero stats.
+func (ds *Database) Stats() PoolStats {
+	tracker, ok := ds.db.(interface{ Stats() PoolStats })
+	if !ok {
+		return PoolStats{}
+	}
+	return tracker.Stats()
+}
+
+// LeakedConnections returns the connections checked out for longer than
+// threshold, typically transactions that were never committed or rolled back.
+//
+//	for _, conn := range db.LeakedConnections(5 * time.Minute) {
+//		log.Printf("connection %d held by transaction %d since %s", conn.ConnectionID, conn.TransID, conn.Since)
+//	}
+func (ds *Database) LeakedConnections(threshold time.Duration) []ConnectionUse {
+	var leaked []ConnectionUse
+	for _, use := range ds.Stats().InUse {
+		if time.Since(use.Since) > threshold {
+			leaked = append(leaked, use)
+		}
+	}
+	return leaked
+}
@@ -0,0 +1,93 @@
+package gosybase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+// Stmt is a query with ? placeholders that can be executed repeatedly
+// with different argument sets.
+//
+// The bridge doesn't support server-side prepared statements yet, so the
+// arguments are bound client-side as dialect literals on every execution.
+type Stmt struct {
+	db           *Database
+	query        string
+	placeholders int
+	built        bool // created by PrepareBuilder, skipping the strict mode checks
+}
+
+// Prepare creates a Stmt for a query using ? placeholders.
+func (ds *Database) Prepare(query string) (*Stmt, error) {
+	if query == "" {
+		return nil, errors.New("empty query")
+	}
+
+	return &Stmt{
+		db:           ds,
+		query:        query,
+		placeholders: builder.CountPlaceholders(query),
+	}, nil
+}
+
+// PrepareBuilder creates a Stmt from a builder running in args mode
+// (see UseArgs). The args returned by the builder are ignored; they are
+// supplied on each execution instead.
+func (ds *Database) PrepareBuilder(qb builder.QueryBuilder) (*Stmt, error) {
+	if qb == nil {
+		return nil, errors.New("nil query builder")
+	}
+
+	query, _, err := qb.BuildSQLWithArgs()
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := ds.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	stmt.built = true
+	return stmt, nil
+}
+
+// NumInput returns the number of placeholders in the statement.
+func (st *Stmt) NumInput() int {
+	return st.placeholders
+}
+
+// Query executes the statement with the given args and returns every row.
+func (st *Stmt) Query(args ...any) (*RawResponse, error) {
+	query, err := st.bind(args)
+	if err != nil {
+		return nil, err
+	}
+	return st.db.RawQueryContext(st.context(), query)
+}
+
+// Exec executes the statement with the given args.
+func (st *Stmt) Exec(args ...any) (any, error) {
+	query, err := st.bind(args)
+	if err != nil {
+		return nil, err
+	}
+	return st.db.ExecContext(st.context(), query)
+}
+
+// context returns the context of the executions, which skip the strict
+// mode checks for built statements like QueryBuilder.
+func (st *Stmt) context() context.Context {
+	if st.built {
+		return AllowUnsafe(context.Background())
+	}
+	return context.Background()
+}
+
+func (st *Stmt) bind(args []any) (string, error) {
+	if len(args) != st.placeholders {
+		return "", fmt.Errorf("statement expects %d args, got %d", st.placeholders, len(args))
+	}
+	return st.db.dialect.Bind(st.query, args...)
+}
@@ -0,0 +1,45 @@
+package gosybase
+
+import (
+	"context"
+
+	sybase "github.com/CatHood0/Go-Sybase/internal"
+)
+
+// ErrSuspiciousSQL is returned in strict mode (see SetStrict) for the raw
+// statements with unbalanced quotes, stacked statements or comment
+// sequences.
+var ErrSuspiciousSQL = sybase.ErrSuspiciousSQL
+
+type unsafeKey struct{}
+
+// SetStrict enables the strict mode, which refuses to send the raw
+// statements (RawQuery, Exec, and those of transactions and sessions)
+// showing patterns of SQL built by concatenation: unbalanced quotes,
+// stacked statements and comment sequences. It is a heuristic that nudges
+// towards the builders, whose statements are not checked, and prepared
+// statements (see Prepare); AllowUnsafe lets a legitimate statement
+// through. Helpers building their own batches, such as InsertStruct,
+// ReadText or Explain, only check the SQL fragments passed to them.
+func (ds *Database) SetStrict(strict bool) {
+	ds.strict = strict
+}
+
+// AllowUnsafe returns a context whose statements skip the strict mode
+// checks.
+//
+//	db.RawQueryContext(gosybase.AllowUnsafe(ctx), "SELECT 1; SELECT 2")
+func AllowUnsafe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unsafeKey{}, true)
+}
+
+// lint checks query in strict mode, unless ctx allows unsafe statements.
+func (ds *Database) lint(ctx context.Context, query string) error {
+	if !ds.strict {
+		return nil
+	}
+	if unsafe, _ := ctx.Value(unsafeKey{}).(bool); unsafe {
+		return nil
+	}
+	return sybase.LintSQL(query)
+}
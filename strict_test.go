@@ -0,0 +1,61 @@
+package gosybase_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	gosybase "github.com/CatHood0/Go-Sybase"
+	builder "github.com/CatHood0/Go-Sybase/builders"
+	"github.com/CatHood0/Go-Sybase/gosybasetest"
+)
+
+func TestStrictModeAllowsBuiltStatements(t *testing.T) {
+	bridge := gosybasetest.NewBridge().
+		On(`@@identity`, gosybasetest.Response{Rows: []map[string]any{{"id": 7.0}}}).
+		On(`datalength`, gosybasetest.Response{Rows: []map[string]any{{"length": 4.0}}}).
+		On(`READTEXT`, gosybasetest.Response{Rows: []map[string]any{{"body": "abcd"}}}).
+		On(`SHOWPLAN`, gosybasetest.Response{Messages: []string{"QUERY PLAN FOR STATEMENT 1"}})
+	db := bridge.Open(builder.DialectASE)
+	db.SetStrict(true)
+
+	if _, err := gosybase.InsertStruct(db, "accounts", &account{Name: "Ana"}); err != nil {
+		t.Fatalf("InsertStruct: %v", err)
+	}
+	if _, err := gosybase.NewRepository[account](db, "accounts", "id").Insert(&account{Name: "Eva"}); err != nil {
+		t.Fatalf("Repository.Insert: %v", err)
+	}
+	var body bytes.Buffer
+	if _, err := db.ReadText("documents", "body", "id = 10", &body); err != nil {
+		t.Fatalf("ReadText: %v", err)
+	}
+	if _, err := db.WriteText("documents", "body", "id = 10", strings.NewReader("it's -- done")); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	if _, err := db.Explain("SELECT * FROM orders WHERE id = 1"); err != nil {
+		t.Fatalf("Explain: %v", err)
+	}
+
+	anywhere := gosybasetest.NewBridge().Open(builder.DialectSQLAnywhere)
+	anywhere.SetStrict(true)
+	if err := anywhere.FindInBatches("SELECT id FROM orders ORDER BY id", 10, func([]map[string]any) error { return nil }); err != nil {
+		t.Fatalf("FindInBatches: %v", err)
+	}
+}
+
+func TestStrictModeChecksUserFragments(t *testing.T) {
+	db := gosybasetest.NewBridge().Open(builder.DialectASE)
+	db.SetStrict(true)
+
+	checks := map[string]error{}
+	_, checks["ReadText"] = db.ReadText("documents", "body", "id = 10; DROP TABLE documents", &bytes.Buffer{})
+	_, checks["WriteText"] = db.WriteText("documents", "body", "id = 10 --", strings.NewReader("x"))
+	_, checks["Explain"] = db.Explain("SELECT * FROM orders WHERE id = '1")
+	checks["FindInBatchesBy"] = db.FindInBatchesBy("SELECT id FROM orders /* x */", "id", 10, func([]map[string]any) error { return nil })
+	for name, err := range checks {
+		if !errors.Is(err, gosybase.ErrSuspiciousSQL) {
+			t.Errorf("%s: err = %v, want ErrSuspiciousSQL", name, err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package gosybase
+
+import (
+	"context"
+	"strings"
+)
+
+// maxTagLength bounds the comment added by WithTag.
+const maxTagLength = 128
+
+type tagKey struct{}
+
+// WithTag returns a context whose queries are prefixed with a comment
+// holding tag, making them identifiable in sysprocesses, the MDA tables
+// (monProcessSQLText, monSysStatement) and DBA traces. Characters other
+// than letters, digits, spaces and -_.:/@=, are replaced with an
+// underscore so the tag cannot close the comment.
+//
+//	ctx := gosybase.WithTag(ctx, "checkout-service:orderLookup")
+//	db.RawQueryContext(ctx, "SELECT * FROM orders WHERE id = 10")
+//	// /* checkout-service:orderLookup */ SELECT * FROM orders WHERE id = 10
+func WithTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, tagKey{}, sanitizeTag(tag))
+}
+
+// tagged prefixes query with the tag of ctx, if any.
+func tagged(ctx context.Context, query string) string {
+	tag, _ := ctx.Value(tagKey{}).(string)
+	if tag == "" {
+		return query
+	}
+	return "/* " + tag + " */ " + query
+}
+
+func sanitizeTag(tag string) string {
+	var sanitized strings.Builder
+	for _, r := range strings.TrimSpace(tag) {
+		if sanitized.Len() >= maxTagLength {
+			break
+		}
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', strings.ContainsRune(" -_.:/@=,", r):
+			sanitized.WriteRune(r)
+		default:
+			sanitized.WriteByte('_')
+		}
+	}
+	return sanitized.String()
+}
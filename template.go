@@ -0,0 +1,192 @@
+package gosybase
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+// SQLTemplate is a SQL statement with named {{param}} placeholders, for
+// SQL that lives in files rather than builders. Parameters are rendered
+// as escaped literals of the template dialect.
+type SQLTemplate struct {
+	sql     string
+	params  []string
+	dialect builder.Dialect
+}
+
+// Template parses a SQL statement with named parameters.
+//
+//	tpl, err := gosybase.Template("select * from t where id = {{id}}")
+//	sql, err := tpl.Render(map[string]any{"id": 5})
+//
+// Placeholders inside string literals and comments are left untouched.
+func Template(sql string) (*SQLTemplate, error) {
+	params, err := templateParams(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLTemplate{sql: sql, params: params}, nil
+}
+
+// TemplateFile reads and parses a SQL template from a file.
+func TemplateFile(path string) (*SQLTemplate, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read template %s: %w", path, err)
+	}
+	return Template(string(content))
+}
+
+// WithDialect sets the dialect used to render parameter literals.
+func (t *SQLTemplate) WithDialect(dialect builder.Dialect) *SQLTemplate {
+	t.dialect = dialect
+	return t
+}
+
+// withDialectCopy returns a copy using the dialect, leaving the template
+// untouched so it can be shared between databases.
+func (t *SQLTemplate) withDialectCopy(dialect builder.Dialect) *SQLTemplate {
+	clone := *t
+	clone.dialect = dialect
+	return &clone
+}
+
+// Params returns the distinct parameter names in order of appearance.
+func (t *SQLTemplate) Params() []string {
+	return append([]string(nil), t.params...)
+}
+
+// Render replaces every parameter with the literal of its value. Every
+// parameter must be present in params; a slice value renders as a
+// comma-separated list, useful for IN ({{ids}}).
+func (t *SQLTemplate) Render(params map[string]any) (string, error) {
+	var out strings.Builder
+	sql := t.sql
+	for i := 0; i < len(sql); i++ {
+		if end := skipComment(sql, i); end > i {
+			out.WriteString(sql[i:end])
+			i = end - 1
+			continue
+		}
+		if sql[i] == '\'' {
+			end := skipLiteral(sql, i)
+			out.WriteString(sql[i:end])
+			i = end - 1
+			continue
+		}
+
+		if !strings.HasPrefix(sql[i:], "{{") {
+			out.WriteByte(sql[i])
+			continue
+		}
+
+		end := strings.Index(sql[i:], "}}")
+		name := strings.TrimSpace(sql[i+2 : i+end])
+		value, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("missing template param %q", name)
+		}
+		out.WriteString(t.literal(value))
+		i += end + 1
+	}
+	return out.String(), nil
+}
+
+// literal renders a value, expanding slices (except []byte) into a list.
+func (t *SQLTemplate) literal(value any) string {
+	if _, isBytes := value.([]byte); isBytes || value == nil {
+		return t.dialect.Literal(value)
+	}
+
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() != reflect.Slice && reflected.Kind() != reflect.Array {
+		return t.dialect.Literal(value)
+	}
+
+	literals := make([]string, reflected.Len())
+	for i := range literals {
+		literals[i] = t.dialect.Literal(reflected.Index(i).Interface())
+	}
+	return strings.Join(literals, ", ")
+}
+
+// QueryTemplate renders the template with the database dialect and executes it.
+func (ds *Database) QueryTemplate(t *SQLTemplate, params map[string]any) (*RawResponse, error) {
+	sql, err := t.withDialectCopy(ds.dialect).Render(params)
+	if err != nil {
+		return nil, err
+	}
+	return ds.RawQuery(sql)
+}
+
+// templateParams validates the template and returns its parameter names.
+func templateParams(sql string) ([]string, error) {
+	var params []string
+	seen := map[string]bool{}
+	for i := 0; i < len(sql); i++ {
+		if end := skipComment(sql, i); end > i {
+			i = end - 1
+			continue
+		}
+		if sql[i] == '\'' {
+			i = skipLiteral(sql, i) - 1
+			continue
+		}
+		if !strings.HasPrefix(sql[i:], "{{") {
+			continue
+		}
+
+		end := strings.Index(sql[i:], "}}")
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed template param at position %d", i)
+		}
+		name := strings.TrimSpace(sql[i+2 : i+end])
+		if name == "" {
+			return nil, fmt.Errorf("empty template param at position %d", i)
+		}
+		if !seen[name] {
+			seen[name] = true
+			params = append(params, name)
+		}
+		i += end + 1
+	}
+	return params, nil
+}
+
+// skipLiteral returns the position after the string literal starting at start.
+func skipLiteral(sql string, start int) int {
+	for i := start + 1; i < len(sql); i++ {
+		if sql[i] != '\'' {
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == '\'' {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(sql)
+}
+
+// skipComment returns the position after the comment starting at start, or
+// start if there is none. A -- comment ends before its newline.
+func skipComment(sql string, start int) int {
+	switch {
+	case strings.HasPrefix(sql[start:], "--"):
+		if end := strings.IndexByte(sql[start:], '\n'); end >= 0 {
+			return start + end
+		}
+		return len(sql)
+	case strings.HasPrefix(sql[start:], "/*"):
+		if end := strings.Index(sql[start+2:], "*/"); end >= 0 {
+			return start + 2 + end + 2
+		}
+		return len(sql)
+	}
+	return start
+}
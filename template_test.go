@@ -0,0 +1,50 @@
+package gosybase_test
+
+import (
+	"slices"
+	"testing"
+
+	gosybase "github.com/CatHood0/Go-Sybase"
+	builder "github.com/CatHood0/Go-Sybase/builders"
+)
+
+func TestTemplateSkipsCommentsAndLiterals(t *testing.T) {
+	tests := []struct {
+		name   string
+		sql    string
+		params []string
+		want   string
+	}{
+		{"apostrophe in line comment", "-- don't change\nSELECT * FROM t WHERE id = {{id}} AND n = 'x'", []string{"id"}, "-- don't change\nSELECT * FROM t WHERE id = 5 AND n = 'x'"},
+		{"apostrophe in block comment", "SELECT * /* it's {{name}} */ FROM t WHERE id = {{id}}", []string{"id"}, "SELECT * /* it's {{name}} */ FROM t WHERE id = 5"},
+		{"param in literal", "SELECT '{{name}} --' AS a FROM t WHERE id = {{id}}", []string{"id"}, "SELECT '{{name}} --' AS a FROM t WHERE id = 5"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tpl, err := gosybase.Template(test.sql)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if params := tpl.Params(); !slices.Equal(params, test.params) {
+				t.Fatalf("Params = %q, want %q", params, test.params)
+			}
+			got, err := tpl.WithDialect(builder.DialectASE).Render(map[string]any{"id": 5})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Fatalf("Render = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTemplateRenderRequiresParams(t *testing.T) {
+	tpl, err := gosybase.Template("-- don't change\nSELECT * FROM t WHERE id = {{id}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tpl.Render(map[string]any{}); err == nil {
+		t.Fatal("Render succeeded without the id param")
+	}
+}
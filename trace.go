@@ -0,0 +1,24 @@
+package gosybase
+
+import "context"
+
+type traceIDKey struct{}
+
+// WithTraceID returns a context whose queries carry traceID to the bridge,
+// which adds it to the logs of the statement ([traceId=...] lines, logged
+// with a traceId attribute) and to the active query, so a slow statement in
+// the bridge or JDBC logs can be tied back to the request of the
+// application. The ID is sanitized like the tags of WithTag. Backends
+// without trace IDs ignore it.
+//
+//	ctx := gosybase.WithTraceID(r.Context(), r.Header.Get("X-Request-ID"))
+//	db.RawQueryContext(ctx, "SELECT * FROM orders WHERE id = 10")
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, sanitizeTag(traceID))
+}
+
+// traceIDOf returns the trace ID of ctx, if any.
+func traceIDOf(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey{}).(string)
+	return traceID
+}
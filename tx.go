@@ -0,0 +1,121 @@
+package gosybase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sybase "github.com/CatHood0/Go-Sybase/internal"
+)
+
+// ErrConnectionChanged is returned when the bridge runs a statement of a
+// transaction or session on a different connection than the previous ones,
+// which would lose its temporary tables and @@identity. The transaction is
+// no longer usable.
+var ErrConnectionChanged = sybase.ErrConnectionChanged
+
+// ErrBridgeRestarted is returned by the queries that were running when the
+// bridge was restarted: by Reconnect, the keepalive, or the watchdog after a
+// hung query ignored its cancellation (Config.WatchdogGrace).
+var ErrBridgeRestarted = sybase.ErrBridgeRestarted
+
+// ErrReadOnly is returned for the write statements (INSERT, UPDATE, DELETE,
+// SELECT INTO, EXEC and DDL) of a connection with Config.ReadOnly set. They
+// are rejected before reaching the server.
+var ErrReadOnly = sybase.ErrReadOnly
+
+// Tx is a transaction. Its statements run on a connection of the bridge's
+// transaction pool with auto-commit disabled, until Commit or Rollback
+// releases it. The bridge reports the connection of each statement, so
+// connection-scoped state (temporary tables, @@identity) is reliable.
+type Tx struct {
+	ds         *Database
+	tx         BackendTx
+	savepoint  string // set on transactions started by Tx.Begin
+	savepoints *int   // savepoints created in the transaction, shared with nested ones
+}
+
+// Begin starts a transaction.
+func (ds *Database) Begin() (*Tx, error) {
+	tx, err := ds.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{ds: ds, tx: tx, savepoints: new(int)}, nil
+}
+
+// Begin starts a nested transaction using a savepoint (SAVE TRANSACTION).
+// Its Rollback only undoes the statements run since it started, and its
+// Commit does nothing: the changes are committed with the outer transaction.
+func (tx *Tx) Begin() (*Tx, error) {
+	*tx.savepoints++
+	savepoint := fmt.Sprintf("gosybase_sp%d", *tx.savepoints)
+	if _, err := tx.Exec("SAVE TRANSACTION " + savepoint); err != nil {
+		return nil, err
+	}
+	return &Tx{ds: tx.ds, tx: tx.tx, savepoint: savepoint, savepoints: tx.savepoints}, nil
+}
+
+// RawQuery executes query inside the transaction, returning every row.
+func (tx *Tx) RawQuery(query string) (*RawResponse, error) {
+	query, err := tx.ds.rewrite(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := tx.tx.Raw(query)
+	if err != nil {
+		tx.ds.Logger().Error("query failed", "error", err)
+		return nil, fmt.Errorf("unable to execute the query by: %w", err)
+	}
+	return response, truncated(response)
+}
+
+// QueryFirst executes query inside the transaction, returning its first row.
+func (tx *Tx) QueryFirst(query string) (map[string]any, error) {
+	response, err := tx.RawQuery(query)
+	if err != nil && !errors.Is(err, ErrTruncated) {
+		return map[string]any{}, err
+	}
+	if len(response.Results) < 1 {
+		return map[string]any{}, fmt.Errorf("no result was found")
+	}
+	return response.Results[0], nil
+}
+
+// Query executes query inside the transaction, calling callback for every row.
+func (tx *Tx) Query(query string, callback func(map[string]any) error) error {
+	response, err := tx.RawQuery(query)
+	if err != nil && !errors.Is(err, ErrTruncated) {
+		return err
+	}
+	defer tx.ds.release(response)
+	for _, result := range response.Results {
+		if err := callback(result); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Exec executes a statement inside the transaction.
+func (tx *Tx) Exec(query string) (any, error) {
+	return tx.RawQuery(query)
+}
+
+// Commit commits the transaction.
+func (tx *Tx) Commit() error {
+	if tx.savepoint != "" {
+		return nil
+	}
+	return tx.tx.Commit()
+}
+
+// Rollback aborts the transaction.
+func (tx *Tx) Rollback() error {
+	if tx.savepoint != "" {
+		_, err := tx.Exec("ROLLBACK TRANSACTION " + tx.savepoint)
+		return err
+	}
+	return tx.tx.Rollback()
+}
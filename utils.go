@@ -1,20 +1,378 @@
 package gosybase
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	builder "github.com/CatHood0/Go-Sybase/builders"
 )
 
+// mapToStruct maps a result row into a new T, matching columns to fields
+// through their `db` tags (see builder.StructFields). Columns without a
+// matching field are ignored.
 func mapToStruct[T any](value map[string]any) (*T, error) {
 	var target T
+	if err := assignStruct(reflect.ValueOf(&target).Elem(), value); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// assignStruct sets every field of dest whose column is present in row.
+func assignStruct(dest reflect.Value, row map[string]any) error {
+	destType := dest.Type()
+	for i := range destType.NumField() {
+		field := destType.Field(i)
+		tag := field.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			if err := assignStruct(dest.Field(i), row); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		column, _, _ := strings.Cut(tag, ",")
+		if column == "" {
+			column = field.Name
+		}
+		value, ok := row[column]
+		if !ok {
+			continue
+		}
+		if err := convertAssign(dest.Field(i), value); err != nil {
+			return fmt.Errorf("unable to assign column %q to field %s: %w", column, field.Name, err)
+		}
+	}
+	return nil
+}
+
+// convertAssign stores a value decoded from the bridge JSON (nil, bool,
+// float64, string, []any or map[string]any, plus time.Time, []byte and
+// json.Number with Config.TypedValues) into dest, converting it to the
+// destination type.
+func convertAssign(dest reflect.Value, value any) error {
+	if value == nil {
+		dest.SetZero()
+		return nil
+	}
+
+	if dest.Kind() == reflect.Pointer {
+		target := reflect.New(dest.Type().Elem())
+		if err := convertAssign(target.Elem(), value); err != nil {
+			return err
+		}
+		dest.Set(target)
+		return nil
+	}
+
+	if dest.Type() == reflect.TypeFor[time.Time]() {
+		date, err := parseTime(value)
+		if err != nil {
+			return err
+		}
+		dest.Set(reflect.ValueOf(date))
+		return nil
+	}
+
+	source := reflect.ValueOf(value)
+	switch dest.Kind() {
+	case reflect.Interface:
+		dest.Set(source)
+		return nil
+	case reflect.String:
+		switch v := value.(type) {
+		case string:
+			dest.SetString(v)
+		case float64:
+			dest.SetString(strconv.FormatFloat(v, 'f', -1, 64))
+		case json.Number:
+			dest.SetString(v.String())
+		case time.Time:
+			dest.SetString(v.Format(time.RFC3339Nano))
+		case []byte:
+			dest.SetString(string(v))
+		default:
+			dest.SetString(fmt.Sprint(v))
+		}
+		return nil
+	case reflect.Bool:
+		switch v := value.(type) {
+		case bool:
+			dest.SetBool(v)
+		case float64:
+			dest.SetBool(v != 0)
+		case string:
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				return err
+			}
+			dest.SetBool(parsed)
+		default:
+			return fmt.Errorf("cannot convert %T to bool", value)
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := toInteger(value)
+		if err != nil {
+			return err
+		}
+		if dest.OverflowInt(number) {
+			return fmt.Errorf("value %v overflows %s", value, dest.Type())
+		}
+		dest.SetInt(number)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, err := toUnsigned(value)
+		if err != nil {
+			return err
+		}
+		if dest.OverflowUint(number) {
+			return fmt.Errorf("value %v overflows %s", value, dest.Type())
+		}
+		dest.SetUint(number)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		number, err := toFloat64(value)
+		if err != nil {
+			return err
+		}
+		dest.SetFloat(number)
+		return nil
+	case reflect.Slice:
+		if dest.Type().Elem().Kind() == reflect.Uint8 {
+			data, err := toBytes(value)
+			if err != nil {
+				return err
+			}
+			dest.SetBytes(data)
+			return nil
+		}
+	}
+
+	if source.Type().AssignableTo(dest.Type()) {
+		dest.Set(source)
+		return nil
+	}
+	if source.Type().ConvertibleTo(dest.Type()) {
+		dest.Set(source.Convert(dest.Type()))
+		return nil
+	}
+
+	// structs, maps and slices are decoded through JSON
 	jsonData, err := json.Marshal(value)
 	if err != nil {
-		return nil, fmt.Errorf("Error serializing map: %v", err)
+		return fmt.Errorf("error serializing value: %v", err)
 	}
+	return json.Unmarshal(jsonData, dest.Addr().Interface())
+}
 
-	if err := json.Unmarshal(jsonData, &target); err != nil {
-		return nil, fmt.Errorf("Error while deserializing map to struct: %v", err)
+// toBytes converts a binary column value into bytes. The bridge sends
+// binary, varbinary and image columns as 0x hex strings; older bridges
+// send them as arrays of signed bytes. Other strings are returned as is.
+func toBytes(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return bytes.Clone(v), nil
+	case string:
+		if digits, ok := strings.CutPrefix(v, "0x"); ok {
+			if data, err := hex.DecodeString(digits); err == nil {
+				return data, nil
+			}
+		}
+		return []byte(v), nil
+	case []any:
+		data := make([]byte, len(v))
+		for i, item := range v {
+			number, err := toFloat64(item)
+			if err != nil {
+				return nil, err
+			}
+			data[i] = byte(int64(number))
+		}
+		return data, nil
 	}
+	return nil, fmt.Errorf("cannot convert %T to []byte", value)
+}
 
-	return &target, nil
+// toFloat64 converts a numeric JSON value (or numeric string) into a float64.
+func toFloat64(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	case json.Number:
+		return v.Float64()
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(reflected.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(reflected.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return reflected.Float(), nil
+	}
+	return 0, fmt.Errorf("cannot convert %T to a number", value)
+}
+
+// toInteger converts a number of the bridge into an int64. Strings and
+// json.Number are parsed as integers rather than through float64, so BIGINT
+// values above 2^53 keep every digit. Fractional values are an error instead
+// of being truncated.
+func toInteger(value any) (int64, error) {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		return parseInt64(v)
+	case json.Number:
+		return parseInt64(v.String())
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflected.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if reflected.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("value %v overflows int64", value)
+		}
+		return int64(reflected.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return floatToInt64(reflected.Float())
+	}
+	return 0, fmt.Errorf("cannot convert %T to an integer", value)
+}
+
+// toUnsigned converts a number of the bridge into a uint64, like toInteger.
+func toUnsigned(value any) (uint64, error) {
+	switch v := value.(type) {
+	case string:
+		if number, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64); err == nil {
+			return number, nil
+		}
+	case json.Number:
+		if number, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return number, nil
+		}
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflected.Uint(), nil
+	}
+	number, err := toInteger(value)
+	if err != nil {
+		return 0, err
+	}
+	if number < 0 {
+		return 0, fmt.Errorf("negative value %v for an unsigned integer", value)
+	}
+	return uint64(number), nil
+}
+
+// parseInt64 parses an integer, accepting decimals with a zero fractional
+// part such as NUMERIC(10,2) values.
+func parseInt64(text string) (int64, error) {
+	text = strings.TrimSpace(text)
+	number, err := strconv.ParseInt(text, 10, 64)
+	if err == nil || errors.Is(err, strconv.ErrRange) {
+		return number, err
+	}
+	if whole, fraction, ok := strings.Cut(text, "."); ok && strings.Trim(fraction, "0") == "" {
+		return strconv.ParseInt(whole, 10, 64)
+	}
+	float, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, err
+	}
+	return floatToInt64(float)
+}
+
+// floatToInt64 converts a whole float64 into an int64.
+func floatToInt64(number float64) (int64, error) {
+	if number != math.Trunc(number) {
+		return 0, fmt.Errorf("value %v is not an integer", number)
+	}
+	if number < math.MinInt64 || number >= math.MaxInt64 {
+		return 0, fmt.Errorf("value %v overflows int64", number)
+	}
+	return int64(number), nil
+}
+
+// dateLayouts are the formats the bridge uses for date and time columns.
+var dateLayouts = []string{
+	"2006-01-02T15:04:05.000Z07:00",
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.000",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"15:04:05",
+}
+
+// parseTime converts a date value sent by the bridge into a time.Time.
+func parseTime(value any) (time.Time, error) {
+	if date, ok := value.(time.Time); ok {
+		return date, nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("cannot convert %T to time.Time", value)
+	}
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, str); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unknown date format %q", str)
+}
+
+// buildQuery turns a builder into the SQL sent to the bridge. The bridge
+// doesn't bind parameters, so any ? placeholders are replaced client-side
+// with the dialect literals of their args.
+func buildQuery(qb builder.QueryBuilder, dialect builder.Dialect) (string, error) {
+	if qb == nil {
+		return "", errors.New("nil query builder")
+	}
+
+	query, args, err := qb.BuildSQLWithArgs()
+	if err != nil {
+		return "", err
+	}
+
+	if query == "" {
+		return "", errors.New("query builder produced an empty query")
+	}
+
+	if len(args) == 0 {
+		return query, nil
+	}
+	return dialect.Bind(query, args...)
 }
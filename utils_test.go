@@ -0,0 +1,63 @@
+package gosybase
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestConvertAssignIntegers(t *testing.T) {
+	tests := []struct {
+		value any
+		want  int64
+	}{
+		{json.Number("9007199254740993"), 9007199254740993},
+		{"9223372036854775807", 9223372036854775807},
+		{json.Number("-42"), -42},
+		{json.Number("12.00"), 12},
+		{json.Number("1e3"), 1000},
+		{12.0, 12},
+		{true, 1},
+	}
+	for _, test := range tests {
+		var got int64
+		if err := convertAssign(reflect.ValueOf(&got).Elem(), test.value); err != nil {
+			t.Fatalf("convertAssign(%#v): %v", test.value, err)
+		}
+		if got != test.want {
+			t.Fatalf("convertAssign(%#v) = %d, want %d", test.value, got, test.want)
+		}
+	}
+}
+
+func TestConvertAssignUnsigned(t *testing.T) {
+	var got uint64
+	if err := convertAssign(reflect.ValueOf(&got).Elem(), json.Number("18446744073709551615")); err != nil {
+		t.Fatal(err)
+	}
+	if got != 18446744073709551615 {
+		t.Fatalf("got %d", got)
+	}
+	if err := convertAssign(reflect.ValueOf(&got).Elem(), json.Number("-1")); err == nil {
+		t.Fatal("negative value assigned to uint64")
+	}
+}
+
+func TestConvertAssignRejectsFractionsAndOverflow(t *testing.T) {
+	tests := []struct {
+		dest  any
+		value any
+	}{
+		{new(int), json.Number("12.7")},
+		{new(int), "12.7"},
+		{new(int), 12.7},
+		{new(int8), json.Number("300")},
+		{new(int64), json.Number("9223372036854775808")},
+		{new(uint), 1.5},
+	}
+	for _, test := range tests {
+		if err := convertAssign(reflect.ValueOf(test.dest).Elem(), test.value); err == nil {
+			t.Fatalf("convertAssign(%T, %#v) succeeded", test.dest, test.value)
+		}
+	}
+}